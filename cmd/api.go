@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"pls7-cli/internal/api"
+	"pls7-cli/internal/config"
+	"pls7-cli/internal/util"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiPort      int           // To hold the --port flag value of the api command
+	apiDevMode   bool          // To hold the --dev flag value of the api command
+	apiRetention time.Duration // To hold the --keep-finished flag value of the api command
+)
+
+// apiCmd represents the api subcommand
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Serve games over an HTTP/JSON REST API",
+	Long: `Start an HTTP server that hosts games in memory.
+Create a game with "POST /games", then act for a seat with "POST /games/{id}/actions" using the seat's token.
+Finished games are kept for --keep-finished and then removed.`,
+	Run: runAPI,
+}
+
+// runAPI serves the REST API until the process is stopped.
+func runAPI(_ *cobra.Command, _ []string) {
+	util.InitLogger(apiDevMode)
+
+	registry := api.NewRegistry(apiRetention)
+	defer registry.Close()
+
+	addr := fmt.Sprintf(":%d", apiPort)
+	fmt.Printf("Serving the game API on %s\n", addr)
+	if err := http.ListenAndServe(addr, api.NewHandler(registry, config.LoadGameRulesFromOptions)); err != nil {
+		logrus.Fatalf("API server stopped: %v", err)
+	}
+}
+
+func init() {
+	apiCmd.Flags().IntVarP(&apiPort, "port", "p", 8080, "HTTP port to listen on.")
+	apiCmd.Flags().BoolVar(&apiDevMode, "dev", false, "Enable development mode for verbose logging.")
+	apiCmd.Flags().DurationVar(&apiRetention, "keep-finished", 10*time.Minute, "How long finished games stay available before they are removed.")
+
+	apiCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if apiRetention <= 0 {
+			return fmt.Errorf("keep-finished는 0보다 커야 합니다. 입력값: %s", apiRetention)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"pls7-cli/internal/bot"
+	"pls7-cli/pkg/engine"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	botSpecs   []string      // To hold the --bot flag values ("seat=command")
+	botTimeout time.Duration // To hold the --bot-timeout flag value
+)
+
+// parseBotSpecs parses "seat=command" specifications into the command line of
+// the bot for each seat. The command may include arguments separated by spaces.
+func parseBotSpecs(specs []string) (map[int][]string, error) {
+	bots := make(map[int][]string)
+	for _, spec := range specs {
+		seatStr, command, found := strings.Cut(spec, "=")
+		seat, err := strconv.Atoi(strings.TrimSpace(seatStr))
+		args := strings.Fields(command)
+		if !found || err != nil || len(args) == 0 {
+			return nil, fmt.Errorf("bot은 seat=command 형식이어야 합니다. 입력값: %q", spec)
+		}
+		if seat < 1 || seat > 5 {
+			return nil, fmt.Errorf("bot seat은 1에서 5 사이여야 합니다. 입력값: %d", seat)
+		}
+		if _, ok := bots[seat]; ok {
+			return nil, fmt.Errorf("bot seat이 중복되었습니다: %d", seat)
+		}
+		bots[seat] = args
+	}
+	return bots, nil
+}
+
+// startBots launches the bots given by the --bot flag and seats them, wrapping
+// the given provider so it only handles the other seats. Bot diagnostics on
+// stderr are shown in dev mode only.
+func startBots(g *engine.Game, fallback engine.ActionProvider) (*bot.Provider, error) {
+	specs, err := parseBotSpecs(botSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &bot.Provider{Bots: make(map[int]*bot.Bot), Fallback: fallback}
+	for seat, args := range specs {
+		if seat >= len(g.Players) || !g.Players[seat].IsCPU {
+			provider.Close()
+			return nil, fmt.Errorf("seat %d is not a CPU seat", seat)
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		if devMode {
+			cmd.Stderr = os.Stderr
+		}
+		b, err := bot.Start(cmd, botTimeout)
+		if err != nil {
+			provider.Close()
+			return nil, err
+		}
+		provider.Bots[seat] = b
+		g.Players[seat].Name = fmt.Sprintf("BOT %d", seat)
+	}
+	return provider, nil
+}
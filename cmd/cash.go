@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"pls7-cli/internal/cli"
+	"pls7-cli/pkg/engine"
+	"strconv"
+	"strings"
+)
+
+var (
+	cashGame bool // To hold the --cash flag value
+	minBuyIn int  // To hold the --min-buy-in flag value (0 means half of --initial-chips)
+	maxBuyIn int  // To hold the --max-buy-in flag value (0 means --initial-chips)
+)
+
+// cashRules returns the buy-in limits of the --min-buy-in and --max-buy-in
+// flags. Players buy in for --initial-chips.
+func cashRules() engine.CashRules {
+	rules := engine.CashRules{BuyIn: initialChips, MinBuyIn: minBuyIn, MaxBuyIn: maxBuyIn}
+	if rules.MinBuyIn == 0 {
+		rules.MinBuyIn = max(rules.BuyIn/2, 1)
+	}
+	if rules.MaxBuyIn == 0 {
+		rules.MaxBuyIn = rules.BuyIn
+	}
+	return rules
+}
+
+// bustedHumans returns the human players who ran out of chips in the hand
+// just played, before CleanupHand takes them out of the game.
+func bustedHumans(g *engine.Game) []int {
+	var seats []int
+	for i, p := range g.Players {
+		if !p.IsCPU && p.IsActive() && p.Chips == 0 {
+			seats = append(seats, i)
+		}
+	}
+	return seats
+}
+
+// offerRebuys asks the human players who busted in a cash game whether they
+// rebuy. Those who do not stay out until they buy chips with "buy".
+func offerRebuys(g *engine.Game, seats []int) {
+	for _, seat := range seats {
+		p := g.Players[seat]
+		for {
+			fmt.Printf("%s is out of chips. Rebuy for how many chips (%s-%s, ENTER for %s, 'n' to leave the table)? > ",
+				p.Name, cli.FormatNumber(g.Cash.MinBuyIn), cli.FormatNumber(g.Cash.MaxBuyIn), cli.FormatNumber(g.Cash.BuyIn))
+			input := strings.TrimSpace(strings.ToLower(cli.ReadLine()))
+			if input == "n" {
+				break
+			}
+			amount := g.Cash.BuyIn
+			if input != "" {
+				var err error
+				if amount, err = strconv.Atoi(input); err != nil {
+					fmt.Printf("❌ Invalid amount: %s\n", input)
+					continue
+				}
+			}
+			if err := g.BuyIn(seat, amount); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				continue
+			}
+			fmt.Printf("✅ %s rebuys for %s chips.\n", p.Name, cli.FormatNumber(amount))
+			break
+		}
+	}
+}
+
+// cashCommand carries out a between-hands command of a cash game: "buy
+// [name] [amount]" rebuys or tops up, by default to the maximum buy-in,
+// "out [name]" sits out and "in [name]" sits back in. Without a name, the
+// command is for the first human player. It reports whether the input was
+// one of these commands.
+func cashCommand(g *engine.Game, input string) bool {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || (fields[0] != "buy" && fields[0] != "out" && fields[0] != "in") {
+		return false
+	}
+	seat, args := -1, fields[1:]
+	for i, p := range g.Players {
+		if p.IsCPU {
+			continue
+		}
+		if len(args) > 0 && strings.EqualFold(p.Name, args[0]) {
+			seat, args = i, args[1:]
+			break
+		}
+		if seat < 0 {
+			seat = i
+		}
+	}
+	if seat < 0 {
+		fmt.Println("❌ There is no human player at the table.")
+		return true
+	}
+	p := g.Players[seat]
+
+	var err error
+	switch fields[0] {
+	case "buy":
+		amount := g.Cash.MaxBuyIn - p.Chips
+		if len(args) > 0 {
+			if amount, err = strconv.Atoi(args[0]); err != nil {
+				fmt.Printf("❌ Invalid amount: %s\n", args[0])
+				return true
+			}
+		}
+		if err = g.BuyIn(seat, amount); err == nil {
+			fmt.Printf("✅ %s buys %s chips and has %s.\n", p.Name, cli.FormatNumber(amount), cli.FormatNumber(p.Chips))
+		}
+	case "out":
+		if err = g.SitOut(seat); err == nil {
+			fmt.Printf("✅ %s is sitting out.\n", p.Name)
+		}
+	case "in":
+		if err = g.SitIn(seat); err == nil {
+			fmt.Printf("✅ %s is dealt in from the next hand.\n", p.Name)
+		}
+	}
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return true
+}
+
+// printSessionResults prints the players' profits and losses in a cash game.
+func printSessionResults(g *engine.Game) {
+	for _, line := range cli.FormatSessionResults(g.SessionResults()) {
+		fmt.Println(line)
+	}
+}
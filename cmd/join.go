@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"pls7-cli/internal/cli"
+	"pls7-cli/internal/network"
+	"pls7-cli/internal/util"
+	"pls7-cli/pkg/engine"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	joinName    string // To hold the --name flag value of the join command
+	joinDevMode bool   // To hold the --dev flag value of the join command
+)
+
+// joinCmd represents the join subcommand
+var joinCmd = &cobra.Command{
+	Use:   "join [ip:port]",
+	Short: "Join a multiplayer table hosted with 'pls7 serve'",
+	Long:  `Connect to a table hosted with "pls7 serve" and play from this terminal.`,
+	Args:  cobra.ExactArgs(1),
+	Run:   runClient,
+}
+
+// runClient joins a remote table and renders the messages it receives until the game is over.
+func runClient(_ *cobra.Command, args []string) {
+	util.InitLogger(joinDevMode)
+
+	client, err := network.Dial(args[0], joinName)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+	fmt.Printf("Joined the table as %s (seat %d). Waiting for the game to start...\n", client.Name, client.Seat+1)
+
+	for {
+		msg, err := client.Receive()
+		if err != nil {
+			fmt.Printf("❌ Lost connection to the server: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch msg.Type {
+		case network.MsgGameState:
+			var view engine.PlayerView
+			if err := msg.Decode(&view); err == nil {
+				cli.DisplayPlayerView(&view)
+			}
+		case network.MsgActionRequest:
+			var view engine.PlayerView
+			if err := msg.Decode(&view); err != nil || view.LegalActions == nil {
+				continue
+			}
+			cli.DisplayPlayerView(&view)
+			if err := promptAndSend(client, &view); err != nil {
+				fmt.Printf("❌ Failed to send action: %v\n", err)
+			}
+		case network.MsgAction:
+			var event engine.ActionEvent
+			if err := msg.Decode(&event); err == nil {
+				fmt.Println(cli.FormatActionEvent(&event))
+			}
+		case network.MsgBlinds:
+			var event engine.BlindEvent
+			if err := msg.Decode(&event); err == nil {
+				fmt.Println(cli.FormatBlindEvent(&event))
+			}
+		case network.MsgHandResult:
+			var result network.HandResultData
+			if err := msg.Decode(&result); err == nil {
+				for _, line := range cli.FormatHandEnd(result.View, &result.End) {
+					fmt.Println(line)
+				}
+			}
+		case network.MsgStandings:
+			var standings []engine.Standing
+			if err := msg.Decode(&standings); err == nil {
+				for _, line := range cli.FormatStandings(standings) {
+					fmt.Println(line)
+				}
+			}
+		case network.MsgInfo, network.MsgError, network.MsgGameOver:
+			var text network.TextData
+			if err := msg.Decode(&text); err != nil {
+				continue
+			}
+			if msg.Type == network.MsgError {
+				fmt.Printf("❌ %s\n", text.Text)
+			} else {
+				fmt.Println(text.Text)
+			}
+			if msg.Type == network.MsgGameOver {
+				return
+			}
+		}
+	}
+}
+
+// promptAndSend asks for an action and sends it to the server. If the server
+// has set a time limit, the prompt gives up once it has passed, and the server
+// acts on the player's behalf.
+func promptAndSend(client *network.Client, view *engine.PlayerView) error {
+	ctx := context.Background()
+	if view.TimeLeftMs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(view.TimeLeftMs)*time.Millisecond)
+		defer cancel()
+	}
+	action, err := cli.PromptForLegalAction(ctx, *view.LegalActions)
+	if err != nil {
+		return nil // Time is up; the server has already acted for us.
+	}
+	return client.SendAction(action)
+}
+
+func init() {
+	joinCmd.Flags().StringVarP(&joinName, "name", "n", "", "Name to use at the table.")
+	joinCmd.Flags().BoolVar(&joinDevMode, "dev", false, "Enable development mode for verbose logging.")
+}
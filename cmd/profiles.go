@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"pls7-cli/pkg/engine"
+	"strconv"
+	"strings"
+)
+
+var (
+	aiProfileFiles []string // To hold the --ai-profiles flag values (YAML files with extra AI profiles)
+	cpuSpecs       []string // To hold the --cpu flag values ("seat=profile")
+)
+
+// parseCPUSpecs parses "seat=profile" specifications into the profile name for
+// each seat.
+func parseCPUSpecs(specs []string) (map[int]string, error) {
+	profiles := make(map[int]string)
+	for _, spec := range specs {
+		seatStr, name, found := strings.Cut(spec, "=")
+		seat, err := strconv.Atoi(strings.TrimSpace(seatStr))
+		name = strings.TrimSpace(name)
+		if !found || err != nil || name == "" {
+			return nil, fmt.Errorf("cpu는 seat=profile 형식이어야 합니다. 입력값: %q", spec)
+		}
+		if seat < 1 || seat > 5 {
+			return nil, fmt.Errorf("cpu seat은 1에서 5 사이여야 합니다. 입력값: %d", seat)
+		}
+		if _, ok := profiles[seat]; ok {
+			return nil, fmt.Errorf("cpu seat이 중복되었습니다: %d", seat)
+		}
+		profiles[seat] = name
+	}
+	return profiles, nil
+}
+
+// applyAIProfiles loads the AI profiles of the --ai-profiles files and gives the
+// CPU players their profiles: the line-up of the game's difficulty for a new
+// game, then the profiles chosen with the --cpu flag. Loaded games keep their
+// saved profiles unless --cpu chooses others.
+func applyAIProfiles(g *engine.Game, newGame bool) error {
+	c, err := engine.LoadAIConfig(aiProfileFiles...)
+	if err != nil {
+		return err
+	}
+	if newGame {
+		if err := g.AssignAIProfiles(c); err != nil {
+			return err
+		}
+	}
+
+	specs, err := parseCPUSpecs(cpuSpecs)
+	if err != nil {
+		return err
+	}
+	for seat, name := range specs {
+		profile, err := c.Profile(name)
+		if err != nil {
+			return err
+		}
+		if err := g.SetAIProfile(seat, profile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -17,24 +18,44 @@ import (
 )
 
 var (
-	ruleStr         string // To hold the --rule flag value (load rules/{rule}.yml when the game starts)
-	difficultyStr   string // To hold the flag value
-	devMode         bool   // To hold the --dev flag value
-	showOuts        bool   // To hold the --outs flag value (this does not work if devMode is true, as it will always show outs in dev mode)
-	blindUpInterval int    // To hold the --blind-up flag value
-	initialChips    int    // To hold the --initial-chips flag value
-	smallBlind      int    // To hold the --small-blind flag value
-	bigBlind        int    // To hold the --big-blind flag value
-	loadGame        bool   // To hold the --load flag value (load saved game)
-	loadFile        string // To hold the --load-file flag value (specific filename to load)
-	saveDir         string // To hold the --save-dir flag value (directory for save files)
+	ruleStr         string        // To hold the --rule flag value (load rules/{rule}.yml when the game starts)
+	difficultyStr   string        // To hold the flag value
+	devMode         bool          // To hold the --dev flag value
+	showOuts        bool          // To hold the --outs flag value (this does not work if devMode is true, as it will always show outs in dev mode)
+	blindUpInterval int           // To hold the --blind-up flag value
+	initialChips    int           // To hold the --initial-chips flag value
+	smallBlind      int           // To hold the --small-blind flag value
+	bigBlind        int           // To hold the --big-blind flag value
+	ante            int           // To hold the --ante flag value (0 means no ante)
+	bigBlindAnte    bool          // To hold the --big-blind-ante flag value
+	bombPotAnte     int           // To hold the --bomb-pot-ante flag value (0 means the big blind)
+	buttonStr       string        // To hold the --button flag value (moving or dead)
+	runItTimes      int           // To hold the --run-it flag value (0 or 1 means the board is dealt once)
+	rakePercent     float64       // To hold the --rake flag value (percent of each pot, 0 means no rake)
+	rakeCap         int           // To hold the --rake-cap flag value (0 means no cap)
+	noFlopNoDrop    bool          // To hold the --no-flop-no-drop flag value
+	timeCharge      int           // To hold the --time-charge flag value (0 means no time charge)
+	timeChargeHands int           // To hold the --time-charge-hands flag value (0 means every hand)
+	loadGame        bool          // To hold the --load flag value (load saved game)
+	loadFile        string        // To hold the --load-file flag value (specific filename to load)
+	saveDir         string        // To hold the --save-dir flag value (directory for save files)
+	hotSeatNames    []string      // To hold the --hotseat flag value (names of humans sharing this terminal)
+	decisionTime    time.Duration // To hold the --decision-time flag value (0 means no time limit)
+	timeBank        time.Duration // To hold the --time-bank flag value
+	structureStr    string        // To hold the --structure flag value (tournament structure name or YAML file)
 )
 
 // CLIActionProvider implements the ActionProvider interface using the CLI.
 type CLIActionProvider struct{}
 
-func (p *CLIActionProvider) GetAction(g *engine.Game, _ *engine.Player, _ *rand.Rand) engine.PlayerAction {
-	return cli.PromptForAction(g)
+func (p *CLIActionProvider) GetAction(g *engine.Game, pl *engine.Player, r *rand.Rand) engine.PlayerAction {
+	action, _ := p.GetActionContext(context.Background(), g, pl, r)
+	return action
+}
+
+// GetActionContext prompts for an action until the player's time is up.
+func (p *CLIActionProvider) GetActionContext(ctx context.Context, g *engine.Game, _ *engine.Player, _ *rand.Rand) (engine.PlayerAction, error) {
+	return cli.PromptForAction(ctx, g)
 }
 
 // CPUActionProvider implements the ActionProvider interface for CPU players.
@@ -44,16 +65,97 @@ func (p *CPUActionProvider) GetAction(g *engine.Game, pl *engine.Player, r *rand
 	return g.GetCPUAction(pl, r)
 }
 
+// AgreeToRunouts lets CPU players agree to run the board more than once,
+// which changes the variance of a hand but not what it is worth.
+func (p *CPUActionProvider) AgreeToRunouts(_ *engine.Game, _ *engine.Player, _ int) bool {
+	return true
+}
+
 // CombinedActionProvider decides which provider to use based on player type.
 type CombinedActionProvider struct{}
 
 // GetAction method for CombinedActionProvider
 func (p *CombinedActionProvider) GetAction(g *engine.Game, player *engine.Player, r *rand.Rand) engine.PlayerAction {
+	action, _ := p.GetActionContext(context.Background(), g, player, r)
+	return action
+}
+
+// GetActionContext method for CombinedActionProvider
+func (p *CombinedActionProvider) GetActionContext(ctx context.Context, g *engine.Game, player *engine.Player, r *rand.Rand) (engine.PlayerAction, error) {
 	if player.IsCPU {
-		time.Sleep(g.CPUThinkTime())
-		return g.GetCPUAction(player, r)
+		return g.GetCPUAction(player, r), nil
+	}
+	return cli.PromptForAction(ctx, g)
+}
+
+// AgreeToRunouts method for CombinedActionProvider
+func (p *CombinedActionProvider) AgreeToRunouts(_ *engine.Game, player *engine.Player, times int) bool {
+	return player.IsCPU || cli.PromptRunouts(player.Name, times)
+}
+
+// HotSeatActionProvider lets several human players share one terminal. Before a
+// human acts, it shows a "pass the keyboard" screen whenever the previous human
+// viewer was someone else, so nobody sees another player's hole cards.
+type HotSeatActionProvider struct {
+	lastViewer *engine.Player
+}
+
+// GetAction method for HotSeatActionProvider
+func (p *HotSeatActionProvider) GetAction(g *engine.Game, player *engine.Player, r *rand.Rand) engine.PlayerAction {
+	action, _ := p.GetActionContext(context.Background(), g, player, r)
+	return action
+}
+
+// GetActionContext method for HotSeatActionProvider
+func (p *HotSeatActionProvider) GetActionContext(ctx context.Context, g *engine.Game, player *engine.Player, r *rand.Rand) (engine.PlayerAction, error) {
+	if player.IsCPU {
+		return g.GetCPUAction(player, r), nil
+	}
+	if p.lastViewer != player {
+		if err := cli.PassKeyboard(ctx, g, player.Name); err != nil {
+			return engine.PlayerAction{}, err
+		}
+		p.lastViewer = player
+	}
+	return cli.PromptForActionFor(ctx, g, player)
+}
+
+// AgreeToRunouts method for HotSeatActionProvider. The question shows no hole
+// cards, so the keyboard is not passed.
+func (p *HotSeatActionProvider) AgreeToRunouts(_ *engine.Game, player *engine.Player, times int) bool {
+	return player.IsCPU || cli.PromptRunouts(player.Name, times)
+}
+
+// cliObserver prints the progress of a hand to the terminal.
+type cliObserver struct {
+	hotSeat bool
+}
+
+// OnEvent method for cliObserver
+func (o *cliObserver) OnEvent(g *engine.Game, event engine.Event) {
+	switch e := event.(type) {
+	case *engine.HandStartEvent:
+		if e.Blind != nil {
+			fmt.Println(cli.FormatBlindEvent(e.Blind))
+		}
+		if o.hotSeat {
+			// Nobody has the keyboard yet, so only show public information.
+			cli.DisplayGameStateFor(g, nil)
+		} else {
+			cli.DisplayGameState(g)
+		}
+		if e.TimeCharges > 0 {
+			fmt.Printf("Time charges collected: %s\n", cli.FormatNumber(e.TimeCharges))
+		}
+	case *engine.ActionEvent:
+		if message := cli.FormatActionEvent(e); message != "" {
+			fmt.Println(message)
+		}
+	case *engine.HandEndEvent:
+		for _, message := range cli.FormatHandEnd(g.PublicView(), e) {
+			fmt.Println(message)
+		}
 	}
-	return cli.PromptForAction(g)
 }
 
 func runGame(cmd *cobra.Command, _ []string) {
@@ -100,120 +202,108 @@ func runGame(cmd *cobra.Command, _ []string) {
 
 		playerNames := []string{"YOU", "CPU 1", "CPU 2", "CPU 3", "CPU 4", "CPU 5"}
 
-		var difficulty engine.Difficulty
-		switch difficultyStr {
-		case "easy":
-			difficulty = engine.DifficultyEasy
-		case "medium":
-			difficulty = engine.DifficultyMedium
-		case "hard":
-			difficulty = engine.DifficultyHard
-		default:
-			logrus.Warnf("Invalid difficulty '%s' specified. Defaulting to medium.", difficultyStr)
-			difficulty = engine.DifficultyMedium
-		}
+		difficulty := parseDifficulty(difficultyStr)
 
 		g = engine.NewGame(playerNames, initialChips, smallBlind, bigBlind, difficulty, rules, devMode, showOuts, blindUpInterval)
-	}
+		g.Ante, g.BigBlindAnte = ante, bigBlindAnte
+		g.BombPotAnte = bombPotAnte
+		g.RunItTimes = runItTimes
+		g.ButtonPolicy, _ = engine.ParseButtonPolicy(buttonStr) // Validated in PersistentPreRunE.
+		g.Rake = rakeOption()
+
+		if cashGame {
+			if err := g.SetCash(cashRules()); err != nil {
+				logrus.Fatalf("Failed to set up the cash game: %v", err)
+			}
+			fmt.Printf("Cash game: buy-ins from %s to %s chips\n", cli.FormatNumber(g.Cash.MinBuyIn), cli.FormatNumber(g.Cash.MaxBuyIn))
+		}
 
-	actionProvider := &CombinedActionProvider{}
+		if structureStr != "" {
+			structure, err := loadStructureOption(structureStr)
+			if err != nil {
+				logrus.Fatalf("Failed to load tournament structure: %v", err)
+			}
+			g.SetStructure(structure, time.Now())
+			fmt.Printf("Tournament structure: %s\n", structure.Name)
+		}
 
-	// Main Game Loop (multi-hand)
-	for {
-		// Always start a new hand - loaded games are ready to start fresh
-		blindEvent := g.StartNewHand()
-		if blindEvent != nil {
-			message := fmt.Sprintf("\n*** Blinds are now %s/%s ***\n", cli.FormatNumber(blindEvent.SmallBlind), cli.FormatNumber(blindEvent.BigBlind))
-			fmt.Println(message)
+		// In hot-seat mode, the first seats are taken by the named human players.
+		for i, name := range hotSeatNames {
+			if err := g.SetHuman(i, name); err != nil {
+				logrus.Fatalf("Failed to seat human player %s: %v", name, err)
+			}
 		}
-		// Clear the loadFile flag after starting the first hand
-		loadFile = ""
+	}
 
-		cli.DisplayGameState(g)
+	if err := applyAIProfiles(g, !loadGame); err != nil {
+		logrus.Fatalf("Failed to set up AI profiles: %v", err)
+	}
 
-		// Single Hand Loop
-		for g.Phase != engine.PhaseShowdown && g.Phase != engine.PhaseHandOver {
-			if g.CountNonFoldedPlayers() <= 1 {
-				break
-			}
-			g.PrepareNewBettingRound()
-
-			// New Turn-by-turn Betting Loop
-			for !g.IsBettingRoundOver() {
-				player := g.CurrentPlayer()
-				var action engine.PlayerAction
-
-				if player.Status != engine.PlayerStatusPlaying {
-					g.AdvanceTurn()
-					continue
-				}
-
-				action = actionProvider.GetAction(g, player, g.Rand)
-
-				_, event := g.ProcessAction(player, action)
-				if event != nil {
-					var eventMessage string
-					switch event.Action {
-					case engine.ActionFold:
-						eventMessage = fmt.Sprintf("%s folds.", event.PlayerName)
-					case engine.ActionCheck:
-						eventMessage = fmt.Sprintf("%s checks.", event.PlayerName)
-					case engine.ActionCall:
-						eventMessage = fmt.Sprintf("%s calls %s.", event.PlayerName, cli.FormatNumber(event.Amount))
-					case engine.ActionBet:
-						eventMessage = fmt.Sprintf("%s bets %s.", event.PlayerName, cli.FormatNumber(event.Amount))
-					case engine.ActionRaise:
-						eventMessage = fmt.Sprintf("%s raises to %s.", event.PlayerName, cli.FormatNumber(event.Amount))
-					}
-					if eventMessage != "" {
-						fmt.Println(eventMessage)
-					}
-				}
-				g.AdvanceTurn()
-			}
-			g.Advance()
+	g.SetTimeControl(engine.TimeControl{DecisionTime: decisionTime, TimeBank: timeBank})
+
+	hotSeat := g.CountHumanPlayers() > 1
+	var actionProvider engine.ActionProvider = &CombinedActionProvider{}
+	if hotSeat {
+		actionProvider = &HotSeatActionProvider{}
+	}
+	if len(botSpecs) > 0 {
+		bots, err := startBots(g, actionProvider)
+		if err != nil {
+			logrus.Fatalf("Failed to start bots: %v", err)
 		}
+		defer bots.Close()
+		actionProvider = bots
+	}
 
-		// Conclude the hand
-		if g.CountNonFoldedPlayers() > 1 {
-			showdownMessages := cli.FormatShowdownResults(g)
-			for _, msg := range showdownMessages {
-				fmt.Println(msg)
-			}
-		} else {
-			results := g.AwardPotToLastPlayer()
-			fmt.Println("--- POT AWARDED ---")
-			for _, result := range results {
-				fmt.Printf(
-					"%s wins %s chips with %s\n",
-					result.PlayerName, cli.FormatNumber(result.AmountWon), result.HandDesc,
-				)
-			}
-			fmt.Println("------------------------")
+	// Main Game Loop (multi-hand)
+	for {
+		if left := g.BreakLeft(time.Now()); left > 0 {
+			cli.TakeBreak(left)
 		}
 
+		// Always start a new hand - loaded games are ready to start fresh
+		g.PlayHand(actionProvider, &cliObserver{hotSeat: hotSeat})
+		// Clear the loadFile flag after playing the first hand
+		loadFile = ""
+
+		busted := bustedHumans(g)
 		cleanupMessages := g.CleanupHand()
 		for _, msg := range cleanupMessages {
 			fmt.Println(msg)
 		}
 
-		if g.Players[0].Status == engine.PlayerStatusEliminated {
-			fmt.Println("You have been eliminated. GAME OVER.")
+		if g.Cash != nil {
+			offerRebuys(g, busted)
+			if g.CountRemainingHumans() == 0 {
+				fmt.Println("No human player is left at the table.")
+				printSessionResults(g)
+				break
+			}
+		}
+
+		if g.CountRemainingHumans() == 0 {
+			if hotSeat {
+				fmt.Println("All human players have been eliminated. GAME OVER.")
+			} else {
+				fmt.Println("You have been eliminated. GAME OVER.")
+			}
+			printStandings(g)
 			break
 		}
 
 		if g.CountRemainingPlayers() <= 1 {
 			fmt.Println("--- GAME OVER ---")
+			printStandings(g)
 			break
 		}
 
-		fmt.Print("Press ENTER to start the next hand, type 's' to save, or type 'q' to exit > ")
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(strings.ToLower(input))
+		input := readNextHandCommand(g)
 
 		switch input {
 		case "q":
+			if g.Cash != nil {
+				printSessionResults(g)
+			}
 			fmt.Println("Thanks for playing!")
 			return
 		case "s":
@@ -228,20 +318,128 @@ func runGame(cmd *cobra.Command, _ []string) {
 			if err != nil {
 				fmt.Printf("❌ Failed to save game: %v\n", err)
 				fmt.Print("Press ENTER to continue...")
-				reader.ReadString('\n')
+				cli.ReadLine()
 			} else {
 				fmt.Printf("✅ Game saved successfully as %s.json\n", saveFilename)
 				fmt.Printf("🔄 You can load this game later with: go run main.go --load\n")
 				fmt.Print("Press ENTER to continue...")
-				reader.ReadString('\n')
+				cli.ReadLine()
 			}
 			continue
 		default:
-			// Continue to next hand
+			// Continue to next hand, straddled or as a bomb pot if asked for.
+			if handType, err := engine.ParseHandType(input); err == nil {
+				g.NextHandType = handType
+			}
 		}
 	}
 }
 
+// readNextHandCommand prompts for what to do before the next hand. The
+// commands of a cash game are carried out right away, and the prompt is
+// shown again.
+func readNextHandCommand(g *engine.Game) string {
+	prompt := "Press ENTER to start the next hand, type 'utg' or 'button' for a straddle, 'bomb' for a bomb pot, 's' to save, or 'q' to exit > "
+	if g.Cash != nil {
+		prompt = "Press ENTER to start the next hand, type 'utg' or 'button' for a straddle, 'bomb' for a bomb pot, 'buy [amount]' to top up, 'out' or 'in' to sit out or in, 's' to save, or 'q' to exit > "
+	}
+	for {
+		fmt.Print(prompt)
+		input := strings.TrimSpace(strings.ToLower(cli.ReadLine()))
+		if g.Cash == nil || !cashCommand(g, input) {
+			return input
+		}
+	}
+}
+
+// rakeOption returns the rake of the --rake, --rake-cap, --no-flop-no-drop,
+// --time-charge and --time-charge-hands flags.
+func rakeOption() engine.Rake {
+	return engine.Rake{
+		Percent:         rakePercent,
+		Cap:             rakeCap,
+		NoFlopNoDrop:    noFlopNoDrop,
+		TimeCharge:      timeCharge,
+		TimeChargeHands: timeChargeHands,
+	}
+}
+
+// parseDifficulty converts the --difficulty flag value into an engine.Difficulty,
+// defaulting to medium for unknown values.
+func parseDifficulty(s string) engine.Difficulty {
+	switch s {
+	case "easy":
+		return engine.DifficultyEasy
+	case "medium":
+		return engine.DifficultyMedium
+	case "hard":
+		return engine.DifficultyHard
+	default:
+		logrus.Warnf("Invalid difficulty '%s' specified. Defaulting to medium.", s)
+		return engine.DifficultyMedium
+	}
+}
+
+// loadStructureOption loads the tournament structure of the --structure flag:
+// a YAML file, or the name of one in the structures directory.
+func loadStructureOption(s string) (*engine.Structure, error) {
+	if _, err := os.Stat(s); err != nil && !strings.ContainsAny(s, `/\.`) {
+		s = fmt.Sprintf("structures/%s.yml", s)
+	}
+	return engine.LoadStructure(s)
+}
+
+// printStandings prints the players' finishing places.
+func printStandings(g *engine.Game) {
+	for _, line := range cli.FormatStandings(g.Standings()) {
+		fmt.Println(line)
+	}
+}
+
+// validateTableFlags checks the flags of the chips, the blinds, the ante and
+// the button that the commands hosting a table share.
+func validateTableFlags(initialChips, smallBlind, bigBlind, ante int, bigBlindAnte bool, button string) error {
+	if initialChips <= 0 {
+		return fmt.Errorf("initial-chips는 0보다 커야 합니다. 입력값: %d", initialChips)
+	}
+	if smallBlind <= 0 {
+		return fmt.Errorf("small-blind는 0보다 커야 합니다. 입력값: %d", smallBlind)
+	}
+	if bigBlind <= 0 {
+		return fmt.Errorf("big-blind는 0보다 커야 합니다. 입력값: %d", bigBlind)
+	}
+	if smallBlind >= bigBlind {
+		return fmt.Errorf("small-blind(%d)는 big-blind(%d)보다 작아야 합니다", smallBlind, bigBlind)
+	}
+	if ante < 0 {
+		return fmt.Errorf("ante는 0 이상이어야 합니다. 입력값: %d", ante)
+	}
+	if bigBlindAnte && ante == 0 {
+		return fmt.Errorf("big-blind-ante에는 0보다 큰 ante가 필요합니다")
+	}
+	if _, err := engine.ParseButtonPolicy(button); err != nil {
+		return fmt.Errorf("button은 moving 또는 dead여야 합니다. 입력값: %s", button)
+	}
+	return nil
+}
+
+// validateTimeControlFlags checks the --decision-time and --time-bank flags.
+func validateTimeControlFlags(decisionTime, timeBank time.Duration) error {
+	if decisionTime < 0 || timeBank < 0 {
+		return fmt.Errorf("decision-time과 time-bank는 0 이상이어야 합니다. 입력값: %s, %s", decisionTime, timeBank)
+	}
+	return nil
+}
+
+// addRakeFlags adds the flags of the rake to a command.
+func addRakeFlags(cmd *cobra.Command) {
+	cmd.Flags().Float64Var(&rakePercent, "rake", 0, "Percent of each pot the house takes. 0 means no rake.")
+	cmd.Flags().IntVar(&rakeCap, "rake-cap", 0, "Most the house takes from the pot of a hand. 0 means no cap.")
+	cmd.Flags().BoolVar(&noFlopNoDrop, "no-flop-no-drop", false, "Take no rake from hands that end before the flop.")
+	cmd.Flags().IntVar(&timeCharge, "time-charge", 0, "Fee every player dealt in pays to the house every --time-charge-hands hands. 0 means no time charge.")
+	cmd.Flags().IntVar(&timeChargeHands, "time-charge-hands", 0, "Number of hands a time charge pays for. 0 means every hand.")
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "pls7",
@@ -354,6 +552,12 @@ func Execute() {
 	savesCmd.AddCommand(listCmd)
 	savesCmd.AddCommand(validateCmd)
 	savesCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(joinCmd)
+	rootCmd.AddCommand(apiCmd)
+	rootCmd.AddCommand(tuneCmd)
+	rootCmd.AddCommand(simulateCmd)
+	rootCmd.AddCommand(tournamentCmd)
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -367,25 +571,90 @@ func init() {
 	rootCmd.Flags().BoolVar(&devMode, "dev", false, "Enable development mode for verbose logging.")
 	rootCmd.Flags().BoolVar(&showOuts, "outs", false, "Shows outs for players if found (temporarily draws fixed good hole cards).")
 	rootCmd.Flags().IntVar(&blindUpInterval, "blind-up", 2, "Sets the number of rounds for blind up. 0 means no blind up.")
+	rootCmd.Flags().BoolVar(&cashGame, "cash", false, "Play a cash game: the blinds stay fixed, players may rebuy, top up and sit out, and busted CPUs are replaced.")
+	rootCmd.Flags().IntVar(&minBuyIn, "min-buy-in", 0, "Fewest chips a player may rebuy for in a cash game. 0 means half of --initial-chips.")
+	rootCmd.Flags().IntVar(&maxBuyIn, "max-buy-in", 0, "Most chips a player may have after a rebuy or top-up in a cash game. 0 means --initial-chips.")
+	rootCmd.Flags().StringVar(&structureStr, "structure", "", "Tournament structure to play (turbo, standard, or a YAML file). Replaces --small-blind, --big-blind, --ante and --blind-up.")
 	rootCmd.Flags().IntVar(&initialChips, "initial-chips", 300000, "Initial chips for each player.")
 	rootCmd.Flags().IntVar(&smallBlind, "small-blind", 500, "Small blind amount.")
 	rootCmd.Flags().IntVar(&bigBlind, "big-blind", 1000, "Big blind amount.")
+	rootCmd.Flags().IntVar(&ante, "ante", 0, "Ante every player posts before a hand is dealt. 0 means no ante.")
+	rootCmd.Flags().BoolVar(&bigBlindAnte, "big-blind-ante", false, "Let the big blind post the ante for the whole table.")
+	rootCmd.Flags().IntVar(&bombPotAnte, "bomb-pot-ante", 0, "Ante every player posts in a bomb pot. 0 means the big blind.")
+	rootCmd.Flags().IntVar(&runItTimes, "run-it", 0, "Offer to deal the rest of the board this many times (2-4) when players are all-in before the river. 0 means never.")
+	rootCmd.Flags().StringVar(&buttonStr, "button", "moving", "How the button moves when players are eliminated (moving, dead).")
+	addRakeFlags(rootCmd)
 	rootCmd.Flags().BoolVarP(&loadGame, "load", "l", false, "Load the most recent saved game.")
 	rootCmd.Flags().StringVar(&loadFile, "load-file", "", "Load a specific saved game file.")
 	rootCmd.Flags().StringVar(&saveDir, "save-dir", "saves", "Directory to store save files.")
+	rootCmd.Flags().StringSliceVar(&hotSeatNames, "hotseat", nil, "Comma-separated names of human players sharing this terminal (e.g. Alice,Bob).")
+	rootCmd.Flags().DurationVar(&decisionTime, "decision-time", 0, "Time each decision may take before the time bank is used (e.g. 30s). 0 means no time limit.")
+	rootCmd.Flags().DurationVar(&timeBank, "time-bank", 0, "Extra time each player gets for the whole game (e.g. 2m).")
+	rootCmd.Flags().StringArrayVar(&botSpecs, "bot", nil, "Let an external program play a CPU seat, as seat=command (e.g. 2=./mybot). Repeatable.")
+	rootCmd.Flags().DurationVar(&botTimeout, "bot-timeout", 10*time.Second, "Time a bot has to answer before it checks or folds.")
+	rootCmd.Flags().StringArrayVar(&aiProfileFiles, "ai-profiles", nil, "YAML file with AI profiles and difficulty line-ups to add to the built-in ones. Repeatable.")
+	rootCmd.Flags().StringArrayVar(&cpuSpecs, "cpu", nil, "Choose the AI profile of a CPU seat, as seat=profile (e.g. 3=Tight-Aggressive). Repeatable.")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		if initialChips <= 0 {
-			return fmt.Errorf("initial-chips는 0보다 커야 합니다. 입력값: %d", initialChips)
+		if err := validateTableFlags(initialChips, smallBlind, bigBlind, ante, bigBlindAnte, buttonStr); err != nil {
+			return err
+		}
+		if err := validateTimeControlFlags(decisionTime, timeBank); err != nil {
+			return err
+		}
+		if bombPotAnte < 0 {
+			return fmt.Errorf("bomb-pot-ante는 0 이상이어야 합니다. 입력값: %d", bombPotAnte)
+		}
+		if err := engine.ValidateRunItTimes(runItTimes); err != nil {
+			return fmt.Errorf("run-it은 0에서 %d 사이여야 합니다. 입력값: %d", engine.MaxRunouts, runItTimes)
 		}
-		if smallBlind <= 0 {
-			return fmt.Errorf("small-blind는 0보다 커야 합니다. 입력값: %d", smallBlind)
+		if err := rakeOption().Validate(); err != nil {
+			return fmt.Errorf("rake 설정이 올바르지 않습니다: %v", err)
 		}
-		if bigBlind <= 0 {
-			return fmt.Errorf("big-blind는 0보다 커야 합니다. 입력값: %d", bigBlind)
+		if minBuyIn < 0 || maxBuyIn < 0 {
+			return fmt.Errorf("min-buy-in과 max-buy-in은 0 이상이어야 합니다. 입력값: %d, %d", minBuyIn, maxBuyIn)
+		}
+		if cashGame {
+			if structureStr != "" {
+				return fmt.Errorf("cash 게임에서는 structure를 사용할 수 없습니다")
+			}
+			if err := cashRules().Validate(); err != nil {
+				return fmt.Errorf("cash 게임의 buy-in 설정이 올바르지 않습니다: %v", err)
+			}
+		}
+		if len(hotSeatNames) == 1 || len(hotSeatNames) > 6 {
+			return fmt.Errorf("hotseat에는 2명에서 6명 사이의 이름이 필요합니다. 입력값: %d명", len(hotSeatNames))
+		}
+		seen := make(map[string]bool)
+		for _, name := range hotSeatNames {
+			if strings.TrimSpace(name) == "" || seen[name] {
+				return fmt.Errorf("hotseat 이름은 비어 있거나 중복될 수 없습니다: %q", name)
+			}
+			seen[name] = true
+		}
+		bots, err := parseBotSpecs(botSpecs)
+		if err != nil {
+			return err
+		}
+		for seat := range bots {
+			if seat < len(hotSeatNames) {
+				return fmt.Errorf("bot seat %d는 hotseat 플레이어가 사용 중입니다", seat)
+			}
+		}
+		cpus, err := parseCPUSpecs(cpuSpecs)
+		if err != nil {
+			return err
+		}
+		for seat := range cpus {
+			if seat < len(hotSeatNames) {
+				return fmt.Errorf("cpu seat %d는 hotseat 플레이어가 사용 중입니다", seat)
+			}
+			if _, ok := bots[seat]; ok {
+				return fmt.Errorf("cpu seat %d는 bot이 사용 중입니다", seat)
+			}
 		}
-		if smallBlind >= bigBlind {
-			return fmt.Errorf("small-blind(%d)는 big-blind(%d)보다 작아야 합니다", smallBlind, bigBlind)
+		if botTimeout <= 0 {
+			return fmt.Errorf("bot-timeout은 0보다 커야 합니다. 입력값: %s", botTimeout)
 		}
 		return nil
 	}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func TestFlagDefaultsAreNotOverridden(t *testing.T) {
+	// A flag variable bound by several commands holds the default of the
+	// command bound last, whichever command is run.
+	for _, c := range append([]*cobra.Command{rootCmd}, rootCmd.Commands()...) {
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if f.Value.String() != f.DefValue {
+				t.Errorf("Expected --%s of %q to default to %s, got %s", f.Name, c.Name(), f.DefValue, f.Value)
+			}
+		})
+	}
+
+	if decisionTime != 0 || timeBank != 0 {
+		t.Errorf("Expected no time limit by default, got %s and a time bank of %s", decisionTime, timeBank)
+	}
+	if difficultyStr != "medium" || blindUpInterval != 2 || devMode {
+		t.Errorf("Expected a medium game with blinds up every 2 hands, got %s, %d and dev mode %v", difficultyStr, blindUpInterval, devMode)
+	}
+}
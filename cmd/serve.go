@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"pls7-cli/internal/config"
+	"pls7-cli/internal/network"
+	"pls7-cli/internal/util"
+	"pls7-cli/pkg/engine"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servePort            int           // To hold the --port flag value of the serve command
+	serveHumans          int           // To hold the --humans flag value (number of remote players to wait for)
+	servePlayers         int           // To hold the --players flag value (total seats, the rest are CPUs)
+	serveRule            string        // To hold the --rule flag value of the serve command
+	serveDifficulty      string        // To hold the --difficulty flag value of the serve command
+	serveDevMode         bool          // To hold the --dev flag value of the serve command
+	serveBlindUpInterval int           // To hold the --blind-up flag value of the serve command
+	serveStructure       string        // To hold the --structure flag value of the serve command
+	serveInitialChips    int           // To hold the --initial-chips flag value of the serve command
+	serveSmallBlind      int           // To hold the --small-blind flag value of the serve command
+	serveBigBlind        int           // To hold the --big-blind flag value of the serve command
+	serveAnte            int           // To hold the --ante flag value of the serve command
+	serveBigBlindAnte    bool          // To hold the --big-blind-ante flag value of the serve command
+	serveButton          string        // To hold the --button flag value of the serve command
+	serveDecisionTime    time.Duration // To hold the --decision-time flag value of the serve command
+	serveTimeBank        time.Duration // To hold the --time-bank flag value of the serve command
+)
+
+// serveCmd represents the serve subcommand
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Host a multiplayer table over TCP",
+	Long: `Host a poker table that other players can join with "pls7 join <ip:port>".
+The game starts once the requested number of players have joined; the remaining seats are filled with CPU players.`,
+	Run: runServer,
+}
+
+// runServer hosts a table until the game is over.
+func runServer(_ *cobra.Command, _ []string) {
+	util.InitLogger(serveDevMode)
+
+	rules, err := config.LoadGameRulesFromOptions(serveRule)
+	if err != nil {
+		logrus.Fatalf("Failed to load game rules: %v", err)
+	}
+
+	buttonPolicy, _ := engine.ParseButtonPolicy(serveButton) // Validated in PreRunE.
+
+	var structure *engine.Structure
+	if serveStructure != "" {
+		if structure, err = loadStructureOption(serveStructure); err != nil {
+			logrus.Fatalf("Failed to load tournament structure: %v", err)
+		}
+	}
+
+	server, err := network.NewServer(network.Config{
+		Rules:           rules,
+		Humans:          serveHumans,
+		Players:         servePlayers,
+		InitialChips:    serveInitialChips,
+		SmallBlind:      serveSmallBlind,
+		BigBlind:        serveBigBlind,
+		Ante:            serveAnte,
+		BigBlindAnte:    serveBigBlindAnte,
+		ButtonPolicy:    buttonPolicy,
+		BlindUpInterval: serveBlindUpInterval,
+		Structure:       structure,
+		Difficulty:      parseDifficulty(serveDifficulty),
+		HandDelay:       3 * time.Second,
+		TimeControl:     engine.TimeControl{DecisionTime: serveDecisionTime, TimeBank: serveTimeBank},
+	})
+	if err != nil {
+		logrus.Fatalf("Failed to create server: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", servePort))
+	if err != nil {
+		logrus.Fatalf("Failed to listen on port %d: %v", servePort, err)
+	}
+
+	fmt.Printf("======== %s ========\n", rules.Name)
+	fmt.Printf("Hosting a table on port %d for %d player(s) and %d CPU(s).\n", servePort, serveHumans, servePlayers-serveHumans)
+	if err := server.Serve(listener); err != nil {
+		logrus.Fatalf("Server stopped: %v", err)
+	}
+	fmt.Println("--- GAME OVER ---")
+}
+
+func init() {
+	serveCmd.Flags().IntVarP(&servePort, "port", "p", 7777, "TCP port to listen on.")
+	serveCmd.Flags().IntVar(&serveHumans, "humans", 2, "Number of remote players to wait for before dealing.")
+	serveCmd.Flags().IntVar(&servePlayers, "players", 6, "Total number of seats (2-6). Seats without a remote player are CPUs.")
+	serveCmd.Flags().StringVarP(&serveRule, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh, plo, plo8, pls7-db, plo8-db).")
+	serveCmd.Flags().StringVarP(&serveDifficulty, "difficulty", "d", "medium", "Set AI difficulty (easy, medium, hard)")
+	serveCmd.Flags().BoolVar(&serveDevMode, "dev", false, "Enable development mode for verbose logging.")
+	serveCmd.Flags().IntVar(&serveBlindUpInterval, "blind-up", 2, "Sets the number of rounds for blind up. 0 means no blind up.")
+	serveCmd.Flags().StringVar(&serveStructure, "structure", "", "Tournament structure to play (turbo, standard, or a YAML file). Replaces --small-blind, --big-blind, --ante and --blind-up.")
+	serveCmd.Flags().IntVar(&serveInitialChips, "initial-chips", 300000, "Initial chips for each player.")
+	serveCmd.Flags().IntVar(&serveSmallBlind, "small-blind", 500, "Small blind amount.")
+	serveCmd.Flags().IntVar(&serveBigBlind, "big-blind", 1000, "Big blind amount.")
+	serveCmd.Flags().IntVar(&serveAnte, "ante", 0, "Ante every player posts before a hand is dealt. 0 means no ante.")
+	serveCmd.Flags().BoolVar(&serveBigBlindAnte, "big-blind-ante", false, "Let the big blind post the ante for the whole table.")
+	serveCmd.Flags().StringVar(&serveButton, "button", "moving", "How the button moves when players are eliminated (moving, dead).")
+	serveCmd.Flags().DurationVar(&serveDecisionTime, "decision-time", 30*time.Second, "Time each decision may take before the time bank is used. 0 means no time limit.")
+	serveCmd.Flags().DurationVar(&serveTimeBank, "time-bank", 60*time.Second, "Extra time each player gets for the whole game.")
+
+	serveCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := validateTableFlags(serveInitialChips, serveSmallBlind, serveBigBlind, serveAnte, serveBigBlindAnte, serveButton); err != nil {
+			return err
+		}
+		return validateTimeControlFlags(serveDecisionTime, serveTimeBank)
+	}
+}
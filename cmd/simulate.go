@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"pls7-cli/internal/cli"
+	"pls7-cli/internal/config"
+	"pls7-cli/internal/selfplay"
+	"pls7-cli/internal/util"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// maxViolationsShown is the number of chip conservation violations listed by
+// the simulate command; the rest are only counted.
+const maxViolationsShown = 10
+
+var (
+	simRule           string   // To hold the --rule flag value of the simulate command
+	simDifficulty     string   // To hold the --difficulty flag value (line-up played without --profiles)
+	simDevMode        bool     // To hold the --dev flag value of the simulate command
+	simHands          int      // To hold the --hands flag value of the simulate command
+	simGames          int      // To hold the --games flag value (games played to the end, 0 means refilled hands)
+	simBlindUp        int      // To hold the --blind-up flag value (hands between blind increases in games)
+	simPlayers        int      // To hold the --players flag value of the simulate command
+	simSeed           int64    // To hold the --seed flag value of the simulate command
+	simSmallBlind     int      // To hold the --small-blind flag value of the simulate command
+	simBigBlind       int      // To hold the --big-blind flag value of the simulate command
+	simStack          int      // To hold the --stack flag value (chips every player starts each hand with)
+	simProfiles       []string // To hold the --profiles flag value (profiles of the seats, in seat order)
+	simWorkers        int      // To hold the --workers flag value (goroutines the hands are played on)
+	simAIProfileFiles []string // To hold the --ai-profiles flag values of the simulate command
+)
+
+// simulateCmd represents the simulate subcommand
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Play CPU-only hands and report statistics",
+	Long: `Play headless hands between CPU players as fast as possible and report each profile's win rate,
+how often hands reach a showdown, the hands shown down, the average pot, and any chips lost or created by the engine.
+Every player starts each hand with the same stack. With --games, the players instead play whole games without refills
+until one of them has every chip, so that side pots, eliminations and the button moving past empty seats are covered.
+The same seed replays the same hands, so the command doubles as a regression check for engine changes; it exits with
+status 1 if chips were not conserved.`,
+	Run: runSimulate,
+}
+
+// runSimulate plays the hands and prints the report.
+func runSimulate(_ *cobra.Command, _ []string) {
+	util.InitLogger(simDevMode)
+
+	table, err := newSimulationTable()
+	if err != nil {
+		logrus.Fatalf("Failed to set up the simulation: %v", err)
+	}
+
+	fmt.Printf("======== %s ========\n", table.Rules.Name)
+	var report *selfplay.Report
+	if simGames > 0 {
+		fmt.Printf("Simulating %s games to the end at a %d-handed table (seed %d)\n", cli.FormatNumber(simGames), len(table.Profiles), simSeed)
+		report, err = table.SimulateGames(simGames, simSeed)
+	} else {
+		fmt.Printf("Simulating %s hands at a %d-handed table (seed %d)\n", cli.FormatNumber(simHands), len(table.Profiles), simSeed)
+		report, err = table.Simulate(simHands, simSeed)
+	}
+	if err != nil {
+		logrus.Fatalf("Simulation failed: %v", err)
+	}
+	printSimulationReport(report, table.BigBlind)
+
+	if len(report.Violations) > 0 {
+		os.Exit(1)
+	}
+}
+
+// newSimulationTable sets up the table from the flags. Without --profiles, the
+// seats take the profiles of the --difficulty line-up in turn.
+func newSimulationTable() (selfplay.Table, error) {
+	table := selfplay.Table{
+		SmallBlind:      simSmallBlind,
+		BigBlind:        simBigBlind,
+		BlindUpInterval: simBlindUp,
+		Stack:           simStack,
+		Rake:            rakeOption(),
+		Workers:         simWorkers,
+	}
+	rules, err := config.LoadGameRulesFromOptions(simRule)
+	if err != nil {
+		return table, err
+	}
+	table.Rules = rules
+	c, err := engine.LoadAIConfig(simAIProfileFiles...)
+	if err != nil {
+		return table, err
+	}
+
+	names := simProfiles
+	if len(names) == 0 {
+		if names, err = c.LineUp(parseDifficulty(simDifficulty), 5); err != nil {
+			return table, err
+		}
+	}
+	for seat := 0; seat < simPlayers; seat++ {
+		profile, err := c.Profile(strings.TrimSpace(names[seat%len(names)]))
+		if err != nil {
+			return table, err
+		}
+		table.Profiles = append(table.Profiles, profile)
+	}
+	return table, table.Validate()
+}
+
+// printSimulationReport prints the report of a simulation.
+func printSimulationReport(r *selfplay.Report, bigBlind int) {
+	percent := func(n, total int) float64 {
+		if total == 0 {
+			return 0
+		}
+		return float64(n) / float64(total) * 100
+	}
+
+	fmt.Println("\n--- Profiles ---")
+	fmt.Printf("%-20s %5s  %-34s %9s %9s %9s\n", "Profile", "Seats", "Win rate", "Won", "Showdown", "W$SD")
+	for _, p := range r.Profiles {
+		fmt.Printf("%-20s %5d  %-34s %8.1f%% %8.1f%% %8.1f%%\n",
+			p.Name, p.Seats, p.WinRate(), percent(p.HandsWon, p.Hands),
+			percent(p.Showdowns, p.Hands), percent(p.ShowdownsWon, p.Showdowns))
+	}
+
+	if r.Games > 0 {
+		fmt.Println("\n--- Games won ---")
+		for _, p := range r.Profiles {
+			fmt.Printf("%-20s %10s %8.1f%%\n", p.Name, cli.FormatNumber(p.GamesWon), percent(p.GamesWon, r.Games))
+		}
+	}
+
+	fmt.Println("\n--- Hands ---")
+	fmt.Printf("Showdowns: %s of %s hands (%.1f%%)\n", cli.FormatNumber(r.Showdowns), cli.FormatNumber(r.Hands), percent(r.Showdowns, r.Hands))
+	fmt.Printf("Average pot: %s (%.1f BB)\n", cli.FormatNumber(int(r.AveragePot())), r.AveragePot()/float64(bigBlind))
+	if r.TotalRake > 0 || r.TotalTimeCharges > 0 {
+		fmt.Printf("House: %s in rake, %s in time charges (%.2f BB per hand)\n",
+			cli.FormatNumber(r.TotalRake), cli.FormatNumber(r.TotalTimeCharges),
+			float64(r.TotalRake+r.TotalTimeCharges)/float64(r.Hands)/float64(bigBlind))
+	}
+
+	fmt.Println("\n--- Hands shown down ---")
+	total := 0
+	for _, n := range r.HandRanks {
+		total += n
+	}
+	for rank := poker.HighCard; rank <= poker.RoyalFlush; rank++ {
+		if n := r.HandRanks[rank]; n > 0 {
+			fmt.Printf("%-20s %10s %8.2f%%\n", rank, cli.FormatNumber(n), percent(n, total))
+		}
+	}
+
+	fmt.Println("\n--- Chip conservation ---")
+	if len(r.Violations) == 0 {
+		fmt.Println("✅ Every chip was accounted for.")
+		return
+	}
+	fmt.Printf("❌ %d violation(s):\n", len(r.Violations))
+	for i, v := range r.Violations {
+		if i == maxViolationsShown {
+			fmt.Printf("   ... and %d more\n", len(r.Violations)-maxViolationsShown)
+			break
+		}
+		fmt.Printf("   %s\n", v)
+	}
+}
+
+func init() {
+	simulateCmd.Flags().StringVarP(&simRule, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh, plo, plo8, pls7-db, plo8-db).")
+	simulateCmd.Flags().StringVarP(&simDifficulty, "difficulty", "d", "hard", "Difficulty whose line-up fills the seats when --profiles is not given (easy, medium, hard).")
+	simulateCmd.Flags().BoolVar(&simDevMode, "dev", false, "Enable development mode for verbose logging.")
+	simulateCmd.Flags().IntVar(&simHands, "hands", 1000, "Number of hands to play, each with refilled stacks.")
+	simulateCmd.Flags().IntVar(&simGames, "games", 0, "Number of games to play to the end without refilling the stacks, instead of --hands. 0 means none.")
+	simulateCmd.Flags().IntVar(&simBlindUp, "blind-up", 10, "Number of hands after which the blinds double in games played to the end. 0 means no blind up.")
+	simulateCmd.Flags().IntVar(&simPlayers, "players", 6, "Number of seats at the table (2-6).")
+	simulateCmd.Flags().Int64Var(&simSeed, "seed", 1, "Seed for the cards and the AI. The same seed replays the same hands.")
+	simulateCmd.Flags().IntVar(&simSmallBlind, "small-blind", 500, "Small blind amount.")
+	simulateCmd.Flags().IntVar(&simBigBlind, "big-blind", 1000, "Big blind amount.")
+	simulateCmd.Flags().IntVar(&simStack, "stack", 100000, "Chips every player starts each hand, or each game, with.")
+	addRakeFlags(simulateCmd)
+	simulateCmd.Flags().StringSliceVar(&simProfiles, "profiles", nil, "Comma-separated AI profiles of the seats, in seat order. Repeated from the start if shorter than --players.")
+	simulateCmd.Flags().IntVar(&simWorkers, "workers", runtime.NumCPU(), "Number of hand batches, or games, played at once. The results are the same for any number.")
+	simulateCmd.Flags().StringArrayVar(&simAIProfileFiles, "ai-profiles", nil, "YAML file with AI profiles and difficulty line-ups to add to the built-in ones. Repeatable.")
+
+	simulateCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if simPlayers < 2 || simPlayers > 6 {
+			return fmt.Errorf("players는 2에서 6 사이여야 합니다. 입력값: %d", simPlayers)
+		}
+		if simHands <= 0 {
+			return fmt.Errorf("hands는 0보다 커야 합니다. 입력값: %d", simHands)
+		}
+		if simGames < 0 || simBlindUp < 0 {
+			return fmt.Errorf("games와 blind-up은 0 이상이어야 합니다. 입력값: %d, %d", simGames, simBlindUp)
+		}
+		if simWorkers <= 0 {
+			return fmt.Errorf("workers는 0보다 커야 합니다. 입력값: %d", simWorkers)
+		}
+		return nil
+	}
+}
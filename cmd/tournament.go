@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"pls7-cli/internal/cli"
+	"pls7-cli/internal/config"
+	"pls7-cli/internal/util"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mttRule           string   // To hold the --rule flag value of the tournament command
+	mttDifficulty     string   // To hold the --difficulty flag value (line-up played without --profiles)
+	mttDevMode        bool     // To hold the --dev flag value of the tournament command
+	mttPlayers        int      // To hold the --players flag value of the tournament command
+	mttTableSize      int      // To hold the --table-size flag value (seats at each table)
+	mttSeed           int64    // To hold the --seed flag value of the tournament command
+	mttInitialChips   int      // To hold the --initial-chips flag value of the tournament command
+	mttSmallBlind     int      // To hold the --small-blind flag value of the tournament command
+	mttBigBlind       int      // To hold the --big-blind flag value of the tournament command
+	mttBlindUp        int      // To hold the --blind-up flag value (rounds between blind increases)
+	mttStructure      string   // To hold the --structure flag value of the tournament command
+	mttProfiles       []string // To hold the --profiles flag value (profiles of the players, in order)
+	mttWorkers        int      // To hold the --workers flag value (tables played at once)
+	mttAIProfileFiles []string // To hold the --ai-profiles flag values of the tournament command
+)
+
+// tournamentCmd represents the tournament subcommand
+var tournamentCmd = &cobra.Command{
+	Use:   "tournament",
+	Short: "Play a CPU-only multi-table tournament and report the finishing order",
+	Long: `Play a headless tournament between CPU players at several tables. Every table plays a hand each round.
+Players are moved between rounds so that the tables never differ by more than one player, and tables are broken
+as the field shrinks, until the last players meet at the final table. The command reports the eliminations, moves
+and broken tables as they happen, and the final standings. The same seed replays the same tournament.`,
+	Run: runTournament,
+}
+
+// runTournament plays the tournament and prints its progress and standings.
+func runTournament(_ *cobra.Command, _ []string) {
+	util.InitLogger(mttDevMode)
+
+	t, rules, err := newTournament()
+	if err != nil {
+		logrus.Fatalf("Failed to set up the tournament: %v", err)
+	}
+
+	fmt.Printf("======== %s ========\n", rules.Name)
+	fmt.Printf("%d players at %d tables of %d seats (seed %d)\n", t.Entrants, len(t.Tables), t.TableSize, mttSeed)
+	provider := &CPUActionProvider{}
+	for !t.Finished() {
+		for _, event := range t.PlayRound(provider) {
+			fmt.Printf("Round %d: %s\n", t.Round, event)
+		}
+	}
+	for _, line := range cli.FormatStandings(t.Standings()) {
+		fmt.Println(line)
+	}
+}
+
+// newTournament sets up the tournament from the flags. Without --profiles,
+// the players take the profiles of the --difficulty line-up in turn.
+func newTournament() (*engine.Tournament, *poker.GameRules, error) {
+	rules, err := config.LoadGameRulesFromOptions(mttRule)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := engine.LoadAIConfig(mttAIProfileFiles...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := mttProfiles
+	if len(names) == 0 {
+		if names, err = c.LineUp(parseDifficulty(mttDifficulty), 5); err != nil {
+			return nil, nil, err
+		}
+	}
+	profiles := make([]engine.AIProfile, mttPlayers)
+	for i := range profiles {
+		if profiles[i], err = c.Profile(strings.TrimSpace(names[i%len(names)])); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	t, err := engine.NewTournament(profiles, mttTableSize, mttInitialChips, mttSmallBlind, mttBigBlind, rules, mttBlindUp, mttSeed)
+	if err != nil {
+		return nil, nil, err
+	}
+	t.Workers = mttWorkers
+	if mttStructure != "" {
+		structure, err := loadStructureOption(mttStructure)
+		if err != nil {
+			return nil, nil, err
+		}
+		// The rounds are played as fast as possible, so levels timed in
+		// minutes would barely go up.
+		for i, l := range structure.Levels {
+			if !l.Break && l.Minutes > 0 {
+				return nil, nil, fmt.Errorf("level %d of %s lasts %g minutes; a headless tournament needs levels that last a number of hands", i+1, structure.Name, l.Minutes)
+			}
+		}
+		t.SetStructure(structure, time.Now())
+	}
+	return t, rules, nil
+}
+
+func init() {
+	tournamentCmd.Flags().StringVarP(&mttRule, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh, plo, plo8, pls7-db, plo8-db).")
+	tournamentCmd.Flags().StringVarP(&mttDifficulty, "difficulty", "d", "medium", "Difficulty whose line-up the players play when --profiles is not given (easy, medium, hard).")
+	tournamentCmd.Flags().BoolVar(&mttDevMode, "dev", false, "Enable development mode for verbose logging.")
+	tournamentCmd.Flags().IntVar(&mttPlayers, "players", 18, "Number of players who enter the tournament.")
+	tournamentCmd.Flags().IntVar(&mttTableSize, "table-size", engine.MaxTableSize, fmt.Sprintf("Number of seats at each table (2-%d).", engine.MaxTableSize))
+	tournamentCmd.Flags().Int64Var(&mttSeed, "seed", 1, "Seed for the seats, the cards and the AI. The same seed replays the same tournament.")
+	tournamentCmd.Flags().IntVar(&mttInitialChips, "initial-chips", 300000, "Initial chips for each player.")
+	tournamentCmd.Flags().IntVar(&mttSmallBlind, "small-blind", 500, "Small blind amount.")
+	tournamentCmd.Flags().IntVar(&mttBigBlind, "big-blind", 1000, "Big blind amount.")
+	tournamentCmd.Flags().IntVar(&mttBlindUp, "blind-up", 10, "Sets the number of rounds for blind up. 0 means no blind up.")
+	tournamentCmd.Flags().StringVar(&mttStructure, "structure", "", "Tournament structure to play (turbo, or a YAML file whose levels last a number of hands). Replaces --small-blind, --big-blind and --blind-up.")
+	tournamentCmd.Flags().StringSliceVar(&mttProfiles, "profiles", nil, "Comma-separated AI profiles of the players, in order. Repeated from the start if shorter than --players.")
+	tournamentCmd.Flags().IntVar(&mttWorkers, "workers", runtime.NumCPU(), "Number of tables played at once. The results are the same for any number.")
+	tournamentCmd.Flags().StringArrayVar(&mttAIProfileFiles, "ai-profiles", nil, "YAML file with AI profiles and difficulty line-ups to add to the built-in ones. Repeatable.")
+
+	tournamentCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if mttPlayers < 2 {
+			return fmt.Errorf("players는 2 이상이어야 합니다. 입력값: %d", mttPlayers)
+		}
+		if mttTableSize < 2 || mttTableSize > engine.MaxTableSize {
+			return fmt.Errorf("table-size는 2에서 %d 사이여야 합니다. 입력값: %d", engine.MaxTableSize, mttTableSize)
+		}
+		if mttWorkers <= 0 {
+			return fmt.Errorf("workers는 0보다 커야 합니다. 입력값: %d", mttWorkers)
+		}
+		return nil
+	}
+}
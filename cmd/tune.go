@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"pls7-cli/internal/config"
+	"pls7-cli/internal/selfplay"
+	"pls7-cli/internal/util"
+	"pls7-cli/pkg/engine"
+	"runtime"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuneRule           string   // To hold the --rule flag value of the tune command
+	tuneDifficulty     string   // To hold the --difficulty flag value (line-up the candidates play against)
+	tuneDevMode        bool     // To hold the --dev flag value of the tune command
+	tunePlayers        int      // To hold the --players flag value of the tune command
+	tuneHands          int      // To hold the --hands flag value (hands played by each candidate)
+	tuneSeed           int64    // To hold the --seed flag value
+	tuneSmallBlind     int      // To hold the --small-blind flag value of the tune command
+	tuneBigBlind       int      // To hold the --big-blind flag value of the tune command
+	tuneStack          int      // To hold the --stack flag value (chips every player starts each hand with)
+	tuneBase           string   // To hold the --base flag value (profile the search starts from)
+	tuneGrid           []string // To hold the --grid flag values ("param=v1,v2,...")
+	tunePopulation     int      // To hold the --population flag value
+	tuneGenerations    int      // To hold the --generations flag value
+	tuneName           string   // To hold the --name flag value (name of the best tuned profile)
+	tuneKeep           int      // To hold the --keep flag value (number of profiles to write)
+	tuneOutput         string   // To hold the --output flag value (YAML file to write)
+	tuneWorkers        int      // To hold the --workers flag value (goroutines the hands are played on)
+	tuneAIProfileFiles []string // To hold the --ai-profiles flag values of the tune command
+)
+
+// tuneCmd represents the tune subcommand
+var tuneCmd = &cobra.Command{
+	Use:   "tune",
+	Short: "Search for stronger AI profiles with self-play",
+	Long: `Play headless CPU-only hands to find AI profiles that win the most.
+Each candidate profile plays against the line-up of the chosen difficulty and is measured in big blinds won per 100 hands.
+With --grid, every combination of the given parameter values is tried; otherwise an evolutionary search mutates the base profile.
+The best profiles are written as YAML that can be loaded with --ai-profiles.`,
+	Run: runTune,
+}
+
+// runTune runs the search and writes the best profiles.
+func runTune(_ *cobra.Command, _ []string) {
+	util.InitLogger(tuneDevMode)
+
+	tuner, base, err := newTuner()
+	if err != nil {
+		logrus.Fatalf("Failed to set up tuning: %v", err)
+	}
+	played := 0
+	tuner.Progress = func(c selfplay.Candidate) {
+		played++
+		if c.Generation > 0 {
+			fmt.Printf("[%d] Generation %d: %s\n", played, c.Generation, c.Estimate)
+		} else {
+			fmt.Printf("[%d] %s\n", played, c.Estimate)
+		}
+	}
+
+	fmt.Printf("======== %s ========\n", tuner.Table.Rules.Name)
+	fmt.Printf("Tuning %s over %d hands per candidate against:", base.Name, tuner.Hands)
+	for _, p := range tuner.Table.Profiles[1:] {
+		fmt.Printf(" %s", p.Name)
+	}
+	fmt.Println()
+
+	var candidates []selfplay.Candidate
+	if len(tuneGrid) > 0 {
+		axes := make([]selfplay.GridAxis, len(tuneGrid))
+		for i, spec := range tuneGrid {
+			if axes[i], err = selfplay.ParseGridAxis(spec); err != nil {
+				logrus.Fatalf("Failed to parse grid: %v", err)
+			}
+		}
+		candidates, err = tuner.Grid(base, axes)
+	} else {
+		candidates, err = tuner.Evolve(base, tunePopulation, tuneGenerations)
+	}
+	if err != nil {
+		logrus.Fatalf("Tuning failed: %v", err)
+	}
+
+	if len(candidates) > tuneKeep {
+		candidates = candidates[:tuneKeep]
+	}
+	data, err := selfplay.MarshalProfiles(tuneName, candidates)
+	if err != nil {
+		logrus.Fatalf("Failed to encode profiles: %v", err)
+	}
+	if err := os.WriteFile(tuneOutput, data, 0644); err != nil {
+		logrus.Fatalf("Failed to write profiles: %v", err)
+	}
+
+	fmt.Println("\n--- Best profiles ---")
+	for i, c := range candidates {
+		fmt.Printf("%d. %s\n", i+1, c.Estimate)
+	}
+	fmt.Printf("✅ Wrote %d profile(s) to %s\n", len(candidates), tuneOutput)
+	fmt.Printf("🔄 Play against them with: go run main.go --ai-profiles %s --cpu 1=%s\n", tuneOutput, tuneName)
+}
+
+// newTuner sets up the tuner from the flags and returns it with the base
+// profile. The opponents are the line-up of the --difficulty flag.
+func newTuner() (*selfplay.Tuner, engine.AIProfile, error) {
+	rules, err := config.LoadGameRulesFromOptions(tuneRule)
+	if err != nil {
+		return nil, engine.AIProfile{}, err
+	}
+	c, err := engine.LoadAIConfig(tuneAIProfileFiles...)
+	if err != nil {
+		return nil, engine.AIProfile{}, err
+	}
+	base, err := c.Profile(tuneBase)
+	if err != nil {
+		return nil, engine.AIProfile{}, err
+	}
+	lineUp, err := c.LineUp(parseDifficulty(tuneDifficulty), tunePlayers-1)
+	if err != nil {
+		return nil, engine.AIProfile{}, err
+	}
+
+	table := selfplay.Table{
+		Rules:      rules,
+		Profiles:   []engine.AIProfile{base},
+		SmallBlind: tuneSmallBlind,
+		BigBlind:   tuneBigBlind,
+		Stack:      tuneStack,
+		Workers:    tuneWorkers,
+	}
+	for _, name := range lineUp {
+		profile, err := c.Profile(name)
+		if err != nil {
+			return nil, engine.AIProfile{}, err
+		}
+		table.Profiles = append(table.Profiles, profile)
+	}
+	if err := table.Validate(); err != nil {
+		return nil, engine.AIProfile{}, err
+	}
+	return &selfplay.Tuner{Table: table, Hands: tuneHands, Seed: tuneSeed}, base, nil
+}
+
+func init() {
+	tuneCmd.Flags().StringVarP(&tuneRule, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh, plo, plo8, pls7-db, plo8-db).")
+	tuneCmd.Flags().StringVarP(&tuneDifficulty, "difficulty", "d", "hard", "Difficulty whose line-up the candidates play against (easy, medium, hard).")
+	tuneCmd.Flags().BoolVar(&tuneDevMode, "dev", false, "Enable development mode for verbose logging.")
+	tuneCmd.Flags().IntVar(&tunePlayers, "players", 6, "Number of seats at the table (2-6).")
+	tuneCmd.Flags().IntVar(&tuneHands, "hands", 1000, "Number of hands each candidate plays.")
+	tuneCmd.Flags().Int64Var(&tuneSeed, "seed", 1, "Seed for the cards and the search. The same seed repeats the same run.")
+	tuneCmd.Flags().IntVar(&tuneSmallBlind, "small-blind", 500, "Small blind amount.")
+	tuneCmd.Flags().IntVar(&tuneBigBlind, "big-blind", 1000, "Big blind amount.")
+	tuneCmd.Flags().IntVar(&tuneStack, "stack", 100000, "Chips every player starts each hand with.")
+	tuneCmd.Flags().IntVar(&tuneWorkers, "workers", runtime.NumCPU(), "Number of hands played at once. The results are the same for any number.")
+	tuneCmd.Flags().StringArrayVar(&tuneAIProfileFiles, "ai-profiles", nil, "YAML file with AI profiles and difficulty line-ups to add to the built-in ones. Repeatable.")
+	tuneCmd.Flags().StringVar(&tuneBase, "base", "Tight-Aggressive", "Profile the search starts from.")
+	tuneCmd.Flags().StringArrayVar(&tuneGrid, "grid", nil, "Grid search values of a parameter, as name=v1,v2,... (e.g. bluffing_frequency=0.1,0.2). Repeatable.")
+	tuneCmd.Flags().IntVar(&tunePopulation, "population", 8, "Number of candidates per generation of the evolutionary search.")
+	tuneCmd.Flags().IntVar(&tuneGenerations, "generations", 5, "Number of generations of the evolutionary search.")
+	tuneCmd.Flags().StringVar(&tuneName, "name", "Tuned", "Name of the best profile. The others get a number appended.")
+	tuneCmd.Flags().IntVar(&tuneKeep, "keep", 3, "Number of best profiles to write.")
+	tuneCmd.Flags().StringVarP(&tuneOutput, "output", "o", "tuned_profiles.yml", "YAML file to write the profiles to.")
+
+	tuneCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if tunePlayers < 2 || tunePlayers > 6 {
+			return fmt.Errorf("players는 2에서 6 사이여야 합니다. 입력값: %d", tunePlayers)
+		}
+		if tuneHands <= 0 {
+			return fmt.Errorf("hands는 0보다 커야 합니다. 입력값: %d", tuneHands)
+		}
+		if tuneKeep <= 0 {
+			return fmt.Errorf("keep은 0보다 커야 합니다. 입력값: %d", tuneKeep)
+		}
+		if tuneWorkers <= 0 {
+			return fmt.Errorf("workers는 0보다 커야 합니다. 입력값: %d", tuneWorkers)
+		}
+		return nil
+	}
+}
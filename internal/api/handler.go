@@ -0,0 +1,439 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// actionTimeout bounds how long an action request waits for the game to reach
+// the next human decision before responding.
+const actionTimeout = 30 * time.Second
+
+// ruleNamePattern restricts rule names to plain file names in the rules directory.
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// RulesLoader loads the rules of a poker variant by name (e.g. "pls7").
+type RulesLoader func(name string) (*poker.GameRules, error)
+
+// Registry holds the games hosted by the API in memory. Finished games are
+// kept for the registry's retention time, so that clients can still fetch the
+// final state, and then removed.
+type Registry struct {
+	mu        sync.Mutex
+	games     map[string]*Session
+	retention time.Duration
+}
+
+// NewRegistry creates an empty registry that keeps finished games for the
+// given time.
+func NewRegistry(retention time.Duration) *Registry {
+	return &Registry{games: make(map[string]*Session), retention: retention}
+}
+
+// Get returns the game with the given ID.
+func (r *Registry) Get(id string) (*Session, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.games[id]
+	return s, ok
+}
+
+// add registers a new session under a fresh ID and starts its game loop.
+func (r *Registry) add(g *engine.Game) (*Session, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newSession(id, g)
+	if err != nil {
+		return nil, err
+	}
+	s.onFinish = func() {
+		time.AfterFunc(r.retention, func() { r.remove(id) })
+	}
+
+	r.mu.Lock()
+	r.games[id] = s
+	r.mu.Unlock()
+
+	s.mu.Lock()
+	idle := s.idle
+	s.mu.Unlock()
+	s.start()
+	<-idle // Wait until the first human decision is pending.
+	return s, nil
+}
+
+// remove drops the game with the given ID from the registry.
+func (r *Registry) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.games, id)
+}
+
+// Close stops all games.
+func (r *Registry) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.games {
+		s.Close()
+	}
+}
+
+// CreateGameRequest is the body of POST /games.
+type CreateGameRequest struct {
+	// Rule is the name of the poker variant (pls7, pls, nlh, plo, plo8, pls7-db, plo8-db).
+	Rule string `json:"rule"`
+	// Players is the total number of seats (2-6).
+	Players int `json:"players"`
+	// Humans lists the names of the human players, who take the first seats.
+	// The remaining seats are filled with CPU players.
+	Humans []string `json:"humans"`
+	// Difficulty is the AI difficulty: easy, medium or hard.
+	Difficulty string `json:"difficulty"`
+	// InitialChips is the starting stack of every player.
+	InitialChips int `json:"initial_chips"`
+	// SmallBlind is the initial small blind.
+	SmallBlind int `json:"small_blind"`
+	// BigBlind is the initial big blind.
+	BigBlind int `json:"big_blind"`
+	// Ante is the initial ante. 0 means no ante.
+	Ante int `json:"ante"`
+	// BigBlindAnte makes the big blind post the ante for the whole table.
+	BigBlindAnte bool `json:"big_blind_ante"`
+	// Button is how the button moves when players are eliminated: moving
+	// (the default) or dead.
+	Button string `json:"button"`
+	// BlindUpInterval is the number of hands after which the blinds double. 0 disables this.
+	BlindUpInterval int `json:"blind_up_interval"`
+	// DecisionTime is the number of seconds every decision may take. 0 disables time limits.
+	DecisionTime int `json:"decision_time"`
+	// TimeBank is the number of extra seconds each player gets for the whole game.
+	TimeBank int `json:"time_bank"`
+}
+
+// SeatToken is returned for each human seat when a game is created.
+type SeatToken struct {
+	// Seat is the seat index.
+	Seat int `json:"seat"`
+	// Name is the player's name.
+	Name string `json:"name"`
+	// Token authorizes requests on behalf of the seat (Authorization: Bearer <token>).
+	Token string `json:"token"`
+}
+
+// CreateGameResponse is the body of a successful POST /games.
+type CreateGameResponse struct {
+	// ID identifies the new game.
+	ID string `json:"id"`
+	// Seats holds one token per human seat.
+	Seats []SeatToken `json:"seats"`
+}
+
+// GameResponse is the body of GET /games/{id} and POST /games/{id}/actions.
+type GameResponse struct {
+	// ID identifies the game.
+	ID string `json:"id"`
+	// Status is "running" or "finished".
+	Status string `json:"status"`
+	// WaitingFor is the seat whose action is awaited, or -1.
+	WaitingFor int `json:"waiting_for"`
+	// View is the game as seen from the authorized seat, or a spectator view.
+	View *engine.PlayerView `json:"view"`
+}
+
+// ErrorResponse is the body of every error response.
+type ErrorResponse struct {
+	// Error describes what went wrong.
+	Error string `json:"error"`
+}
+
+// Handler serves the REST API for the games in a registry.
+type Handler struct {
+	registry *Registry
+	loadRule RulesLoader
+	mux      *http.ServeMux
+}
+
+// NewHandler creates the HTTP handler for the API.
+func NewHandler(registry *Registry, loadRule RulesLoader) *Handler {
+	h := &Handler{registry: registry, loadRule: loadRule, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /games", h.createGame)
+	h.mux.HandleFunc("GET /games/{id}", h.getGame)
+	h.mux.HandleFunc("POST /games/{id}/actions", h.postAction)
+	h.mux.HandleFunc("GET /games/{id}/history", h.getHistory)
+	h.mux.HandleFunc("GET /games/{id}/events", h.streamEvents)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// createGame handles POST /games.
+func (h *Handler) createGame(w http.ResponseWriter, r *http.Request) {
+	req := CreateGameRequest{
+		Rule:         "pls7",
+		Players:      6,
+		Humans:       []string{"YOU"},
+		Difficulty:   "medium",
+		InitialChips: 300000,
+		SmallBlind:   500,
+		BigBlind:     1000,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	g, err := h.newGame(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s, err := h.registry.add(g)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	logrus.Infof("Created game %s (%s, %d players)", s.ID, req.Rule, req.Players)
+
+	resp := CreateGameResponse{ID: s.ID}
+	s.mu.Lock()
+	for token, seat := range s.tokens {
+		resp.Seats = append(resp.Seats, SeatToken{Seat: seat, Name: s.game.Players[seat].Name, Token: token})
+	}
+	s.mu.Unlock()
+	sortSeatTokens(resp.Seats)
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// newGame validates the request and creates the game it describes.
+func (h *Handler) newGame(req CreateGameRequest) (*engine.Game, error) {
+	if !ruleNamePattern.MatchString(req.Rule) {
+		return nil, fmt.Errorf("invalid rule name: %q", req.Rule)
+	}
+	if req.Players < 2 || req.Players > 6 {
+		return nil, fmt.Errorf("players must be between 2 and 6, got %d", req.Players)
+	}
+	if len(req.Humans) < 1 || len(req.Humans) > req.Players {
+		return nil, fmt.Errorf("humans must name between 1 and %d players, got %d", req.Players, len(req.Humans))
+	}
+	seen := make(map[string]bool)
+	for _, name := range req.Humans {
+		if strings.TrimSpace(name) == "" || seen[name] {
+			return nil, fmt.Errorf("human names must be unique and non-empty: %q", name)
+		}
+		seen[name] = true
+	}
+	if req.InitialChips <= 0 || req.SmallBlind <= 0 || req.SmallBlind >= req.BigBlind {
+		return nil, fmt.Errorf("invalid chips or blinds: %d chips, %d/%d", req.InitialChips, req.SmallBlind, req.BigBlind)
+	}
+	if req.Ante < 0 || (req.BigBlindAnte && req.Ante == 0) {
+		return nil, fmt.Errorf("invalid ante: %d (big blind ante: %t)", req.Ante, req.BigBlindAnte)
+	}
+	buttonPolicy := engine.ButtonMoving
+	if req.Button != "" {
+		var err error
+		if buttonPolicy, err = engine.ParseButtonPolicy(req.Button); err != nil {
+			return nil, err
+		}
+	}
+	if req.DecisionTime < 0 || req.TimeBank < 0 {
+		return nil, fmt.Errorf("invalid time control: %ds per decision, %ds time bank", req.DecisionTime, req.TimeBank)
+	}
+
+	var difficulty engine.Difficulty
+	switch req.Difficulty {
+	case "easy":
+		difficulty = engine.DifficultyEasy
+	case "medium":
+		difficulty = engine.DifficultyMedium
+	case "hard":
+		difficulty = engine.DifficultyHard
+	default:
+		return nil, fmt.Errorf("invalid difficulty: %q", req.Difficulty)
+	}
+
+	rules, err := h.loadRule(req.Rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rule %q: %w", req.Rule, err)
+	}
+	if rules.BettingLimit != "pot_limit" && rules.BettingLimit != "no_limit" {
+		return nil, fmt.Errorf("unsupported betting limit: %q", rules.BettingLimit)
+	}
+
+	playerNames := make([]string, req.Players)
+	playerNames[0] = "YOU"
+	for i := 1; i < req.Players; i++ {
+		playerNames[i] = fmt.Sprintf("CPU %d", i)
+	}
+	g := engine.NewGame(playerNames, req.InitialChips, req.SmallBlind, req.BigBlind, difficulty, rules, false, false, req.BlindUpInterval)
+	g.Ante, g.BigBlindAnte = req.Ante, req.BigBlindAnte
+	g.ButtonPolicy = buttonPolicy
+	for seat, name := range req.Humans {
+		if err := g.SetHuman(seat, name); err != nil {
+			return nil, err
+		}
+	}
+	g.SetTimeControl(engine.TimeControl{
+		DecisionTime: time.Duration(req.DecisionTime) * time.Second,
+		TimeBank:     time.Duration(req.TimeBank) * time.Second,
+	})
+	return g, nil
+}
+
+// getGame handles GET /games/{id}.
+func (h *Handler) getGame(w http.ResponseWriter, r *http.Request) {
+	s, seat, ok := h.authorize(w, r, false)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.response(seat))
+}
+
+// postAction handles POST /games/{id}/actions. It responds once the game has
+// processed the action and is waiting for the next human decision.
+func (h *Handler) postAction(w http.ResponseWriter, r *http.Request) {
+	s, seat, ok := h.authorize(w, r, true)
+	if !ok {
+		return
+	}
+	var action engine.PlayerAction
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid action: %w", err))
+		return
+	}
+
+	s.mu.Lock()
+	processed, err := s.submit(seat, action)
+	s.mu.Unlock()
+	if err != nil {
+		var notYourTurn errNotYourTurn
+		if errors.As(err, &notYourTurn) {
+			writeError(w, http.StatusConflict, err)
+		} else {
+			writeError(w, http.StatusUnprocessableEntity, err)
+		}
+		return
+	}
+
+	select {
+	case <-processed:
+	case <-time.After(actionTimeout):
+	case <-r.Context().Done():
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.response(seat))
+}
+
+// getHistory handles GET /games/{id}/history. The optional "since" query
+// parameter returns only the entries with a greater sequence number.
+func (h *Handler) getHistory(w http.ResponseWriter, r *http.Request) {
+	s, _, ok := h.authorize(w, r, false)
+	if !ok {
+		return
+	}
+	since := 0
+	if v := r.URL.Query().Get("since"); v != "" {
+		if _, err := fmt.Sscanf(v, "%d", &since); err != nil || since < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid since: %q", v))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := []HistoryEntry{}
+	if since < len(s.history) {
+		entries = append(entries, s.history[since:]...)
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// authorize looks up the game and the seat of the bearer token. Browsers cannot
+// set headers on WebSocket requests, so the token may also be passed in the
+// "token" query parameter. Without a token the request is treated as a
+// spectator (seat -1) unless a seat is required.
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request, requireSeat bool) (*Session, int, bool) {
+	s, ok := h.registry.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("game not found"))
+		return nil, 0, false
+	}
+
+	token := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		var found bool
+		token, found = strings.CutPrefix(auth, "Bearer ")
+		if !found {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("expected a bearer token"))
+			return nil, 0, false
+		}
+	}
+	if token == "" {
+		if requireSeat {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("a seat token is required"))
+			return nil, 0, false
+		}
+		return s, -1, true
+	}
+
+	s.mu.Lock()
+	seat, ok := s.seatForToken(token)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusUnauthorized, fmt.Errorf("invalid token"))
+		return nil, 0, false
+	}
+	return s, seat, true
+}
+
+// response builds the game response for a seat. The caller must hold the session lock.
+func (s *Session) response(seat int) GameResponse {
+	return GameResponse{
+		ID:         s.ID,
+		Status:     s.status,
+		WaitingFor: s.waitingSeat,
+		View:       s.game.ViewFor(seat),
+	}
+}
+
+// sortSeatTokens orders seat tokens by seat.
+func sortSeatTokens(seats []SeatToken) {
+	for i := 1; i < len(seats); i++ {
+		for j := i; j > 0 && seats[j].Seat < seats[j-1].Seat; j-- {
+			seats[j], seats[j-1] = seats[j-1], seats[j]
+		}
+	}
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logrus.Warnf("Failed to write response: %v", err)
+	}
+}
+
+// writeError writes an ErrorResponse with the given status code.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pls7-cli/internal/config"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"testing"
+	"time"
+)
+
+// newTestServer starts an API server that loads rules from the repository's rules directory.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return serveRegistry(t, NewRegistry(time.Hour))
+}
+
+// serveRegistry starts an API server for the games in the registry.
+func serveRegistry(t *testing.T, registry *Registry) *httptest.Server {
+	t.Helper()
+	loadRule := func(name string) (*poker.GameRules, error) {
+		return config.LoadGameRulesFromFile("../../rules/" + name + ".yml")
+	}
+	ts := httptest.NewServer(NewHandler(registry, loadRule))
+	t.Cleanup(func() {
+		ts.Close()
+		registry.Close()
+	})
+	return ts
+}
+
+// doJSON sends a request with an optional JSON body and bearer token, decodes
+// the response into out and returns the status code.
+func doJSON(t *testing.T, method, url, token string, body, out interface{}) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode response of %s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// createHeadsUpGame creates a game between two human players and returns its ID and tokens.
+func createHeadsUpGame(t *testing.T, ts *httptest.Server) CreateGameResponse {
+	t.Helper()
+	var created CreateGameResponse
+	status := doJSON(t, "POST", ts.URL+"/games", "", CreateGameRequest{
+		Rule:         "pls7",
+		Players:      2,
+		Humans:       []string{"Alice", "Bob"},
+		Difficulty:   "easy",
+		InitialChips: 10000,
+		SmallBlind:   50,
+		BigBlind:     100,
+	}, &created)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", status)
+	}
+	if created.ID == "" || len(created.Seats) != 2 {
+		t.Fatalf("Expected a game ID and 2 seat tokens, got %+v", created)
+	}
+	return created
+}
+
+func TestAPI_CreateGameAndViewPerSeat(t *testing.T) {
+	ts := newTestServer(t)
+	created := createHeadsUpGame(t, ts)
+
+	for _, seat := range created.Seats {
+		var game GameResponse
+		if status := doJSON(t, "GET", ts.URL+"/games/"+created.ID, seat.Token, nil, &game); status != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", status)
+		}
+		if game.Status != StatusRunning || game.View.Seat != seat.Seat || len(game.View.Hand) == 0 {
+			t.Errorf("Expected a running game viewed from seat %d with hole cards, got %+v", seat.Seat, game)
+		}
+		for _, s := range game.View.Seats {
+			if s.Seat != seat.Seat && len(s.Hand) > 0 {
+				t.Errorf("Seat %d can see the hole cards of seat %d", seat.Seat, s.Seat)
+			}
+		}
+		if (game.WaitingFor == seat.Seat) != (game.View.LegalActions != nil) {
+			t.Errorf("Expected legal actions only for the seat to act, got waiting_for=%d for seat %d", game.WaitingFor, seat.Seat)
+		}
+	}
+
+	var spectator GameResponse
+	doJSON(t, "GET", ts.URL+"/games/"+created.ID, "", nil, &spectator)
+	if spectator.View.Seat != -1 || len(spectator.View.Hand) > 0 {
+		t.Errorf("Expected a spectator view without hole cards, got %+v", spectator.View)
+	}
+
+	if status := doJSON(t, "GET", ts.URL+"/games/"+created.ID, "bogus", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for an invalid token, got %d", status)
+	}
+	if status := doJSON(t, "GET", ts.URL+"/games/unknown", "", nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown game, got %d", status)
+	}
+}
+
+func TestAPI_CreateDoubleBoardGame(t *testing.T) {
+	ts := newTestServer(t)
+	var created CreateGameResponse
+	status := doJSON(t, "POST", ts.URL+"/games", "", CreateGameRequest{
+		Rule:         "pls7-db",
+		Players:      2,
+		Humans:       []string{"Alice", "Bob"},
+		Difficulty:   "easy",
+		InitialChips: 10000,
+		SmallBlind:   50,
+		BigBlind:     100,
+	}, &created)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", status)
+	}
+
+	var game GameResponse
+	doJSON(t, "GET", ts.URL+"/games/"+created.ID, created.Seats[0].Token, nil, &game)
+	if game.Status != StatusRunning || len(game.View.Boards) != 2 {
+		t.Errorf("Expected a running game with 2 boards, got %+v", game)
+	}
+}
+
+func TestAPI_CreateGameRejectsInvalidRequests(t *testing.T) {
+	ts := newTestServer(t)
+	tests := []struct {
+		name string
+		req  CreateGameRequest
+	}{
+		{"too many players", CreateGameRequest{Rule: "pls7", Players: 7, Humans: []string{"A"}, Difficulty: "easy", InitialChips: 1000, SmallBlind: 5, BigBlind: 10}},
+		{"no humans", CreateGameRequest{Rule: "pls7", Players: 2, Difficulty: "easy", InitialChips: 1000, SmallBlind: 5, BigBlind: 10}},
+		{"duplicate names", CreateGameRequest{Rule: "pls7", Players: 2, Humans: []string{"A", "A"}, Difficulty: "easy", InitialChips: 1000, SmallBlind: 5, BigBlind: 10}},
+		{"path in rule name", CreateGameRequest{Rule: "../pls7", Players: 2, Humans: []string{"A"}, Difficulty: "easy", InitialChips: 1000, SmallBlind: 5, BigBlind: 10}},
+		{"unknown rule", CreateGameRequest{Rule: "nope", Players: 2, Humans: []string{"A"}, Difficulty: "easy", InitialChips: 1000, SmallBlind: 5, BigBlind: 10}},
+		{"bad difficulty", CreateGameRequest{Rule: "pls7", Players: 2, Humans: []string{"A"}, Difficulty: "insane", InitialChips: 1000, SmallBlind: 5, BigBlind: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ErrorResponse
+			if status := doJSON(t, "POST", ts.URL+"/games", "", tt.req, &resp); status != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", status)
+			}
+			if resp.Error == "" {
+				t.Error("Expected an error message")
+			}
+		})
+	}
+}
+
+func TestAPI_PostActionValidatesTurnAndLegality(t *testing.T) {
+	ts := newTestServer(t)
+	created := createHeadsUpGame(t, ts)
+	url := ts.URL + "/games/" + created.ID
+
+	var game GameResponse
+	doJSON(t, "GET", url, "", nil, &game)
+	actor, other := created.Seats[0], created.Seats[1]
+	if game.WaitingFor != actor.Seat {
+		actor, other = other, actor
+	}
+
+	if status := doJSON(t, "POST", url+"/actions", "", engine.PlayerAction{Type: engine.ActionFold}, nil); status != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without a token, got %d", status)
+	}
+	if status := doJSON(t, "POST", url+"/actions", other.Token, engine.PlayerAction{Type: engine.ActionFold}, nil); status != http.StatusConflict {
+		t.Errorf("Expected status 409 out of turn, got %d", status)
+	}
+	if status := doJSON(t, "POST", url+"/actions", actor.Token, engine.PlayerAction{Type: engine.ActionRaise, Amount: 1}, nil); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422 for an illegal raise, got %d", status)
+	}
+
+	var after GameResponse
+	if status := doJSON(t, "POST", url+"/actions", actor.Token, engine.PlayerAction{Type: engine.ActionCall}, &after); status != http.StatusOK {
+		t.Fatalf("Expected status 200 for a legal call, got %d", status)
+	}
+	if after.WaitingFor != other.Seat {
+		t.Errorf("Expected seat %d to act next, got %d", other.Seat, after.WaitingFor)
+	}
+
+	var history []HistoryEntry
+	if status := doJSON(t, "GET", url+"/history", "", nil, &history); status != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	if len(history) < 2 || history[0].Type != EntryHandStart || history[len(history)-1].Type != EntryAction {
+		t.Fatalf("Expected a hand start followed by the call, got %+v", history)
+	}
+	for i, entry := range history {
+		if entry.Seq != i+1 {
+			t.Errorf("Expected entry %d to have sequence number %d, got %d", i, i+1, entry.Seq)
+		}
+	}
+
+	var since []HistoryEntry
+	doJSON(t, "GET", url+"/history?since=1", "", nil, &since)
+	if len(since) != len(history)-1 {
+		t.Errorf("Expected %d entries after seq 1, got %d", len(history)-1, len(since))
+	}
+}
+
+func TestAPI_PlaysGameToTheEnd(t *testing.T) {
+	ts := newTestServer(t)
+	created := createHeadsUpGame(t, ts)
+	url := ts.URL + "/games/" + created.ID
+	tokens := map[int]string{}
+	for _, seat := range created.Seats {
+		tokens[seat.Seat] = seat.Token
+	}
+
+	var game GameResponse
+	doJSON(t, "GET", url, "", nil, &game)
+	// Raise the maximum whenever possible, so every hand ends in an all-in
+	// showdown and the game is decided within a few hands.
+	for i := 0; game.Status == StatusRunning; i++ {
+		if i > 1000 {
+			t.Fatal("Game did not finish")
+		}
+		token := tokens[game.WaitingFor]
+		doJSON(t, "GET", url, token, nil, &game)
+		if game.View.LegalActions == nil {
+			t.Fatalf("Expected legal actions for seat %d", game.WaitingFor)
+		}
+		legal := game.View.LegalActions
+		action := engine.PlayerAction{Type: engine.ActionCall}
+		switch {
+		case legal.CanBet:
+			action = engine.PlayerAction{Type: engine.ActionBet, Amount: legal.MaxAmount}
+		case legal.CanRaise:
+			action = engine.PlayerAction{Type: engine.ActionRaise, Amount: legal.MaxAmount}
+		case legal.CanCheck:
+			action.Type = engine.ActionCheck
+		}
+		if status := doJSON(t, "POST", url+"/actions", token, action, &game); status != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", status)
+		}
+	}
+
+	if game.WaitingFor != -1 {
+		t.Errorf("Expected no pending action after the game ended, got seat %d", game.WaitingFor)
+	}
+	total := 0
+	for _, s := range game.View.Seats {
+		total += s.Chips
+	}
+	if total != 20000 {
+		t.Errorf("Chips are not conserved: expected 20000, got %d", total)
+	}
+	if status := doJSON(t, "POST", url+"/actions", tokens[0], engine.PlayerAction{Type: engine.ActionFold}, nil); status != http.StatusConflict {
+		t.Errorf("Expected status 409 after the game is over, got %d", status)
+	}
+}
+
+func TestAPI_RemovesFinishedGamesAfterRetention(t *testing.T) {
+	registry := NewRegistry(200 * time.Millisecond)
+	ts := serveRegistry(t, registry)
+	finished := createHeadsUpGame(t, ts)
+	running := createHeadsUpGame(t, ts)
+	url := ts.URL + "/games/" + finished.ID
+
+	s, _ := registry.Get(finished.ID)
+	s.Close()
+	var game GameResponse
+	for deadline := time.Now().Add(5 * time.Second); game.Status != StatusFinished; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Game did not finish after it was closed")
+		}
+		if status := doJSON(t, "GET", url, "", nil, &game); status != http.StatusOK {
+			t.Fatalf("Expected the finished game to stay available for a while, got status %d", status)
+		}
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Finished game was not removed")
+		}
+		if status := doJSON(t, "GET", url, "", nil, nil); status == http.StatusNotFound {
+			break
+		}
+	}
+	if status := doJSON(t, "GET", ts.URL+"/games/"+running.ID, "", nil, nil); status != http.StatusOK {
+		t.Errorf("Expected the running game to stay, got status %d", status)
+	}
+}
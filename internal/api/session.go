@@ -0,0 +1,347 @@
+// Package api exposes poker games over an HTTP/JSON REST API. Games live in an
+// in-memory registry; each human seat is controlled by whoever holds its token.
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"pls7-cli/pkg/engine"
+	"sync"
+)
+
+// Game statuses reported by the API.
+const (
+	// StatusRunning means the game is in progress.
+	StatusRunning = "running"
+	// StatusFinished means the game is over.
+	StatusFinished = "finished"
+)
+
+// History entry types.
+const (
+	EntryHandStart = "hand_start" // Data: engine.HandStartEvent
+	EntryAction    = "action"     // Data: engine.ActionEvent
+	EntryPhase     = "phase"      // Data: engine.PhaseEvent
+	EntryHandEnd   = "hand_end"   // Data: HandEndData
+	EntryMessage   = "message"    // Data: MessageData
+	// EntryState is only streamed to WebSocket clients and not recorded in the
+	// history. Its Seq is that of the last event. Data: GameResponse
+	EntryState = "state"
+)
+
+// subscriberBuffer is the number of messages buffered for a WebSocket client
+// on top of the replayed history. Clients that fall further behind are
+// disconnected and have to resync.
+const subscriberBuffer = 256
+
+// HistoryEntry is a single event in the history of a game. Entries are numbered
+// by a sequence number that increases by one for each event of the game.
+type HistoryEntry struct {
+	// Seq is the sequence number of the entry, starting at 1.
+	Seq int `json:"seq"`
+	// Hand is the number of the hand the entry belongs to.
+	Hand int `json:"hand"`
+	// Type identifies the kind of event and determines the type of Data.
+	Type string `json:"type"`
+	// Data is the event itself.
+	Data interface{} `json:"data"`
+}
+
+// HandEndData is the data of a hand_end history entry.
+type HandEndData struct {
+	engine.HandEndEvent
+	// View is the public view at the end of the hand, revealing shown-down hands.
+	View engine.PublicView `json:"view"`
+}
+
+// MessageData is the data of a message history entry.
+type MessageData struct {
+	// Text is the human-readable message, e.g. an elimination notice.
+	Text string `json:"text"`
+}
+
+// Session is a single game hosted by the API. The game loop runs in its own
+// goroutine; it holds the session lock while it changes the game state and
+// releases it while a player is deciding, so requests can read the state.
+type Session struct {
+	// ID identifies the game in URLs.
+	ID string
+
+	mu          sync.Mutex
+	game        *engine.Game
+	tokens      map[string]int // token -> seat
+	status      string
+	waitingSeat int // seat whose action is awaited, or -1
+	history     []HistoryEntry
+	subscribers map[*subscriber]struct{}
+	onFinish    func() // called once the game is over, if set
+
+	actions chan engine.PlayerAction
+	idle    chan struct{} // closed when the game next waits for a human or finishes
+	quit    chan struct{}
+}
+
+// newSession wraps the game in a session and issues a token for each human seat.
+func newSession(id string, g *engine.Game) (*Session, error) {
+	s := &Session{
+		ID:          id,
+		game:        g,
+		tokens:      make(map[string]int),
+		status:      StatusRunning,
+		waitingSeat: -1,
+		subscribers: make(map[*subscriber]struct{}),
+		actions:     make(chan engine.PlayerAction, 1),
+		idle:        make(chan struct{}),
+		quit:        make(chan struct{}),
+	}
+	for seat, p := range g.Players {
+		if p.IsCPU {
+			continue
+		}
+		token, err := randomHex(16)
+		if err != nil {
+			return nil, err
+		}
+		s.tokens[token] = seat
+	}
+	return s, nil
+}
+
+// start launches the game loop.
+func (s *Session) start() {
+	go s.run()
+}
+
+// run plays hands until the game is over or the session is closed.
+func (s *Session) run() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.game
+	for {
+		g.PlayHand(s, s)
+		for _, msg := range g.CleanupHand() {
+			s.record(EntryMessage, MessageData{Text: msg})
+		}
+
+		if g.CountRemainingPlayers() <= 1 || g.CountRemainingHumans() == 0 || s.closed() {
+			s.status = StatusFinished
+			s.settle()
+			for sub := range s.subscribers {
+				s.unsubscribe(sub)
+			}
+			if s.onFinish != nil {
+				s.onFinish()
+			}
+			return
+		}
+	}
+}
+
+// closed reports whether Close has been called.
+func (s *Session) closed() bool {
+	select {
+	case <-s.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops the game loop. Pending human decisions are answered by folding
+// so the current hand can finish.
+func (s *Session) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed() {
+		close(s.quit)
+	}
+}
+
+// settle wakes up the requests waiting for the game to need a human decision.
+// The caller must hold the session lock.
+func (s *Session) settle() {
+	close(s.idle)
+	s.idle = make(chan struct{})
+	s.publishState()
+}
+
+// GetAction implements engine.ActionProvider.
+func (s *Session) GetAction(g *engine.Game, p *engine.Player, r *mathrand.Rand) engine.PlayerAction {
+	action, _ := s.GetActionContext(context.Background(), g, p, r)
+	return action
+}
+
+// GetActionContext implements engine.ContextActionProvider. It is called from
+// the game loop with the session lock held and releases it while the player
+// decides.
+func (s *Session) GetActionContext(ctx context.Context, g *engine.Game, p *engine.Player, r *mathrand.Rand) (engine.PlayerAction, error) {
+	if p.IsCPU {
+		s.mu.Unlock()
+		defer s.mu.Lock()
+		return g.GetCPUAction(p, r), nil
+	}
+
+	s.waitingSeat = p.Position
+	s.settle()
+	s.mu.Unlock()
+
+	var action engine.PlayerAction
+	var err error
+	select {
+	case action = <-s.actions:
+	case <-s.quit:
+		action = engine.PlayerAction{Type: engine.ActionFold}
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	s.mu.Lock()
+	s.waitingSeat = -1
+	select {
+	case <-s.actions: // Discard an action submitted just as the time ran out.
+	default:
+	}
+	return action, err
+}
+
+// OnEvent implements engine.HandObserver by recording the events in the history.
+func (s *Session) OnEvent(g *engine.Game, event engine.Event) {
+	switch e := event.(type) {
+	case *engine.HandStartEvent:
+		s.record(EntryHandStart, e)
+	case *engine.ActionEvent:
+		s.record(EntryAction, e)
+	case *engine.PhaseEvent:
+		s.record(EntryPhase, e)
+	case *engine.HandEndEvent:
+		s.record(EntryHandEnd, HandEndData{HandEndEvent: *e, View: g.PublicView()})
+	}
+}
+
+// record appends an entry to the history. The caller must hold the session lock.
+func (s *Session) record(entryType string, data interface{}) {
+	entry := HistoryEntry{
+		Seq:  len(s.history) + 1,
+		Hand: s.game.HandCount,
+		Type: entryType,
+		Data: data,
+	}
+	s.history = append(s.history, entry)
+	for sub := range s.subscribers {
+		s.push(sub, entry)
+	}
+	s.publishState()
+}
+
+// subscriber is a WebSocket client following the game from a seat, or from
+// seat -1 as a spectator.
+type subscriber struct {
+	seat     int
+	messages chan HistoryEntry // closed when the subscription ends
+}
+
+// subscribe registers a client that receives the events after the given
+// sequence number, followed by the current state and all subsequent events and
+// state updates. The caller must hold the session lock.
+func (s *Session) subscribe(seat, since int) *subscriber {
+	var replay []HistoryEntry
+	if since < len(s.history) {
+		replay = s.history[since:]
+	}
+	sub := &subscriber{seat: seat, messages: make(chan HistoryEntry, len(replay)+subscriberBuffer)}
+	for _, entry := range replay {
+		sub.messages <- entry
+	}
+	sub.messages <- s.stateEntry(seat)
+
+	if s.status == StatusFinished {
+		close(sub.messages)
+	} else {
+		s.subscribers[sub] = struct{}{}
+	}
+	return sub
+}
+
+// unsubscribe ends a subscription. The caller must hold the session lock.
+func (s *Session) unsubscribe(sub *subscriber) {
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.messages)
+	}
+}
+
+// push queues a message for a subscriber, dropping the subscriber if it has
+// fallen too far behind. The caller must hold the session lock.
+func (s *Session) push(sub *subscriber, entry HistoryEntry) {
+	select {
+	case sub.messages <- entry:
+	default:
+		s.unsubscribe(sub)
+	}
+}
+
+// publishState sends each subscriber the game as seen from its seat. The
+// caller must hold the session lock.
+func (s *Session) publishState() {
+	for sub := range s.subscribers {
+		s.push(sub, s.stateEntry(sub.seat))
+	}
+}
+
+// stateEntry wraps the state seen from a seat in a stream message. The caller
+// must hold the session lock.
+func (s *Session) stateEntry(seat int) HistoryEntry {
+	return HistoryEntry{
+		Seq:  len(s.history),
+		Hand: s.game.HandCount,
+		Type: EntryState,
+		Data: s.response(seat),
+	}
+}
+
+// seatForToken returns the seat controlled by the token.
+func (s *Session) seatForToken(token string) (int, bool) {
+	seat, ok := s.tokens[token]
+	return seat, ok
+}
+
+// submit validates an action for the seat and hands it to the game loop. It
+// returns a channel that is closed once the game has processed the action and
+// is waiting for the next human decision (or is over). The caller must hold the
+// session lock.
+func (s *Session) submit(seat int, action engine.PlayerAction) (<-chan struct{}, error) {
+	if s.status != StatusRunning {
+		return nil, errNotYourTurn{msg: "the game is over"}
+	}
+	if s.waitingSeat != seat {
+		return nil, errNotYourTurn{msg: "it is not your turn"}
+	}
+	if err := s.game.ValidateAction(s.game.Players[seat], action); err != nil {
+		return nil, err
+	}
+	s.waitingSeat = -1 // Accept a single action per decision.
+	s.actions <- action
+	return s.idle, nil
+}
+
+// errNotYourTurn is returned when an action arrives while the seat is not expected to act.
+type errNotYourTurn struct {
+	msg string
+}
+
+func (e errNotYourTurn) Error() string {
+	return e.msg
+}
+
+// randomHex returns n random bytes encoded as a hexadecimal string.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random identifier: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
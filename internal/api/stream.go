@@ -0,0 +1,101 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// writeTimeout bounds how long a single WebSocket write may take.
+	writeTimeout = 10 * time.Second
+	// pongTimeout is how long a WebSocket client may stay silent before it is
+	// considered gone. Pings are sent at half this interval.
+	pongTimeout = 60 * time.Second
+)
+
+// upgrader upgrades HTTP requests to WebSocket connections. Seats are
+// authorized by token rather than cookies, so requests from any origin are
+// accepted.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// streamEvents handles GET /games/{id}/events. It upgrades the connection to a
+// WebSocket and streams the history entries after the "since" query parameter,
+// followed by every new event and a "state" message with the game as seen from
+// the token's seat whenever it changes. Clients without a token are spectators
+// and only receive public information. The stream ends when the game is over.
+func (h *Handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	s, seat, ok := h.authorize(w, r, false)
+	if !ok {
+		return
+	}
+	since := 0
+	if v := r.URL.Query().Get("since"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid since: %q", v))
+			return
+		}
+		since = n
+	}
+
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // The upgrader has already replied with an error.
+	}
+	defer ws.Close()
+
+	s.mu.Lock()
+	sub := s.subscribe(seat, since)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.unsubscribe(sub)
+		s.mu.Unlock()
+	}()
+
+	// Read in the background to process pongs and notice when the client leaves.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		_ = ws.SetReadDeadline(time.Now().Add(pongTimeout))
+		ws.SetPongHandler(func(string) error {
+			return ws.SetReadDeadline(time.Now().Add(pongTimeout))
+		})
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(pongTimeout / 2)
+	defer ping.Stop()
+	for {
+		select {
+		case entry, ok := <-sub.messages:
+			_ = ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if !ok {
+				_ = ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "stream ended"))
+				return
+			}
+			if err := ws.WriteJSON(entry); err != nil {
+				logrus.Debugf("Failed to write to WebSocket client of game %s: %v", s.ID, err)
+				return
+			}
+		case <-ping.C:
+			_ = ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := ws.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-gone:
+			return
+		}
+	}
+}
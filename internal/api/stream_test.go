@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"pls7-cli/pkg/engine"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// streamMessage is a message received from the event stream, with the data left raw.
+type streamMessage struct {
+	Seq  int             `json:"seq"`
+	Hand int             `json:"hand"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// dialEvents connects to the event stream of a game.
+func dialEvents(t *testing.T, ts *httptest.Server, id, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/games/" + id + "/events" + query
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect to %s: %v", url, err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+// readUntilState reads stream messages up to and including the next state
+// message and returns the events before it and the state.
+func readUntilState(t *testing.T, ws *websocket.Conn) ([]streamMessage, GameResponse) {
+	t.Helper()
+	_ = ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var events []streamMessage
+	for {
+		var msg streamMessage
+		if err := ws.ReadJSON(&msg); err != nil {
+			t.Fatalf("Failed to read from the stream: %v", err)
+		}
+		if msg.Type != EntryState {
+			events = append(events, msg)
+			continue
+		}
+		var state GameResponse
+		if err := json.Unmarshal(msg.Data, &state); err != nil {
+			t.Fatal(err)
+		}
+		return events, state
+	}
+}
+
+func TestStream_ReplaysHistoryAndPushesEvents(t *testing.T) {
+	ts := newTestServer(t)
+	created := createHeadsUpGame(t, ts)
+	seat := created.Seats[0]
+
+	ws := dialEvents(t, ts, created.ID, "?token="+seat.Token)
+	events, state := readUntilState(t, ws)
+	if len(events) == 0 || events[0].Type != EntryHandStart || events[0].Seq != 1 {
+		t.Fatalf("Expected the history to be replayed from the hand start, got %+v", events)
+	}
+	if state.View.Seat != seat.Seat || len(state.View.Hand) == 0 {
+		t.Errorf("Expected the state seen from seat %d with hole cards, got %+v", seat.Seat, state.View)
+	}
+	lastSeq := events[len(events)-1].Seq
+
+	// Act over HTTP and expect the action to be pushed.
+	token := created.Seats[state.WaitingFor].Token
+	if status := doJSON(t, "POST", ts.URL+"/games/"+created.ID+"/actions", token, engine.PlayerAction{Type: engine.ActionCall}, nil); status != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", status)
+	}
+	events, _ = readUntilState(t, ws)
+	if len(events) != 1 || events[0].Type != EntryAction || events[0].Seq != lastSeq+1 {
+		t.Fatalf("Expected the call to be pushed with seq %d, got %+v", lastSeq+1, events)
+	}
+	var action engine.ActionEvent
+	if err := json.Unmarshal(events[0].Data, &action); err != nil {
+		t.Fatal(err)
+	}
+	if action.Action != engine.ActionCall {
+		t.Errorf("Expected a call event, got %+v", action)
+	}
+}
+
+func TestStream_ResyncsFromSequenceNumber(t *testing.T) {
+	ts := newTestServer(t)
+	created := createHeadsUpGame(t, ts)
+	var game GameResponse
+	doJSON(t, "GET", ts.URL+"/games/"+created.ID, "", nil, &game)
+	doJSON(t, "POST", ts.URL+"/games/"+created.ID+"/actions", created.Seats[game.WaitingFor].Token, engine.PlayerAction{Type: engine.ActionCall}, nil)
+
+	var history []HistoryEntry
+	doJSON(t, "GET", ts.URL+"/games/"+created.ID+"/history", "", nil, &history)
+	if len(history) < 2 {
+		t.Fatalf("Expected at least 2 history entries, got %d", len(history))
+	}
+
+	ws := dialEvents(t, ts, created.ID, "?since=1")
+	events, state := readUntilState(t, ws)
+	if len(events) != len(history)-1 || events[0].Seq != 2 {
+		t.Errorf("Expected the %d events after seq 1, got %+v", len(history)-1, events)
+	}
+	if state.Status != StatusRunning {
+		t.Errorf("Expected a running game, got %q", state.Status)
+	}
+
+	ws = dialEvents(t, ts, created.ID, "?since=1000")
+	if events, _ := readUntilState(t, ws); len(events) != 0 {
+		t.Errorf("Expected no events after an up-to-date seq, got %+v", events)
+	}
+}
+
+func TestStream_SpectatorsOnlySeePublicInformation(t *testing.T) {
+	ts := newTestServer(t)
+	created := createHeadsUpGame(t, ts)
+
+	ws := dialEvents(t, ts, created.ID, "")
+	_, state := readUntilState(t, ws)
+	if state.View.Seat != -1 || len(state.View.Hand) > 0 || state.View.LegalActions != nil {
+		t.Errorf("Expected a spectator view, got %+v", state.View)
+	}
+	for _, s := range state.View.Seats {
+		if len(s.Hand) > 0 {
+			t.Errorf("Spectator can see the hole cards of seat %d", s.Seat)
+		}
+	}
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/games/" + created.ID + "/events?token=bogus"
+	if _, resp, err := websocket.DefaultDialer.Dial(url, nil); err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected an invalid token to be refused with 401, got %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+// Package bot lets external programs play seats at the table. A bot is a
+// subprocess that speaks a line-based JSON protocol over stdin and stdout:
+//
+//	engine -> bot: {"type": "act", "data": <engine.PlayerView with legal_actions>}
+//	bot -> engine: {"type": "raise", "amount": 3000}
+//	engine -> bot: {"type": "quit"}
+//
+// Each request is a single line and each "act" request must be answered with a
+// single line holding an engine.PlayerAction. Bots may write diagnostics to stderr.
+package bot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os/exec"
+	"pls7-cli/pkg/engine"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Request types sent to bots.
+const (
+	// MsgAct asks the bot for the action of its seat. Data: engine.PlayerView
+	MsgAct = "act"
+	// MsgQuit tells the bot that the game is over and it should exit. No data.
+	MsgQuit = "quit"
+)
+
+// quitTimeout is how long a bot may take to exit after the quit request before it is killed.
+const quitTimeout = 2 * time.Second
+
+// Request is a single line sent to a bot.
+type Request struct {
+	// Type determines the meaning of Data.
+	Type string `json:"type"`
+	// Data is the payload of the request, if any.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// ErrTimeout is returned when a bot does not answer in time.
+var ErrTimeout = errors.New("bot did not answer in time")
+
+// Bot is a running bot subprocess.
+type Bot struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	lines   chan string // lines written by the bot; closed when its stdout closes
+	timeout time.Duration
+
+	mu sync.Mutex
+}
+
+// Start launches the bot command. The command's stdin and stdout are used for
+// the protocol; its stderr is left as configured by the caller. Every request
+// for an action must be answered within the timeout.
+func Start(cmd *exec.Cmd, timeout time.Duration) (*Bot, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the bot's stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the bot's stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start bot %s: %w", cmd.Path, err)
+	}
+
+	b := &Bot{cmd: cmd, stdin: stdin, lines: make(chan string, 16), timeout: timeout}
+	go func() {
+		defer close(b.lines)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			b.lines <- scanner.Text()
+		}
+	}()
+	return b, nil
+}
+
+// Act sends the view to the bot and waits for its action, until the bot's
+// timeout expires or the context is done. A bot that has not even read the
+// view by then has stopped reading its requests, and is killed. The action is
+// not validated against the legal actions.
+func (b *Bot) Act(ctx context.Context, view *engine.PlayerView) (engine.PlayerAction, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Discard late answers to earlier requests that timed out.
+	for drained := false; !drained; {
+		select {
+		case _, ok := <-b.lines:
+			if !ok {
+				return engine.PlayerAction{}, fmt.Errorf("bot has exited")
+			}
+		default:
+			drained = true
+		}
+	}
+
+	deadline := time.After(b.timeout)
+	written := make(chan error, 1)
+	go func() { written <- b.send(Request{Type: MsgAct, Data: view}) }()
+	select {
+	case err := <-written:
+		if err != nil {
+			return engine.PlayerAction{}, err
+		}
+	case <-deadline:
+		b.kill(written)
+		return engine.PlayerAction{}, ErrTimeout
+	case <-ctx.Done():
+		b.kill(written)
+		return engine.PlayerAction{}, ctx.Err()
+	}
+
+	select {
+	case line, ok := <-b.lines:
+		if !ok {
+			return engine.PlayerAction{}, fmt.Errorf("bot has exited")
+		}
+		var action engine.PlayerAction
+		if err := json.Unmarshal([]byte(line), &action); err != nil {
+			return engine.PlayerAction{}, fmt.Errorf("invalid answer from bot %q: %w", line, err)
+		}
+		return action, nil
+	case <-deadline:
+		return engine.PlayerAction{}, ErrTimeout
+	case <-ctx.Done():
+		return engine.PlayerAction{}, ctx.Err()
+	}
+}
+
+// Close asks the bot to quit and waits for it to exit, killing it if it does not.
+func (b *Bot) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The bot may have stopped reading, so the request is written while
+	// waiting for the bot to exit.
+	go func() {
+		_ = b.send(Request{Type: MsgQuit})
+		_ = b.stdin.Close()
+	}()
+
+	exited := make(chan error, 1)
+	go func() { exited <- b.cmd.Wait() }()
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(quitTimeout):
+		_ = b.cmd.Process.Kill()
+		return <-exited
+	}
+}
+
+// kill stops a bot that has not read a request, and waits for the write of
+// the request to fail.
+func (b *Bot) kill(written <-chan error) {
+	_ = b.cmd.Process.Kill()
+	// Closing stdin also unblocks the write if the bot's children still
+	// hold the pipe open.
+	_ = b.stdin.Close()
+	<-written
+}
+
+// send writes a request as a single line.
+func (b *Bot) send(req Request) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if _, err := b.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to bot: %w", err)
+	}
+	return nil
+}
+
+// Provider implements engine.ActionProvider by asking bots for the actions of
+// their seats. Other seats are handled by the fallback provider.
+type Provider struct {
+	// Bots maps seat indexes to the bots playing them.
+	Bots map[int]*Bot
+	// Fallback provides the actions of seats without a bot.
+	Fallback engine.ActionProvider
+}
+
+// GetAction implements engine.ActionProvider.
+func (p *Provider) GetAction(g *engine.Game, player *engine.Player, r *rand.Rand) engine.PlayerAction {
+	action, _ := p.GetActionContext(context.Background(), g, player, r)
+	return action
+}
+
+// GetActionContext asks the seat's bot for an action. If the bot fails to
+// answer in time, has exited or answers with an illegal action, the player
+// checks if possible and folds otherwise. Seats without a bot are passed on to
+// the fallback provider along with the context.
+func (p *Provider) GetActionContext(ctx context.Context, g *engine.Game, player *engine.Player, r *rand.Rand) (engine.PlayerAction, error) {
+	b, ok := p.Bots[player.Position]
+	if !ok {
+		return engine.RequestAction(ctx, p.Fallback, g, player, r)
+	}
+
+	action, err := b.Act(ctx, g.StrictViewFor(player.Position))
+	if err == nil {
+		err = g.ValidateAction(player, action)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return engine.PlayerAction{}, ctx.Err()
+		}
+		logrus.Warnf("Bot for %s failed to act: %v", player.Name, err)
+		return g.DefaultAction(player), nil
+	}
+	return action, nil
+}
+
+// AgreeToRunouts implements engine.RunoutProvider. The protocol does not ask
+// bots, so they decline; seats without a bot are passed on to the fallback
+// provider.
+func (p *Provider) AgreeToRunouts(g *engine.Game, player *engine.Player, times int) bool {
+	if _, ok := p.Bots[player.Position]; ok {
+		return false
+	}
+	return engine.RequestRunouts(p.Fallback, g, player, times)
+}
+
+// Close stops all bots.
+func (p *Provider) Close() {
+	for seat, b := range p.Bots {
+		if err := b.Close(); err != nil {
+			logrus.Debugf("Bot for seat %d exited with an error: %v", seat, err)
+		}
+	}
+}
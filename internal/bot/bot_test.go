@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"pls7-cli/internal/config"
+	"pls7-cli/pkg/engine"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHelperBot is not a real test. It is run as a bot subprocess by the other
+// tests, behaving as selected by the PLS7_BOT_HELPER environment variable.
+func TestHelperBot(t *testing.T) {
+	mode := os.Getenv("PLS7_BOT_HELPER")
+	if mode == "" {
+		return
+	}
+	if mode == "deaf" {
+		// Never read a request.
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var req struct {
+			Type string             `json:"type"`
+			Data *engine.PlayerView `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid request: %v\n", err)
+			os.Exit(2)
+		}
+		if req.Type == MsgQuit {
+			os.Exit(0)
+		}
+
+		switch mode {
+		case "passive":
+			// Check or call, using the legal actions in the view.
+			action := `{"type": "call"}`
+			if req.Data.LegalActions.CanCheck {
+				action = `{"type": "check"}`
+			}
+			fmt.Println(action)
+		case "illegal":
+			fmt.Println(`{"type": "raise", "amount": 1}`)
+		case "garbage":
+			fmt.Println("I fold!")
+		case "slow":
+			time.Sleep(time.Second)
+			fmt.Println(`{"type": "call"}`)
+		case "crash":
+			os.Exit(3)
+		case "peek":
+			// Call, unless the hole cards of another seat can be seen.
+			action := `{"type": "call"}`
+			for _, seat := range req.Data.Seats {
+				if seat.Seat != req.Data.Seat && len(seat.Hand) > 0 {
+					action = `{"type": "fold"}`
+				}
+			}
+			fmt.Println(action)
+		}
+	}
+	os.Exit(0)
+}
+
+// startHelperBot starts the test binary as a bot behaving in the given mode.
+func startHelperBot(t *testing.T, mode string, timeout time.Duration) *Bot {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperBot$")
+	cmd.Env = append(os.Environ(), "PLS7_BOT_HELPER="+mode)
+	cmd.Stderr = os.Stderr
+	b, err := Start(cmd, timeout)
+	if err != nil {
+		t.Fatalf("Failed to start helper bot: %v", err)
+	}
+	return b
+}
+
+// newHeadsUpGame creates a heads-up game with 10000 chips per player.
+func newHeadsUpGame(t *testing.T) *engine.Game {
+	t.Helper()
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls7.yml")
+	if err != nil {
+		t.Fatalf("Failed to load game rules: %v", err)
+	}
+	return engine.NewGame([]string{"YOU", "CPU1"}, 10000, 50, 100, engine.DifficultyMedium, rules, true, false, 0)
+}
+
+func TestProvider_UsesBotActions(t *testing.T) {
+	g := newHeadsUpGame(t)
+	g.StartNewHand()
+	b := startHelperBot(t, "passive", 5*time.Second)
+	provider := &Provider{Bots: map[int]*Bot{g.CurrentTurnPos: b}}
+	defer provider.Close()
+
+	player := g.CurrentPlayer()
+	action := provider.GetAction(g, player, g.Rand)
+	if action.Type != engine.ActionCall {
+		t.Errorf("Expected the bot to call the big blind, got %+v", action)
+	}
+}
+
+func TestProvider_FallsBackToDefaultAction(t *testing.T) {
+	tests := []struct {
+		mode    string
+		timeout time.Duration
+	}{
+		{"illegal", 5 * time.Second},
+		{"garbage", 5 * time.Second},
+		{"slow", 100 * time.Millisecond},
+		{"crash", 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			g := newHeadsUpGame(t)
+			g.StartNewHand()
+			b := startHelperBot(t, tt.mode, tt.timeout)
+			provider := &Provider{Bots: map[int]*Bot{g.CurrentTurnPos: b}}
+			defer provider.Close()
+
+			// Facing the big blind, the default action is to fold.
+			player := g.CurrentPlayer()
+			if action := provider.GetAction(g, player, g.Rand); action.Type != engine.ActionFold {
+				t.Errorf("Expected a fold, got %+v", action)
+			}
+		})
+	}
+}
+
+func TestProvider_HidesDevModeCards(t *testing.T) {
+	g := newHeadsUpGame(t)
+	g.DevMode = true
+	g.StartNewHand()
+	b := startHelperBot(t, "peek", 5*time.Second)
+	provider := &Provider{Bots: map[int]*Bot{g.CurrentTurnPos: b}}
+	defer provider.Close()
+
+	player := g.CurrentPlayer()
+	if action := provider.GetAction(g, player, g.Rand); action.Type != engine.ActionCall {
+		t.Errorf("Expected the bot not to see the other hole cards in dev mode, got %+v", action)
+	}
+}
+
+func TestBot_KilledWhenNotReading(t *testing.T) {
+	b := startHelperBot(t, "deaf", 100*time.Millisecond)
+	// The view is larger than a pipe's buffer, so writing it blocks until the
+	// bot reads it.
+	view := &engine.PlayerView{PublicView: engine.PublicView{Seats: []engine.SeatView{{Name: strings.Repeat("x", 1<<20)}}}}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.Act(context.Background(), view)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("Expected a timeout, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Act to give up on a bot that does not read")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		_ = b.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Close to return for a killed bot")
+	}
+}
+
+func TestProvider_DefersToFallbackForOtherSeats(t *testing.T) {
+	g := newHeadsUpGame(t)
+	g.StartNewHand()
+	fallback := &recordingProvider{}
+	provider := &Provider{Bots: map[int]*Bot{}, Fallback: fallback}
+
+	player := g.CurrentPlayer()
+	provider.GetAction(g, player, g.Rand)
+	if fallback.calls != 1 {
+		t.Errorf("Expected the fallback provider to be asked once, got %d", fallback.calls)
+	}
+}
+
+func TestBot_PlaysFullHands(t *testing.T) {
+	g := newHeadsUpGame(t)
+	provider := &Provider{Bots: map[int]*Bot{
+		0: startHelperBot(t, "passive", 5*time.Second),
+		1: startHelperBot(t, "passive", 5*time.Second),
+	}}
+	defer provider.Close()
+
+	for i := 0; i < 3; i++ {
+		end := g.PlayHand(provider, nil)
+		if !end.Showdown {
+			t.Errorf("Expected passive bots to reach showdown, got %+v", end)
+		}
+		g.CleanupHand()
+	}
+	if total := g.Players[0].Chips + g.Players[1].Chips; total != 20000 {
+		t.Errorf("Chips are not conserved: expected 20000, got %d", total)
+	}
+}
+
+// recordingProvider counts the actions it is asked for and always folds.
+type recordingProvider struct {
+	calls int
+}
+
+func (p *recordingProvider) GetAction(*engine.Game, *engine.Player, *rand.Rand) engine.PlayerAction {
+	p.calls++
+	return engine.PlayerAction{Type: engine.ActionFold}
+}
@@ -6,33 +6,74 @@ import (
 	"pls7-cli/pkg/poker"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-// DisplayGameState prints the current state of the game board and players.
+// DisplayGameState prints the current state of the game board and players from
+// the perspective of the first human player, which suits a single human playing
+// against CPUs. If there is no human at the table, only public information is shown.
 func DisplayGameState(g *engine.Game) {
-	if !g.DevMode {
+	var viewer *engine.Player
+	for _, p := range g.Players {
+		if !p.IsCPU {
+			viewer = p
+			break
+		}
+	}
+	DisplayGameStateFor(g, viewer)
+}
+
+// DisplayGameStateFor prints the game state from the perspective of a single
+// viewer: only the viewer's own hole cards are shown. A nil viewer sees no hole
+// cards at all, which is used between turns in hot-seat games.
+func DisplayGameStateFor(g *engine.Game, viewer *engine.Player) {
+	seat := -1
+	if viewer != nil {
+		seat = viewer.Position
+	}
+	checkTotalChips(g)
+	DisplayPlayerView(g.ViewFor(seat))
+}
+
+// DisplayPlayerView clears the screen (outside dev mode) and prints the given view.
+func DisplayPlayerView(v *engine.PlayerView) {
+	if !v.DevMode {
 		clearScreen()
 	}
+	fmt.Print(FormatPlayerView(v))
+}
 
+// FormatPlayerView renders a per-viewer snapshot of the game board and players.
+// Only the hole cards contained in the view are shown, so the same renderer can
+// be used for local players and remote clients.
+func FormatPlayerView(v *engine.PlayerView) string {
 	var output string // Concat all output here and print at once not to be mixed with other logs
 
-	phaseName := strings.ToUpper(g.Phase.String())
-	output += fmt.Sprintf("\n\n--- %s (%s) | HAND #%d | PHASE: %s | POT: %s | BLINDS: %s/%s ---\n",
-		g.Rules.Abbreviation, g.Difficulty, g.HandCount, phaseName,
-		FormatNumber(g.Pot), FormatNumber(g.SmallBlind), FormatNumber(g.BigBlind),
+	phaseName := strings.ToUpper(v.Phase.String())
+	output += fmt.Sprintf("\n\n--- %s (%s) | HAND #%d%s | PHASE: %s | POT: %s | BLINDS: %s/%s%s%s ---\n",
+		v.Rules.Abbreviation, v.Difficulty, v.HandCount, formatHandType(v.HandType), phaseName,
+		FormatNumber(v.Pot), FormatNumber(v.SmallBlind), FormatNumber(v.BigBlind),
+		formatAnte(v.Ante, v.BigBlindAnte, " | ANTE: %s", " | BB ANTE: %s"), formatLevel(v.PublicView),
 	)
 
-	var communityCardStrings []string
-	for _, c := range g.CommunityCards {
-		communityCardStrings = append(communityCardStrings, c.String())
+	boards := viewBoards(v.PublicView)
+	for b, board := range boards {
+		var communityCardStrings []string
+		for _, c := range board {
+			communityCardStrings = append(communityCardStrings, c.String())
+		}
+		label := "Board"
+		if len(boards) > 1 {
+			label = fmt.Sprintf("%s %d", boardLabel(v.PublicView), b+1)
+		}
+		output += fmt.Sprintf("%s: %s\n", label, strings.Join(communityCardStrings, " "))
 	}
-	output += fmt.Sprintf("Board: %s\n\n", strings.Join(communityCardStrings, " "))
+	output += "\n"
 
-	totalChips := g.Pot
 	output += fmt.Sprintln("Players:")
-	for i, p := range g.Players {
+	for i, p := range v.Seats {
 		// --- NEW: Skip eliminated players from the display ---
 		if p.Status == engine.PlayerStatusEliminated {
 			continue
@@ -40,10 +81,10 @@ func DisplayGameState(g *engine.Game) {
 		// --- END OF NEW PART ---
 
 		indicator := "  "
-		if i == g.DealerPos {
+		if i == v.DealerPos {
 			indicator = "D "
 		}
-		if i == g.CurrentTurnPos {
+		if i == v.CurrentTurnPos {
 			indicator = "> "
 		}
 
@@ -54,20 +95,33 @@ func DisplayGameState(g *engine.Game) {
 		if p.Status == engine.PlayerStatusAllIn && p.CurrentBet == 0 {
 			status = "(All In)"
 		}
+		if p.Status == engine.PlayerStatusSittingOut {
+			status = "(Sitting Out)"
+		}
 
 		handInfo := ""
-		if !p.IsCPU || g.DevMode {
+		if len(p.Hand) > 0 {
 			var handStrings []string
 			for _, c := range p.Hand {
 				handStrings = append(handStrings, c.String())
 			}
 			handInfo = fmt.Sprintf("| Hand: %s", strings.Join(handStrings, " "))
 
-			if g.Phase > engine.PhasePreFlop {
-				highRank, lowRank := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
-				rankInfo := fmt.Sprintf(" | High: %s", highRank.String())
-				if g.Rules.LowHand.Enabled && lowRank != nil {
-					rankInfo += fmt.Sprintf(", Low: %s", lowRank.String())
+			if v.Phase > engine.PhasePreFlop {
+				// With several boards, the hands on each board are separated by slashes.
+				var highRanks, lowRanks []string
+				for _, board := range boards {
+					highRank, lowRank := poker.EvaluateHand(p.Hand, board, &v.Rules)
+					highRanks = append(highRanks, highRank.String())
+					if lowRank != nil {
+						lowRanks = append(lowRanks, lowRank.String())
+					} else {
+						lowRanks = append(lowRanks, "-")
+					}
+				}
+				rankInfo := fmt.Sprintf(" | High: %s", strings.Join(highRanks, " / "))
+				if v.Rules.LowHand.Enabled && strings.Trim(strings.Join(lowRanks, ""), "-") != "" {
+					rankInfo += fmt.Sprintf(", Low: %s", strings.Join(lowRanks, " / "))
 				}
 				handInfo += rankInfo
 			}
@@ -76,25 +130,26 @@ func DisplayGameState(g *engine.Game) {
 		actionInfo := ""
 		if p.Status != engine.PlayerStatusEliminated {
 			actionInfo = fmt.Sprintf(", Current Bet: %-6s", FormatNumber(p.CurrentBet))
-			if p.LastActionDesc != "" && i != g.CurrentTurnPos {
-				actionInfo += fmt.Sprintf(" - %s", p.LastActionDesc)
+			if p.LastAction != "" && i != v.CurrentTurnPos {
+				actionInfo += fmt.Sprintf(" - %s", p.LastAction)
 			}
 		}
 		logrus.Debugf(
 			"Player %s: Status: %v, Current Bet: %s, Last Action: %s, actionInfo: [%s]",
-			p.Name, p.Status, FormatNumber(p.CurrentBet), p.LastActionDesc, actionInfo,
+			p.Name, p.Status, FormatNumber(p.CurrentBet), p.LastAction, actionInfo,
 		)
 
 		nameInfo := fmt.Sprintf("%s%s", indicator, p.Name)
-		if p.IsCPU && g.DevMode {
-			nameInfo = fmt.Sprintf("%s%s (%s)", indicator, p.Name, p.Profile.Name)
+		if p.ProfileName != "" {
+			nameInfo = fmt.Sprintf("%s%s (%s)", indicator, p.Name, p.ProfileName)
 		}
 		line := fmt.Sprintf("% -30s: Chips: %-9s%s %s %s", nameInfo, FormatNumber(p.Chips), actionInfo, status, handInfo)
 		output += fmt.Sprintln(strings.TrimSpace(line))
 
-		// Display outs for the player in dev mode
-		if g.CanShowOuts(p) {
-			hasOuts, outsInfo := poker.CalculateOuts(p.Hand, g.CommunityCards, g.Rules)
+		// Display outs for the viewer in dev mode. Outs are only counted on a
+		// single board.
+		if i == v.Seat && v.CanShowOuts && len(boards) == 1 {
+			hasOuts, outsInfo := poker.CalculateOuts(p.Hand, v.CommunityCards, &v.Rules)
 			if hasOuts {
 				sort.Slice(outsInfo.AllOuts, func(i, j int) bool {
 					if outsInfo.AllOuts[i].Suit != outsInfo.AllOuts[j].Suit {
@@ -104,11 +159,77 @@ func DisplayGameState(g *engine.Game) {
 				})
 				output += formatOuts(outsInfo)
 
-				amountToCall := g.BetToCall - p.CurrentBet
-				output += formatEquities(g.Pot, amountToCall, len(outsInfo.AllOuts), g.Phase)
+				amountToCall := v.BetToCall - p.CurrentBet
+				output += formatEquities(v.Pot, amountToCall, len(outsInfo.AllOuts), v.Phase)
 			}
 		}
+	}
+
+	output += fmt.Sprintln("-------------------------------------------------")
+	return output
+}
 
+// viewBoards returns the boards of the view: all of them in a game with
+// several boards or a hand run more than once, or else the community cards.
+func viewBoards(v engine.PublicView) [][]poker.Card {
+	if len(v.Boards) > 1 {
+		return v.Boards
+	}
+	return [][]poker.Card{v.CommunityCards}
+}
+
+// boardLabel names the boards of the view: "Board" in a game with several
+// boards, and "Run" for a hand run more than once.
+func boardLabel(v engine.PublicView) string {
+	if v.Rules.BoardCount() > 1 {
+		return "Board"
+	}
+	return "Run"
+}
+
+// formatHandType returns the header tag of a straddled hand or a bomb pot, e.g.
+// " (BOMB POT)", or an empty string for regular hands.
+func formatHandType(t engine.HandType) string {
+	if t == engine.HandRegular {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.ToUpper(t.String()))
+}
+
+// formatAnte formats the ante with the per-player or the big blind ante
+// format, or returns an empty string if there is no ante.
+func formatAnte(ante int, bigBlindAnte bool, perPlayerFormat, bigBlindFormat string) string {
+	switch {
+	case ante == 0:
+		return ""
+	case bigBlindAnte:
+		return fmt.Sprintf(bigBlindFormat, FormatNumber(ante))
+	}
+	return fmt.Sprintf(perPlayerFormat, FormatNumber(ante))
+}
+
+// formatLevel returns the tournament level part of the header, e.g.
+// " | LEVEL 3 (12:34 left)", or an empty string without a structure.
+func formatLevel(v engine.PublicView) string {
+	if v.Level == 0 {
+		return ""
+	}
+	output := fmt.Sprintf(" | LEVEL %d", v.Level)
+	if v.LevelHandsLeft == 1 {
+		output += " (1 more hand)"
+	} else if v.LevelHandsLeft > 1 {
+		output += fmt.Sprintf(" (%d more hands)", v.LevelHandsLeft)
+	} else if left := time.Duration(v.LevelTimeLeftMs) * time.Millisecond; left > 0 {
+		output += fmt.Sprintf(" (%s left)", FormatClock(left))
+	}
+	return output
+}
+
+// checkTotalChips logs a warning if the chips on the table, in the pot and
+// taken by the house no longer add up to the total the game started with.
+func checkTotalChips(g *engine.Game) {
+	totalChips := g.Pot + g.HouseChips
+	for _, p := range g.Players {
 		// Calculate total chips for the game
 		if p.Status != engine.PlayerStatusEliminated {
 			totalChips += p.Chips
@@ -127,9 +248,6 @@ func DisplayGameState(g *engine.Game) {
 			FormatNumber(g.TotalInitialChips),
 		)
 	}
-
-	output += fmt.Sprintln("-------------------------------------------------")
-	fmt.Print(output)
 }
 
 // formatOuts formats the outs cards for display.
@@ -184,12 +302,28 @@ func clearScreen() {
 	fmt.Print("\033[H\033[2J")
 }
 
-func FormatShowdownResults(g *engine.Game) []string {
+// FormatShowdownResults formats the shown-down hands and the pot distribution of
+// a hand. It renders from a public view taken at showdown, in which the hands of
+// all players still in the pot are revealed.
+func FormatShowdownResults(v engine.PublicView, distributionResults []engine.DistributionResult) []string {
 	var outputLines []string
 	outputLines = append(outputLines, "\n--- SHOWDOWN ---")
-	outputLines = append(outputLines, fmt.Sprintf("Community Cards: %s", g.CommunityCards))
+	// A hand with several boards, or run more than once, shows every board.
+	// The winners of each board are named in the pot distribution.
+	if len(v.Boards) > 1 {
+		for b, board := range v.Boards {
+			outputLines = append(outputLines, fmt.Sprintf("%s %d: %s", boardLabel(v), b+1, board))
+			for _, player := range v.Seats {
+				if player.Status == engine.PlayerStatusFolded || player.Status == engine.PlayerStatusEliminated || len(player.Hand) == 0 {
+					continue
+				}
+				outputLines = append(outputLines, fmt.Sprintf("- %-7s: %v -> %s", player.Name, player.Hand, formatShownDownHand(player.Hand, board, &v.Rules)))
+			}
+		}
+		return append(outputLines, formatPotDistribution(distributionResults)...)
+	}
 
-	distributionResults := g.DistributePot()
+	outputLines = append(outputLines, fmt.Sprintf("Community Cards: %s", v.CommunityCards))
 
 	winnerMap := make(map[string][]string)
 	for _, result := range distributionResults {
@@ -204,23 +338,11 @@ func FormatShowdownResults(g *engine.Game) []string {
 		winnerMap[result.PlayerName] = append(winnerMap[result.PlayerName], winType)
 	}
 
-	for _, player := range g.Players {
-		if player.Status == engine.PlayerStatusFolded || player.Status == engine.PlayerStatusEliminated {
+	for _, player := range v.Seats {
+		if player.Status == engine.PlayerStatusFolded || player.Status == engine.PlayerStatusEliminated || len(player.Hand) == 0 {
 			continue
 		}
-		highHand, lowHand := poker.EvaluateHand(player.Hand, g.CommunityCards, g.Rules)
-
-		handDesc := highHand.String()
-		if g.Rules.LowHand.Enabled && lowHand != nil {
-			var lowHandRanks []string
-			for _, c := range lowHand.Cards {
-				lowHandRanks = append(lowHandRanks, c.Rank.String())
-			}
-			if len(lowHandRanks) > 0 && lowHandRanks[0] == "A" {
-				lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
-			}
-			handDesc += fmt.Sprintf(" | Low: %s-High", strings.Join(lowHandRanks, "-"))
-		}
+		handDesc := formatShownDownHand(player.Hand, v.CommunityCards, &v.Rules)
 
 		winnerStatus := ""
 		if statuses, ok := winnerMap[player.Name]; ok {
@@ -230,6 +352,12 @@ func FormatShowdownResults(g *engine.Game) []string {
 		outputLines = append(outputLines, fmt.Sprintf("- %-7s: %v -> %s%s", player.Name, player.Hand, handDesc, winnerStatus))
 	}
 
+	return append(outputLines, formatPotDistribution(distributionResults)...)
+}
+
+// formatPotDistribution formats how the pot of a showdown was distributed.
+func formatPotDistribution(distributionResults []engine.DistributionResult) []string {
+	var outputLines []string
 	outputLines = append(outputLines, "\n--- POT DISTRIBUTION ---")
 	for _, result := range distributionResults {
 		outputLines = append(outputLines, fmt.Sprintf(
@@ -240,3 +368,116 @@ func FormatShowdownResults(g *engine.Game) []string {
 	outputLines = append(outputLines, "------------------------")
 	return outputLines
 }
+
+// formatShownDownHand describes the high hand, and the low hand if the rules
+// have one, that the hole cards make on the board.
+func formatShownDownHand(hand, board []poker.Card, rules *poker.GameRules) string {
+	highHand, lowHand := poker.EvaluateHand(hand, board, rules)
+
+	handDesc := highHand.String()
+	if rules.LowHand.Enabled && lowHand != nil {
+		var lowHandRanks []string
+		for _, c := range lowHand.Cards {
+			lowHandRanks = append(lowHandRanks, c.Rank.String())
+		}
+		if len(lowHandRanks) > 0 && lowHandRanks[0] == "A" {
+			lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
+		}
+		handDesc += fmt.Sprintf(" | Low: %s-High", strings.Join(lowHandRanks, "-"))
+	}
+	return handDesc
+}
+
+// FormatPotAwarded formats the result of a hand in which everyone but one player folded.
+func FormatPotAwarded(results []engine.DistributionResult) []string {
+	outputLines := []string{"--- POT AWARDED ---"}
+	for _, result := range results {
+		outputLines = append(outputLines, fmt.Sprintf(
+			"%s wins %s chips with %s",
+			result.PlayerName, FormatNumber(result.AmountWon), result.HandDesc,
+		))
+	}
+	outputLines = append(outputLines, "------------------------")
+	return outputLines
+}
+
+// FormatHandEnd formats the end of a hand, either as a showdown or as the pot
+// going to the last remaining player, followed by the rake if any was taken.
+func FormatHandEnd(v engine.PublicView, end *engine.HandEndEvent) []string {
+	var lines []string
+	if end.Showdown {
+		lines = FormatShowdownResults(v, end.Results)
+	} else {
+		lines = FormatPotAwarded(end.Results)
+	}
+	if end.Rake > 0 {
+		lines = append(lines, fmt.Sprintf("Rake: %s", FormatNumber(end.Rake)))
+	}
+	return lines
+}
+
+// FormatActionEvent returns a one-line description of a player's action, or an
+// empty string for unknown actions.
+func FormatActionEvent(event *engine.ActionEvent) string {
+	if event.TimedOut {
+		switch event.Action {
+		case engine.ActionFold:
+			return fmt.Sprintf("⏰ %s ran out of time and folds.", event.PlayerName)
+		case engine.ActionCheck:
+			return fmt.Sprintf("⏰ %s ran out of time and checks.", event.PlayerName)
+		}
+	}
+	switch event.Action {
+	case engine.ActionFold:
+		return fmt.Sprintf("%s folds.", event.PlayerName)
+	case engine.ActionCheck:
+		return fmt.Sprintf("%s checks.", event.PlayerName)
+	case engine.ActionCall:
+		return fmt.Sprintf("%s calls %s.", event.PlayerName, FormatNumber(event.Amount))
+	case engine.ActionBet:
+		return fmt.Sprintf("%s bets %s.", event.PlayerName, FormatNumber(event.Amount))
+	case engine.ActionRaise:
+		return fmt.Sprintf("%s raises to %s.", event.PlayerName, FormatNumber(event.Amount))
+	}
+	return ""
+}
+
+// FormatBlindEvent returns the announcement shown when the blinds go up.
+func FormatBlindEvent(event *engine.BlindEvent) string {
+	blinds := fmt.Sprintf("Blinds are now %s/%s%s", FormatNumber(event.SmallBlind), FormatNumber(event.BigBlind),
+		formatAnte(event.Ante, event.BigBlindAnte, ", ante %s", ", big blind ante %s"))
+	if event.Level > 0 {
+		return fmt.Sprintf("\n*** Level %d: %s ***\n", event.Level, blinds)
+	}
+	return fmt.Sprintf("\n*** %s ***\n", blinds)
+}
+
+// FormatStandings returns the lines of the final standings, e.g.
+// "1. CPU 3 - 1,200,000 chips" or "5. YOU - out in hand #42".
+func FormatStandings(standings []engine.Standing) []string {
+	lines := []string{"\n--- Final Standings ---"}
+	for _, s := range standings {
+		if s.EliminatedHand > 0 {
+			lines = append(lines, fmt.Sprintf("%d. %s - out in hand #%d", s.Place, s.Name, s.EliminatedHand))
+		} else {
+			lines = append(lines, fmt.Sprintf("%d. %s - %s chips", s.Place, s.Name, FormatNumber(s.Chips)))
+		}
+	}
+	return lines
+}
+
+// FormatSessionResults returns the lines of a cash game's session results,
+// e.g. "YOU - bought in 300,000, has 412,500 (+112,500)". Players
+// who broke even are shown with "+0".
+func FormatSessionResults(results []engine.SessionResult) []string {
+	lines := []string{"\n--- Session Results ---"}
+	for _, r := range results {
+		profit, sign := r.Profit(), "+"
+		if profit < 0 {
+			profit, sign = -profit, "-"
+		}
+		lines = append(lines, fmt.Sprintf("%s - bought in %s, has %s (%s%s)",
+			r.Name, FormatNumber(r.BoughtIn), FormatNumber(r.Chips), sign, FormatNumber(profit)))
+	}
+	return lines
+}
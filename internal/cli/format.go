@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"fmt"
 	"strconv"
+	"time"
 )
 
 // FormatNumber takes an integer and returns a string with commas as thousands separators.
@@ -25,3 +27,9 @@ func FormatNumber(n int) string {
 
 	return result
 }
+
+// FormatClock formats a duration as minutes and seconds, e.g. "12:34".
+func FormatClock(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -2,67 +2,193 @@ package cli
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"pls7-cli/pkg/engine"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+var (
+	stdinOnce  sync.Once
+	stdinLines chan string // lines typed on stdin; closed at EOF
+)
+
+// ReadLine waits for the next line typed on stdin and returns it without the
+// trailing newline. At EOF it returns an empty string.
+func ReadLine() string {
+	line, _ := readLine(context.Background())
+	return line
+}
+
+// readLine waits for the next line typed on stdin until the context is done.
+// A single goroutine reads stdin, so a prompt that gives up does not swallow
+// the next line. If the context has a deadline, a countdown is shown during the
+// last seconds.
+func readLine(ctx context.Context) (string, error) {
+	stdinOnce.Do(func() {
+		stdinLines = make(chan string)
+		go func() {
+			defer close(stdinLines)
+			reader := bufio.NewReader(os.Stdin)
+			for {
+				line, err := reader.ReadString('\n')
+				if line != "" {
+					stdinLines <- strings.TrimRight(line, "\r\n")
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	})
+
+	var tick <-chan time.Time
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case line, ok := <-stdinLines:
+			if !ok {
+				if ctx.Done() != nil {
+					// Nothing more will be typed, so wait for the context instead of
+					// returning empty lines over and over.
+					<-ctx.Done()
+					fmt.Println("\n⏰ Time is up!")
+					return "", ctx.Err()
+				}
+				return "", io.EOF
+			}
+			return line, nil
+		case <-tick:
+			left := int(time.Until(deadline).Round(time.Second).Seconds())
+			if left == 10 || (left > 0 && left <= 5) {
+				fmt.Printf("\n⏰ %d seconds left > ", left)
+			}
+		case <-ctx.Done():
+			fmt.Println("\n⏰ Time is up!")
+			return "", ctx.Err()
+		}
+	}
+}
+
 // PromptForAction requests the player to choose an action during their turn.
-func PromptForAction(g *engine.Game) engine.PlayerAction {
+// It gives up with the context's error once the context is done.
+func PromptForAction(ctx context.Context, g *engine.Game) (engine.PlayerAction, error) {
 	DisplayGameState(g)
+	return PromptForLegalAction(ctx, g.LegalActions(g.CurrentPlayer()))
+}
 
+// PromptForActionFor requests an action from the given player, showing the table
+// from that player's perspective only. It is used in hot-seat games.
+func PromptForActionFor(ctx context.Context, g *engine.Game, viewer *engine.Player) (engine.PlayerAction, error) {
+	DisplayGameStateFor(g, viewer)
+	return PromptForLegalAction(ctx, g.LegalActions(g.CurrentPlayer()))
+}
+
+// PassKeyboard clears the screen and waits until the named player confirms they
+// have the keyboard, so the previous player's hole cards are no longer visible.
+// The player's clock keeps running, so it gives up once the context is done.
+func PassKeyboard(ctx context.Context, g *engine.Game, name string) error {
+	if !g.DevMode {
+		clearScreen()
+	}
+	fmt.Printf("\n\n=== Pass the keyboard to %s ===\n", name)
+	fmt.Printf("%s, press ENTER when you are ready to see your cards > ", name)
+	_, err := readLine(ctx)
+	return err
+}
+
+// TakeBreak announces a tournament break and waits until it is over, or until
+// someone presses ENTER to resume early.
+func TakeBreak(left time.Duration) {
+	fmt.Printf("\n☕ Break! The next level starts in %s. Press ENTER to resume now > ", FormatClock(left))
+	ctx, cancel := context.WithTimeout(context.Background(), left)
+	defer cancel()
+	_, _ = readLine(ctx)
+}
+
+// PromptRunouts asks the named player whether they agree to deal the rest of
+// the board the given number of times, now that no more bets can be made.
+// Anything but "y" declines.
+func PromptRunouts(name string, times int) bool {
+	fmt.Printf("\n%s, the players are all-in. Run it %d times? (y/N) > ", name, times)
+	answer := strings.TrimSpace(strings.ToLower(ReadLine()))
+	return answer == "y" || answer == "yes"
+}
+
+// PromptForLegalAction keeps prompting until the player chooses one of the given
+// legal actions, or the context is done. It only depends on the legal actions,
+// so it can also be used by clients that receive a PlayerView instead of the
+// full game.
+func PromptForLegalAction(ctx context.Context, legal engine.LegalActions) (engine.PlayerAction, error) {
 	// for loop to keep prompting until a valid action is chosen
 	for {
-		player := g.Players[g.CurrentTurnPos]
-		canCheck := player.CurrentBet == g.BetToCall
-		amountToCall := g.BetToCall - player.CurrentBet
+		canCheck := legal.CanCheck
+		amountToCall := legal.CallAmount
 
 		var prompt strings.Builder
-		prompt.WriteString("Choose your action: ")
+		prompt.WriteString("Choose your action")
+		if deadline, ok := ctx.Deadline(); ok {
+			prompt.WriteString(fmt.Sprintf(" [%ds left]", int(time.Until(deadline).Seconds())))
+		}
+		prompt.WriteString(": ")
 
 		if canCheck {
-			prompt.WriteString("chec(k), (b)et, (f)old > ")
+			prompt.WriteString("chec(k), ")
+			if legal.CanBet {
+				prompt.WriteString("(b)et, ")
+			}
+			prompt.WriteString("(f)old > ")
 		} else {
 			// If amountToCall is negative, it means remaining players have bet all-in with less than the current bet.
 			// So the player does not need to act anything, call.
 			if amountToCall < 0 {
-				return engine.PlayerAction{Type: engine.ActionCall}
+				return engine.PlayerAction{Type: engine.ActionCall}, nil
 			}
 
 			prompt.WriteString(fmt.Sprintf("(c)all %s, ", FormatNumber(amountToCall)))
 			// Only show raise option if the player has enough chips to make a valid raise.
-			minRaise, _ := g.CalculateBettingLimits()
-			if player.Chips > amountToCall && player.CurrentBet+player.Chips >= minRaise {
+			if legal.CanRaise {
 				prompt.WriteString("(r)aise, ")
 			}
 			prompt.WriteString("(f)old > ")
 		}
 
 		fmt.Print(prompt.String())
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
+		input, err := readLine(ctx)
+		if err != nil && ctx.Err() != nil {
+			return engine.PlayerAction{}, err
+		}
 		input = strings.TrimSpace(input)
 
 		switch input {
 		case "f":
-			return engine.PlayerAction{Type: engine.ActionFold}
+			return engine.PlayerAction{Type: engine.ActionFold}, nil
 		case "k":
 			if canCheck {
-				return engine.PlayerAction{Type: engine.ActionCheck}
+				return engine.PlayerAction{Type: engine.ActionCheck}, nil
 			}
 		case "c":
 			if !canCheck {
-				return engine.PlayerAction{Type: engine.ActionCall}
+				return engine.PlayerAction{Type: engine.ActionCall}, nil
 			}
 		case "b":
-			if canCheck {
-				return promptForAmount(g, engine.ActionBet)
+			if legal.CanBet {
+				return promptForAmount(ctx, legal, engine.ActionBet)
 			}
 		case "r":
-			if !canCheck {
-				return promptForAmount(g, engine.ActionRaise)
+			if legal.CanRaise {
+				return promptForAmount(ctx, legal, engine.ActionRaise)
 			}
 		}
 
@@ -71,9 +197,9 @@ func PromptForAction(g *engine.Game) engine.PlayerAction {
 }
 
 // promptForAmount requests the betting/raising amount.
-func promptForAmount(g *engine.Game, actionType engine.ActionType) engine.PlayerAction {
+func promptForAmount(ctx context.Context, legal engine.LegalActions, actionType engine.ActionType) (engine.PlayerAction, error) {
 	for {
-		minBet, maxBet := g.CalculateBettingLimits()
+		minBet, maxBet := legal.MinAmount, legal.MaxAmount
 		actionName := "bet"
 		if actionType == engine.ActionRaise {
 			actionName = "raise to"
@@ -84,14 +210,16 @@ func promptForAmount(g *engine.Game, actionType engine.ActionType) engine.Player
 			actionName, FormatNumber(minBet), FormatNumber(maxBet),
 		)
 
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
+		input, err := readLine(ctx)
+		if err != nil && ctx.Err() != nil {
+			return engine.PlayerAction{}, err
+		}
 		amount, err := strconv.Atoi(strings.TrimSpace(input))
 
 		if err != nil || amount < minBet || amount > maxBet {
 			fmt.Println("Invalid amount. Please try again.")
 		} else {
-			return engine.PlayerAction{Type: actionType, Amount: amount}
+			return engine.PlayerAction{Type: actionType, Amount: amount}, nil
 		}
 	}
 }
@@ -15,13 +15,7 @@ func LoadGameRulesFromFile(filePath string) (*poker.GameRules, error) {
 		return nil, err
 	}
 
-	var rules poker.GameRules
-	err = yaml.Unmarshal(data, &rules)
-	if err != nil {
-		return nil, err
-	}
-
-	return &rules, nil
+	return LoadGameRulesFromBytes(data)
 }
 
 // LoadGameRulesFromBytes unmarshals a byte slice into a GameRules struct.
@@ -31,6 +25,9 @@ func LoadGameRulesFromBytes(data []byte) (*poker.GameRules, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := rules.ValidateBoards(); err != nil {
+		return nil, err
+	}
 	return &rules, nil
 }
 
@@ -91,3 +91,26 @@ low_hand:
 		t.Errorf("Expected low_hand.max_rank to be 7, but got %d", rules.LowHand.MaxRank)
 	}
 }
+
+// TestLoadGameRulesFromBytes_Boards tests that the number of boards is parsed and checked.
+func TestLoadGameRulesFromBytes_Boards(t *testing.T) {
+	rules, err := LoadGameRulesFromBytes([]byte("name: \"Double-Board PLO\"\nboards: 2\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if rules.BoardCount() != 2 {
+		t.Errorf("Expected 2 boards, but got %d", rules.BoardCount())
+	}
+
+	rules, err = LoadGameRulesFromBytes([]byte("name: \"PLO\"\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if rules.BoardCount() != 1 {
+		t.Errorf("Expected a single board by default, but got %d", rules.BoardCount())
+	}
+
+	if _, err := LoadGameRulesFromBytes([]byte("boards: 4\n")); err == nil {
+		t.Error("Expected an error for too many boards, but got nil")
+	}
+}
@@ -0,0 +1,69 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"pls7-cli/pkg/engine"
+)
+
+// Client is a connection to a Server, bound to one seat at the table.
+type Client struct {
+	conn *conn
+	// Seat is the seat assigned by the server.
+	Seat int
+	// Name is the name assigned by the server.
+	Name string
+}
+
+// Dial connects to the server at addr and joins the table under the given name.
+// It returns once the server has assigned a seat.
+func Dial(addr, name string) (*Client, error) {
+	netConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	c := newConn(netConn)
+
+	if err := c.send(MsgJoin, JoinData{Name: name}); err != nil {
+		_ = c.close()
+		return nil, fmt.Errorf("failed to join the table: %w", err)
+	}
+	msg, err := c.receive()
+	if err != nil {
+		_ = c.close()
+		return nil, fmt.Errorf("failed to join the table: %w", err)
+	}
+
+	switch msg.Type {
+	case MsgWelcome:
+		var welcome WelcomeData
+		if err := msg.Decode(&welcome); err != nil {
+			_ = c.close()
+			return nil, err
+		}
+		return &Client{conn: c, Seat: welcome.Seat, Name: welcome.Name}, nil
+	case MsgError:
+		var text TextData
+		_ = msg.Decode(&text)
+		_ = c.close()
+		return nil, fmt.Errorf("server refused to join: %s", text.Text)
+	default:
+		_ = c.close()
+		return nil, fmt.Errorf("unexpected message from server: %s", msg.Type)
+	}
+}
+
+// Receive blocks until the next message from the server arrives.
+func (c *Client) Receive() (Message, error) {
+	return c.conn.receive()
+}
+
+// SendAction answers an action request.
+func (c *Client) SendAction(action engine.PlayerAction) error {
+	return c.conn.send(MsgPlayerAction, action)
+}
+
+// Close disconnects from the server.
+func (c *Client) Close() error {
+	return c.conn.close()
+}
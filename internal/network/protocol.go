@@ -0,0 +1,128 @@
+// Package network implements TCP multiplayer for the poker engine: a server that
+// hosts a table and binds remote clients to seats, and a client that joins it.
+//
+// Server and clients exchange newline-delimited JSON messages of the form
+// {"type": "...", "data": ...}.
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"pls7-cli/pkg/engine"
+	"sync"
+)
+
+// Message types sent from the server to the clients.
+const (
+	// MsgWelcome confirms a join and carries the client's seat (WelcomeData).
+	MsgWelcome = "welcome"
+	// MsgGameState carries the table as seen from the client's seat (engine.PlayerView).
+	MsgGameState = "gameState"
+	// MsgActionRequest asks the client to act; the view includes the legal actions (engine.PlayerView).
+	MsgActionRequest = "actionRequest"
+	// MsgAction announces an action taken by a player (engine.ActionEvent).
+	MsgAction = "action"
+	// MsgBlinds announces that the blinds went up (engine.BlindEvent).
+	MsgBlinds = "blinds"
+	// MsgHandResult carries the outcome of a hand (HandResultData).
+	MsgHandResult = "handResult"
+	// MsgInfo carries an informational text line (TextData).
+	MsgInfo = "info"
+	// MsgError reports a rejected join or action (TextData).
+	MsgError = "error"
+	// MsgStandings carries the players' places at the end of the game ([]engine.Standing).
+	MsgStandings = "standings"
+	// MsgGameOver announces the end of the game (TextData).
+	MsgGameOver = "gameOver"
+)
+
+// Message types sent from the clients to the server.
+const (
+	// MsgJoin asks the server for a seat (JoinData).
+	MsgJoin = "join"
+	// MsgPlayerAction answers an action request (engine.PlayerAction).
+	MsgPlayerAction = "playerAction"
+)
+
+// Message is the envelope of every message exchanged between server and client.
+type Message struct {
+	// Type identifies the kind of message and determines the type of Data.
+	Type string `json:"type"`
+	// Data holds the JSON-encoded payload of the message.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Decode unmarshals the message payload into v.
+func (m Message) Decode(v interface{}) error {
+	if err := json.Unmarshal(m.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s message: %w", m.Type, err)
+	}
+	return nil
+}
+
+// JoinData is the payload of a MsgJoin message.
+type JoinData struct {
+	// Name is the name the player wants to use at the table.
+	Name string `json:"name"`
+}
+
+// WelcomeData is the payload of a MsgWelcome message.
+type WelcomeData struct {
+	// Seat is the seat assigned to the client.
+	Seat int `json:"seat"`
+	// Name is the name assigned to the client, which may differ from the requested one.
+	Name string `json:"name"`
+}
+
+// HandResultData is the payload of a MsgHandResult message.
+type HandResultData struct {
+	// View is the public view of the table at the end of the hand, in which the
+	// shown-down hands are revealed.
+	View engine.PublicView `json:"view"`
+	// End describes how the pot was awarded.
+	End engine.HandEndEvent `json:"end"`
+}
+
+// TextData is the payload of MsgInfo, MsgError and MsgGameOver messages.
+type TextData struct {
+	// Text is the human-readable message.
+	Text string `json:"text"`
+}
+
+// conn wraps a network connection with JSON message encoding. Sends are safe
+// for concurrent use; receives must happen from a single goroutine.
+type conn struct {
+	netConn net.Conn
+	enc     *json.Encoder
+	dec     *json.Decoder
+	mu      sync.Mutex
+}
+
+// newConn wraps the given network connection.
+func newConn(c net.Conn) *conn {
+	return &conn{netConn: c, enc: json.NewEncoder(c), dec: json.NewDecoder(c)}
+}
+
+// send encodes the payload and writes it as a single message.
+func (c *conn) send(msgType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s message: %w", msgType, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(Message{Type: msgType, Data: payload})
+}
+
+// receive reads the next message.
+func (c *conn) receive() (Message, error) {
+	var msg Message
+	err := c.dec.Decode(&msg)
+	return msg, err
+}
+
+// close closes the underlying connection.
+func (c *conn) close() error {
+	return c.netConn.Close()
+}
@@ -0,0 +1,406 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Config describes the table hosted by a Server.
+type Config struct {
+	// Rules are the rules of the poker variant to play.
+	Rules *poker.GameRules
+	// Humans is the number of remote players to wait for before the first hand is dealt.
+	Humans int
+	// Players is the total number of seats. Seats not taken by remote players are CPUs.
+	Players int
+	// InitialChips is the starting stack of every player.
+	InitialChips int
+	// SmallBlind is the initial small blind.
+	SmallBlind int
+	// BigBlind is the initial big blind.
+	BigBlind int
+	// Ante is the initial ante. 0 means no ante.
+	Ante int
+	// BigBlindAnte makes the big blind post the ante for the whole table.
+	BigBlindAnte bool
+	// ButtonPolicy decides how the button and the blinds move on when players
+	// are eliminated.
+	ButtonPolicy engine.ButtonPolicy
+	// BlindUpInterval is the number of hands after which the blinds double. 0 disables this.
+	BlindUpInterval int
+	// Structure is the tournament structure the blinds follow. It replaces the
+	// blinds, the ante and BlindUpInterval if set.
+	Structure *engine.Structure
+	// Difficulty determines the AI profiles of the CPU players.
+	Difficulty engine.Difficulty
+	// HandDelay is the pause between two hands, giving players time to read the results.
+	HandDelay time.Duration
+	// MaxHands stops the game after this many hands. 0 plays until the game is over.
+	MaxHands int
+	// TimeControl limits the time players have to decide. Players who run out
+	// of time check or fold.
+	TimeControl engine.TimeControl
+}
+
+// remoteSeat is a seat bound to a connected client.
+type remoteSeat struct {
+	seat    int
+	name    string
+	conn    *conn
+	actions chan engine.PlayerAction
+	done    chan struct{}
+
+	mu       sync.Mutex
+	awaiting bool // true while the server waits for this seat's action
+}
+
+// await marks the seat as waiting for an action.
+func (rs *remoteSeat) await() {
+	rs.mu.Lock()
+	rs.awaiting = true
+	rs.mu.Unlock()
+}
+
+// cancel stops waiting for this seat's action and discards an action that
+// arrived too late to be used.
+func (rs *remoteSeat) cancel() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.awaiting = false
+	select {
+	case <-rs.actions:
+	default:
+	}
+}
+
+// deliver hands an action received from the client to the waiting game loop.
+// It returns false if the server is not waiting for this seat's action.
+func (rs *remoteSeat) deliver(action engine.PlayerAction) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if !rs.awaiting {
+		return false
+	}
+	rs.awaiting = false
+	rs.actions <- action // Buffered, and only one action is accepted per request.
+	return true
+}
+
+// Server hosts a single table. Remote clients are bound to seats and act through
+// the server, which implements engine.ActionProvider for them; the remaining
+// seats are played by the CPU.
+type Server struct {
+	cfg Config
+
+	mu      sync.Mutex
+	seats   map[int]*remoteSeat
+	joined  chan struct{}
+	started bool
+
+	game *engine.Game
+}
+
+// NewServer creates a server for a table with the given configuration.
+func NewServer(cfg Config) (*Server, error) {
+	if cfg.Rules == nil {
+		return nil, fmt.Errorf("rules are required")
+	}
+	if cfg.Players < 2 || cfg.Players > 6 {
+		return nil, fmt.Errorf("players must be between 2 and 6, got %d", cfg.Players)
+	}
+	if cfg.Humans < 1 || cfg.Humans > cfg.Players {
+		return nil, fmt.Errorf("humans must be between 1 and %d, got %d", cfg.Players, cfg.Humans)
+	}
+	return &Server{
+		cfg:    cfg,
+		seats:  make(map[int]*remoteSeat),
+		joined: make(chan struct{}, cfg.Players),
+	}, nil
+}
+
+// Game returns the game hosted by the server, or nil before the first hand.
+// It must not be used concurrently with Serve.
+func (s *Server) Game() *engine.Game {
+	return s.game
+}
+
+// Serve accepts clients on the listener until all remote seats are taken, then
+// plays the game until it is over. It closes the listener and all client
+// connections before returning.
+func (s *Server) Serve(l net.Listener) error {
+	defer l.Close()
+	go s.acceptLoop(l)
+
+	logrus.Infof("Waiting for %d player(s) on %s", s.cfg.Humans, l.Addr())
+	for i := 0; i < s.cfg.Humans; i++ {
+		<-s.joined
+	}
+
+	s.mu.Lock()
+	s.started = true
+	s.game = s.newGame()
+	s.mu.Unlock()
+
+	err := s.play()
+	s.closeAll()
+	return err
+}
+
+// acceptLoop accepts connections until the listener is closed.
+func (s *Server) acceptLoop(l net.Listener) {
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logrus.Warnf("Failed to accept connection: %v", err)
+			}
+			return
+		}
+		go s.handleConn(newConn(c))
+	}
+}
+
+// handleConn performs the join handshake and then forwards the client's actions
+// to its seat until the connection drops.
+func (s *Server) handleConn(c *conn) {
+	msg, err := c.receive()
+	if err != nil || msg.Type != MsgJoin {
+		_ = c.send(MsgError, TextData{Text: "expected a join message"})
+		_ = c.close()
+		return
+	}
+	var join JoinData
+	if err := msg.Decode(&join); err != nil {
+		_ = c.send(MsgError, TextData{Text: err.Error()})
+		_ = c.close()
+		return
+	}
+
+	seat, err := s.bindSeat(c, join.Name)
+	if err != nil {
+		_ = c.send(MsgError, TextData{Text: err.Error()})
+		_ = c.close()
+		return
+	}
+	logrus.Infof("%s joined the table at seat %d", seat.name, seat.seat)
+	_ = c.send(MsgWelcome, WelcomeData{Seat: seat.seat, Name: seat.name})
+	s.joined <- struct{}{}
+
+	defer close(seat.done)
+	for {
+		msg, err := c.receive()
+		if err != nil {
+			logrus.Infof("%s disconnected: %v", seat.name, err)
+			return
+		}
+		if msg.Type != MsgPlayerAction {
+			_ = c.send(MsgError, TextData{Text: fmt.Sprintf("unexpected message type: %s", msg.Type)})
+			continue
+		}
+		var action engine.PlayerAction
+		if err := msg.Decode(&action); err != nil {
+			_ = c.send(MsgError, TextData{Text: err.Error()})
+			continue
+		}
+		if !seat.deliver(action) {
+			_ = c.send(MsgError, TextData{Text: "it is not your turn"})
+		}
+	}
+}
+
+// bindSeat assigns the next free remote seat to the client.
+func (s *Server) bindSeat(c *conn, name string) (*remoteSeat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started || len(s.seats) >= s.cfg.Humans {
+		return nil, fmt.Errorf("the table is full")
+	}
+
+	seatNum := len(s.seats)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("Player %d", seatNum+1)
+	}
+	for _, other := range s.seats {
+		if other.name == name {
+			name = fmt.Sprintf("%s (%d)", name, seatNum+1)
+			break
+		}
+	}
+
+	seat := &remoteSeat{
+		seat:    seatNum,
+		name:    name,
+		conn:    c,
+		actions: make(chan engine.PlayerAction, 1),
+		done:    make(chan struct{}),
+	}
+	s.seats[seatNum] = seat
+	return seat, nil
+}
+
+// newGame creates the game, seating the remote players first and filling the
+// remaining seats with CPUs.
+func (s *Server) newGame() *engine.Game {
+	playerNames := make([]string, s.cfg.Players)
+	playerNames[0] = "YOU"
+	for i := 1; i < s.cfg.Players; i++ {
+		playerNames[i] = fmt.Sprintf("CPU %d", i)
+	}
+
+	g := engine.NewGame(
+		playerNames, s.cfg.InitialChips, s.cfg.SmallBlind, s.cfg.BigBlind,
+		s.cfg.Difficulty, s.cfg.Rules, false, false, s.cfg.BlindUpInterval,
+	)
+	g.Ante, g.BigBlindAnte = s.cfg.Ante, s.cfg.BigBlindAnte
+	g.ButtonPolicy = s.cfg.ButtonPolicy
+	for seatNum, seat := range s.seats {
+		_ = g.SetHuman(seatNum, seat.name)
+	}
+	if s.cfg.Structure != nil {
+		g.SetStructure(s.cfg.Structure, time.Now())
+	}
+	g.SetTimeControl(s.cfg.TimeControl)
+	return g
+}
+
+// play runs hands until the game is over.
+func (s *Server) play() error {
+	g := s.game
+	for {
+		if left := g.BreakLeft(time.Now()); left > 0 {
+			s.broadcast(MsgInfo, TextData{Text: fmt.Sprintf("☕ Break! The next level starts in %s.", left.Round(time.Second))})
+			time.Sleep(left)
+		}
+		g.PlayHand(s, s)
+
+		for _, msg := range g.CleanupHand() {
+			s.broadcast(MsgInfo, TextData{Text: msg})
+		}
+
+		if s.cfg.MaxHands > 0 && g.HandCount >= s.cfg.MaxHands {
+			s.broadcast(MsgStandings, g.Standings())
+			s.broadcast(MsgGameOver, TextData{Text: fmt.Sprintf("Played %d hands. GAME OVER.", g.HandCount)})
+			return nil
+		}
+		if g.CountRemainingPlayers() <= 1 {
+			s.broadcast(MsgStandings, g.Standings())
+			s.broadcast(MsgGameOver, TextData{Text: "--- GAME OVER ---"})
+			return nil
+		}
+		if !s.hasActiveRemotePlayers() {
+			s.broadcast(MsgStandings, g.Standings())
+			s.broadcast(MsgGameOver, TextData{Text: "No remote players left. GAME OVER."})
+			return nil
+		}
+		time.Sleep(s.cfg.HandDelay)
+	}
+}
+
+// hasActiveRemotePlayers reports whether any connected remote player is still in the game.
+func (s *Server) hasActiveRemotePlayers() bool {
+	for seatNum, seat := range s.seats {
+		if s.game.Players[seatNum].Status == engine.PlayerStatusEliminated {
+			continue
+		}
+		select {
+		case <-seat.done:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// GetAction implements engine.ActionProvider. Remote seats are asked over the
+// network, CPU seats use the engine's AI, and disconnected players check or fold.
+func (s *Server) GetAction(g *engine.Game, p *engine.Player, r *rand.Rand) engine.PlayerAction {
+	action, _ := s.GetActionContext(context.Background(), g, p, r)
+	return action
+}
+
+// GetActionContext implements engine.ContextActionProvider, giving up on a
+// remote player once the context is done.
+func (s *Server) GetActionContext(ctx context.Context, g *engine.Game, p *engine.Player, r *rand.Rand) (engine.PlayerAction, error) {
+	if seat, ok := s.seats[p.Position]; ok {
+		return s.requestAction(ctx, g, p, seat)
+	}
+	return g.GetCPUAction(p, r), nil
+}
+
+// requestAction asks a remote player for an action until a legal one is received.
+func (s *Server) requestAction(ctx context.Context, g *engine.Game, p *engine.Player, seat *remoteSeat) (engine.PlayerAction, error) {
+	for {
+		seat.await()
+		if err := seat.conn.send(MsgActionRequest, g.ViewFor(seat.seat)); err != nil {
+			seat.cancel()
+			return g.DefaultAction(p), nil
+		}
+
+		select {
+		case action := <-seat.actions:
+			if err := g.ValidateAction(p, action); err != nil {
+				logrus.Debugf("Rejected action %+v from %s: %v", action, seat.name, err)
+				_ = seat.conn.send(MsgError, TextData{Text: err.Error()})
+				continue
+			}
+			return action, nil
+		case <-seat.done:
+			return g.DefaultAction(p), nil
+		case <-ctx.Done():
+			seat.cancel()
+			_ = seat.conn.send(MsgInfo, TextData{Text: "Time is up!"})
+			return engine.PlayerAction{}, ctx.Err()
+		}
+	}
+}
+
+// OnEvent implements engine.HandObserver by broadcasting the events of the hand
+// and a per-seat view of the table to the remote players.
+func (s *Server) OnEvent(g *engine.Game, event engine.Event) {
+	switch e := event.(type) {
+	case *engine.HandStartEvent:
+		if e.Blind != nil {
+			s.broadcast(MsgBlinds, e.Blind)
+		}
+		s.broadcastState(g)
+	case *engine.ActionEvent:
+		s.broadcast(MsgAction, e)
+	case *engine.HandEndEvent:
+		s.broadcast(MsgHandResult, HandResultData{View: g.PublicView(), End: *e})
+	}
+}
+
+// broadcastState sends every remote player the table as seen from their seat.
+func (s *Server) broadcastState(g *engine.Game) {
+	for seatNum, seat := range s.seats {
+		_ = seat.conn.send(MsgGameState, g.ViewFor(seatNum))
+	}
+}
+
+// broadcast sends the same message to every remote player.
+func (s *Server) broadcast(msgType string, data interface{}) {
+	for _, seat := range s.seats {
+		_ = seat.conn.send(msgType, data)
+	}
+}
+
+// closeAll closes every client connection.
+func (s *Server) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, seat := range s.seats {
+		_ = seat.conn.close()
+	}
+}
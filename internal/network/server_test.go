@@ -0,0 +1,265 @@
+package network
+
+import (
+	"net"
+	"pls7-cli/internal/config"
+	"pls7-cli/pkg/engine"
+	"sync"
+	"testing"
+	"time"
+)
+
+// startTestServer starts a server on a random localhost port and returns its
+// address and a channel that receives the result of Serve.
+func startTestServer(t *testing.T, cfg Config) (*Server, string, <-chan error) {
+	t.Helper()
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls7.yml")
+	if err != nil {
+		t.Fatalf("Failed to load game rules: %v", err)
+	}
+	cfg.Rules = rules
+	if cfg.InitialChips == 0 {
+		cfg.InitialChips, cfg.SmallBlind, cfg.BigBlind = 10000, 50, 100
+	}
+
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(l) }()
+	return s, l.Addr().String(), done
+}
+
+// clientStats records what a scripted client has seen.
+type clientStats struct {
+	states, requests, results int
+	gameOver                  bool
+	errors                    []string
+}
+
+// playPassively joins the table and checks or calls whenever asked to act,
+// verifying that the views never reveal other players' hole cards before showdown.
+func playPassively(t *testing.T, addr, name string) *clientStats {
+	t.Helper()
+	client, err := Dial(addr, name)
+	if err != nil {
+		t.Errorf("%s failed to join: %v", name, err)
+		return nil
+	}
+	defer client.Close()
+
+	stats := &clientStats{}
+	checkView := func(v *engine.PlayerView) {
+		if v.Seat != client.Seat || len(v.Hand) == 0 {
+			t.Errorf("%s received a view for seat %d without hole cards", name, v.Seat)
+		}
+		for _, seat := range v.Seats {
+			if seat.Seat != client.Seat && len(seat.Hand) > 0 {
+				t.Errorf("%s can see %s's hole cards before showdown", name, seat.Name)
+			}
+		}
+	}
+
+	for {
+		msg, err := client.Receive()
+		if err != nil {
+			return stats
+		}
+		switch msg.Type {
+		case MsgGameState:
+			var v engine.PlayerView
+			if err := msg.Decode(&v); err != nil {
+				t.Error(err)
+			}
+			checkView(&v)
+			stats.states++
+		case MsgActionRequest:
+			var v engine.PlayerView
+			if err := msg.Decode(&v); err != nil {
+				t.Error(err)
+			}
+			checkView(&v)
+			stats.requests++
+			if v.LegalActions == nil {
+				t.Errorf("%s was asked to act without legal actions", name)
+				continue
+			}
+			action := engine.PlayerAction{Type: engine.ActionCall}
+			if v.LegalActions.CanCheck {
+				action.Type = engine.ActionCheck
+			}
+			if err := client.SendAction(action); err != nil {
+				t.Error(err)
+			}
+		case MsgHandResult:
+			stats.results++
+		case MsgError:
+			var text TextData
+			_ = msg.Decode(&text)
+			stats.errors = append(stats.errors, text.Text)
+		case MsgGameOver:
+			stats.gameOver = true
+			return stats
+		}
+	}
+}
+
+func TestServer_PlaysHandsWithRemoteAndCPUPlayers(t *testing.T) {
+	s, addr, done := startTestServer(t, Config{Humans: 2, Players: 3, MaxHands: 2})
+
+	var wg sync.WaitGroup
+	stats := make([]*clientStats, 2)
+	for i, name := range []string{"Alice", "Bob"} {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			stats[i] = playPassively(t, addr, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Serve returned an error: %v", err)
+	}
+
+	for i, st := range stats {
+		if st == nil {
+			continue
+		}
+		if !st.gameOver || st.results != 2 || st.states != 2 {
+			t.Errorf("Client %d: expected 2 hand states, 2 results and game over, got %+v", i, st)
+		}
+		if len(st.errors) > 0 {
+			t.Errorf("Client %d: unexpected errors %v", i, st.errors)
+		}
+	}
+
+	g := s.Game()
+	if g.Players[0].IsCPU || g.Players[1].IsCPU || !g.Players[2].IsCPU {
+		t.Errorf("Expected seats 0 and 1 to be remote humans and seat 2 a CPU, got %v", g.Players)
+	}
+	total := g.Pot
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	if total != g.TotalInitialChips {
+		t.Errorf("Chips are not conserved: expected %d, got %d", g.TotalInitialChips, total)
+	}
+}
+
+func TestServer_RejectsIllegalActions(t *testing.T) {
+	_, addr, done := startTestServer(t, Config{Humans: 2, Players: 2, MaxHands: 1})
+
+	go playPassively(t, addr, "Bob")
+	client, err := Dial(addr, "Alice")
+	if err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	defer client.Close()
+
+	requests, rejected := 0, false
+	for {
+		msg, err := client.Receive()
+		if err != nil {
+			t.Fatalf("Connection closed before game over: %v", err)
+		}
+		if msg.Type == MsgGameOver {
+			break
+		}
+		switch msg.Type {
+		case MsgActionRequest:
+			requests++
+			if requests == 1 {
+				// A raise far below the minimum must be rejected.
+				_ = client.SendAction(engine.PlayerAction{Type: engine.ActionRaise, Amount: 1})
+			} else {
+				_ = client.SendAction(engine.PlayerAction{Type: engine.ActionFold})
+			}
+		case MsgError:
+			rejected = true
+		}
+	}
+	<-done
+
+	if !rejected {
+		t.Error("Expected the illegal raise to be rejected with an error message")
+	}
+	if requests < 2 {
+		t.Errorf("Expected the action to be requested again after the rejection, got %d requests", requests)
+	}
+}
+
+func TestServer_RefusesClientsWhenTableIsFull(t *testing.T) {
+	_, addr, done := startTestServer(t, Config{Humans: 1, Players: 2, MaxHands: 1})
+
+	first, err := Dial(addr, "Alice")
+	if err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	if _, err := Dial(addr, "Bob"); err == nil {
+		t.Error("Expected the second client to be refused")
+	}
+
+	// Fold out of the game so the server can finish.
+	for {
+		msg, err := first.Receive()
+		if err != nil || msg.Type == MsgGameOver {
+			break
+		}
+		if msg.Type == MsgActionRequest {
+			_ = first.SendAction(engine.PlayerAction{Type: engine.ActionFold})
+		}
+	}
+	first.Close()
+	<-done
+}
+
+func TestServer_TimesOutUnresponsivePlayers(t *testing.T) {
+	_, addr, done := startTestServer(t, Config{
+		Humans: 2, Players: 2, MaxHands: 1,
+		TimeControl: engine.TimeControl{DecisionTime: 100 * time.Millisecond},
+	})
+
+	go playPassively(t, addr, "Bob")
+	client, err := Dial(addr, "Alice")
+	if err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	defer client.Close()
+
+	// Never answer; the server must act on Alice's behalf and finish the hand.
+	requests, timedOut := 0, false
+	for {
+		msg, err := client.Receive()
+		if err != nil {
+			t.Fatalf("Connection closed before game over: %v", err)
+		}
+		if msg.Type == MsgGameOver {
+			break
+		}
+		switch msg.Type {
+		case MsgActionRequest:
+			requests++
+			var v engine.PlayerView
+			if err := msg.Decode(&v); err != nil || v.TimeLeftMs <= 0 || v.TimeLeftMs > 100 {
+				t.Errorf("Expected the request to carry the time left, got %dms (%v)", v.TimeLeftMs, err)
+			}
+		case MsgAction:
+			var e engine.ActionEvent
+			if err := msg.Decode(&e); err == nil && e.PlayerName == "Alice" {
+				timedOut = timedOut || e.TimedOut
+			}
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Serve returned an error: %v", err)
+	}
+	if requests == 0 || !timedOut {
+		t.Errorf("Expected Alice to be asked to act and to time out, got %d requests, timed out: %v", requests, timedOut)
+	}
+}
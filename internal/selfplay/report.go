@@ -0,0 +1,159 @@
+package selfplay
+
+import (
+	"fmt"
+	"pls7-cli/pkg/poker"
+)
+
+// Report summarizes a simulation.
+type Report struct {
+	// Games is the number of games played to the end, if any.
+	Games int
+	// Hands is the number of hands played.
+	Hands int
+	// Showdowns is the number of hands that reached a showdown.
+	Showdowns int
+	// TotalPot is the sum of the pots of every hand, in chips.
+	TotalPot int
+	// TotalRake and TotalTimeCharges are the chips the house took in rake
+	// and time charges.
+	TotalRake        int
+	TotalTimeCharges int
+	// HandRanks counts the best high hands shown down, one per player and
+	// board at each showdown.
+	HandRanks map[poker.HandRank]int
+	// Profiles reports how each profile did, in the order of their first seat.
+	Profiles []*ProfileReport
+	// Violations describe the hands after which chips were missing or created.
+	Violations []string
+}
+
+// AveragePot returns the average pot of a hand, in chips.
+func (r *Report) AveragePot() float64 {
+	if r.Hands == 0 {
+		return 0
+	}
+	return float64(r.TotalPot) / float64(r.Hands)
+}
+
+// ProfileReport reports how the players with an AI profile did.
+type ProfileReport struct {
+	// Name is the name of the profile.
+	Name string
+	// Seats is the number of seats that played the profile.
+	Seats int
+	// Hands is the number of hands the seats played, counting each seat.
+	Hands int
+	// HandsWon is the number of those hands in which the seat won chips.
+	HandsWon int
+	// Showdowns is the number of those hands in which the seat reached a showdown.
+	Showdowns int
+	// ShowdownsWon is the number of showdowns in which the seat won chips.
+	ShowdownsWon int
+	// GamesWon is the number of games played to the end that the seats won.
+	GamesWon int
+	// results are the big blinds won in each hand.
+	results []float64
+}
+
+// WinRate returns the profile's measured win rate.
+func (p *ProfileReport) WinRate() Estimate {
+	return NewEstimate(p.results)
+}
+
+// Simulate plays the given number of hands at the table, refilling every
+// stack before each hand, and reports how each profile did, how the hands
+// went, and whether every chip was accounted for.
+func (t Table) Simulate(hands int, seed int64) (*Report, error) {
+	r, bySeat := t.newReport()
+	err := t.run(hands, seed, func(hand int, h handRecord) {
+		r.addHand(fmt.Sprintf("hand %d", hand+1), h, bySeat)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SimulateGames plays the given number of games at the table until one player
+// has every chip, without refilling the stacks, and reports on them like
+// Simulate. Short stacks build side pots, players are eliminated and the
+// button moves past their empty seats, and the chips at the table and taken
+// by the house are checked against the game's initial chips after every hand.
+func (t Table) SimulateGames(games int, seed int64) (*Report, error) {
+	records, err := t.runGames(games, seed)
+	if err != nil {
+		return nil, err
+	}
+	r, bySeat := t.newReport()
+	for game, g := range records {
+		r.Games++
+		for hand, h := range g.hands {
+			r.addHand(fmt.Sprintf("game %d, hand %d", game+1, hand+1), h, bySeat)
+		}
+		if g.winner < 0 {
+			r.Violations = append(r.Violations, fmt.Sprintf("game %d: no winner after %d hands", game+1, len(g.hands)))
+			continue
+		}
+		bySeat[g.winner].GamesWon++
+	}
+	return r, nil
+}
+
+// newReport returns an empty report with the table's profiles, and the report
+// of the profile of each seat.
+func (t Table) newReport() (*Report, []*ProfileReport) {
+	r := &Report{HandRanks: make(map[poker.HandRank]int)}
+	bySeat := make([]*ProfileReport, len(t.Profiles))
+	byName := make(map[string]*ProfileReport)
+	for seat, profile := range t.Profiles {
+		p, ok := byName[profile.Name]
+		if !ok {
+			p = &ProfileReport{Name: profile.Name}
+			byName[profile.Name] = p
+			r.Profiles = append(r.Profiles, p)
+		}
+		p.Seats++
+		bySeat[seat] = p
+	}
+	return r, bySeat
+}
+
+// addHand adds a hand to the report. The hand is named in the violations
+// found in it.
+func (r *Report) addHand(name string, h handRecord, bySeat []*ProfileReport) {
+	r.Hands++
+	if h.showdown {
+		r.Showdowns++
+	}
+	r.TotalPot += h.pot
+	r.TotalRake += h.rake
+	r.TotalTimeCharges += h.timeCharges
+	for _, rank := range h.ranks {
+		r.HandRanks[rank]++
+	}
+	for seat, delta := range h.deltas {
+		if !h.dealtIn[seat] {
+			continue
+		}
+		p := bySeat[seat]
+		p.Hands++
+		p.results = append(p.results, float64(delta)/float64(h.bigBlind))
+		if delta > 0 {
+			p.HandsWon++
+		}
+		if h.shownDown[seat] {
+			p.Showdowns++
+			if delta > 0 {
+				p.ShowdownsWon++
+			}
+		}
+	}
+
+	if h.chips != h.expected {
+		r.Violations = append(r.Violations, fmt.Sprintf("%s: %d chips at the table and taken by the house, expected %d", name, h.chips, h.expected))
+	}
+	if h.awarded+h.rake != h.pot {
+		r.Violations = append(r.Violations, fmt.Sprintf("%s: %d chips awarded and %d raked from a pot of %d", name, h.awarded, h.rake, h.pot))
+	}
+}
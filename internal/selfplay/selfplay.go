@@ -0,0 +1,352 @@
+// Package selfplay plays headless games between CPU players. It measures how
+// well AI profiles do against each other and searches for stronger profiles.
+package selfplay
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"sync"
+)
+
+// Table describes a self-play table. Every seat is played by a CPU player.
+// Hands are played either with every stack refilled before each hand, so that
+// the results of the hands are independent of each other, or in games played
+// to the end, in which the stacks are never refilled.
+type Table struct {
+	// Rules are the rules of the game variant played at the table.
+	Rules *poker.GameRules
+	// Profiles are the AI profiles of the seats, in seat order (2-6 seats).
+	Profiles []engine.AIProfile
+	// SmallBlind and BigBlind are the blinds. They only go up in games played
+	// to the end.
+	SmallBlind int
+	BigBlind   int
+	// BlindUpInterval is the number of hands after which the blinds double in
+	// games played to the end. 0 keeps them fixed.
+	BlindUpInterval int
+	// Stack is the number of chips every player starts each hand, or each
+	// game played to the end, with.
+	Stack int
+	// Rake is what the house takes from the game. The zero value takes nothing.
+	Rake engine.Rake
+	// Workers is the number of goroutines the hands are played on. Values below
+	// 2 play every hand on the calling goroutine. The results are the same for
+	// any number of workers.
+	Workers int
+}
+
+// batchHands is the number of hands played in a row at one game. Every batch
+// is a new game with its own random stream, so batches can be played in any
+// order and on any goroutine. It is a multiple of every table size, so each
+// seat has the button equally often.
+const batchHands = 60
+
+// Validate checks that the table can be played.
+func (t Table) Validate() error {
+	if t.Rules == nil {
+		return fmt.Errorf("no game rules")
+	}
+	if len(t.Profiles) < 2 || len(t.Profiles) > 6 {
+		return fmt.Errorf("a table needs 2 to 6 seats, got %d", len(t.Profiles))
+	}
+	if t.SmallBlind <= 0 || t.SmallBlind >= t.BigBlind {
+		return fmt.Errorf("the small blind must be positive and smaller than the big blind, got %d/%d", t.SmallBlind, t.BigBlind)
+	}
+	if t.Stack < t.BigBlind {
+		return fmt.Errorf("the stack must be at least the big blind (%d), got %d", t.BigBlind, t.Stack)
+	}
+	if t.BlindUpInterval < 0 {
+		return fmt.Errorf("the blind-up interval cannot be negative, got %d", t.BlindUpInterval)
+	}
+	if err := t.Rake.Validate(); err != nil {
+		return err
+	}
+	for seat, profile := range t.Profiles {
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("seat %d (%s): %w", seat, profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// newGame sets up a game at the table that draws from the given random stream.
+func (t Table) newGame(r *rand.Rand) (*engine.Game, error) {
+	// NewGame seats a human named YOU in the first seat; every seat is then
+	// handed to a CPU player with the table's profile.
+	names := []string{"YOU"}
+	for len(names) < len(t.Profiles) {
+		names = append(names, fmt.Sprintf("CPU %d", len(names)))
+	}
+	g := engine.NewGame(names, t.Stack, t.SmallBlind, t.BigBlind, engine.DifficultyMedium, t.Rules, false, false, 0)
+	for seat, profile := range t.Profiles {
+		if err := g.SetCPU(seat, fmt.Sprintf("CPU %d", seat), profile); err != nil {
+			return nil, err
+		}
+	}
+	g.Rand = r
+	g.Rake = t.Rake
+	// The hands are already spread over the table's workers.
+	g.EquityWorkers = 1
+	return g, nil
+}
+
+// Play plays the given number of hands at the table and returns the chips each
+// seat won or lost in each hand, in big blinds: results[seat][hand]. Games with
+// the same seed and profiles play out the same way.
+func (t Table) Play(hands int, seed int64) ([][]float64, error) {
+	results := make([][]float64, len(t.Profiles))
+	for seat := range results {
+		results[seat] = make([]float64, hands)
+	}
+	err := t.run(hands, seed, func(hand int, h handRecord) {
+		for seat, delta := range h.deltas {
+			results[seat][hand] = float64(delta) / float64(t.BigBlind)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// handRecord is what a self-play run keeps of a hand once it has been played.
+type handRecord struct {
+	// bigBlind is the big blind of the hand.
+	bigBlind int
+	// dealtIn tells which seats were dealt in, and deltas the chips each seat
+	// won or lost.
+	dealtIn []bool
+	deltas  []int
+	// showdown tells whether the hand reached a showdown, and shownDown which
+	// seats showed their hands there.
+	showdown  bool
+	shownDown []bool
+	// ranks are the ranks of the best high hands shown down.
+	ranks []poker.HandRank
+	// pot is the sum of the seats' bets, awarded the chips awarded from it,
+	// and chips the chips at the table and taken by the house after the hand,
+	// which should be the expected chips.
+	pot, awarded, chips, expected int
+	// rake is the rake taken from the pot, and timeCharges the time charges
+	// the players paid to be dealt in.
+	rake, timeCharges int
+}
+
+// run plays the given number of hands at the table, refilling every stack
+// before each hand, and calls afterHand with each hand in order. The hands are
+// played in batches spread over the table's workers, each batch with its own
+// random stream derived from the seed.
+func (t Table) run(hands int, seed int64, afterHand func(hand int, h handRecord)) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	records := make([]handRecord, hands)
+	batches := (hands + batchHands - 1) / batchHands
+	errs := make([]error, batches)
+	t.forEach(batches, func(batch int) {
+		first := batch * batchHands
+		errs[batch] = t.playBatch(poker.NewStreamRand(seed, batch), records[first:min(first+batchHands, hands)])
+	})
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	for hand, h := range records {
+		afterHand(hand, h)
+	}
+	return nil
+}
+
+// playBatch plays one hand for each record at a new game and fills in the
+// records.
+func (t Table) playBatch(r *rand.Rand, records []handRecord) error {
+	g, err := t.newGame(r)
+	if err != nil {
+		return err
+	}
+	for i := range records {
+		for _, p := range g.Players {
+			p.Chips = t.Stack
+		}
+		houseChips := g.HouseChips
+		records[i] = playHand(g)
+		records[i].expected = t.Stack*len(g.Players) + houseChips
+	}
+	return nil
+}
+
+// maxGameHands is the most hands a game played to the end may last. Games
+// still going by then are reported as unfinished.
+const maxGameHands = 10000
+
+// gameRecord is what a self-play run keeps of a game played to the end.
+type gameRecord struct {
+	// hands are the hands of the game, in order.
+	hands []handRecord
+	// winner is the seat of the player left with every chip, or -1 if the
+	// game did not finish within maxGameHands.
+	winner int
+}
+
+// runGames plays the given number of games at the table to the end without
+// refilling the stacks, and returns them in order. The games are spread over
+// the table's workers, each game with its own random stream derived from the
+// seed.
+func (t Table) runGames(games int, seed int64) ([]gameRecord, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	records := make([]gameRecord, games)
+	errs := make([]error, games)
+	t.forEach(games, func(game int) {
+		records[game], errs[game] = t.playGame(poker.NewStreamRand(seed, game))
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// playGame plays a new game until one player has every chip. Players who run
+// out of chips are eliminated, and the blinds go up every BlindUpInterval
+// hands.
+func (t Table) playGame(r *rand.Rand) (gameRecord, error) {
+	g, err := t.newGame(r)
+	if err != nil {
+		return gameRecord{}, err
+	}
+	g.BlindUpInterval = t.BlindUpInterval
+
+	record := gameRecord{winner: -1}
+	for g.CountRemainingPlayers() > 1 && len(record.hands) < maxGameHands {
+		h := playHand(g)
+		h.expected = g.TotalInitialChips
+		record.hands = append(record.hands, h)
+		g.CleanupHand()
+	}
+	for seat, p := range g.Players {
+		if g.CountRemainingPlayers() == 1 && p.IsActive() {
+			record.winner = seat
+		}
+	}
+	return record, nil
+}
+
+// playHand plays a hand at the game and records it. The expected chips are
+// left for the caller to fill in.
+func playHand(g *engine.Game) handRecord {
+	before := make([]int, len(g.Players))
+	for seat, p := range g.Players {
+		before[seat] = p.Chips
+	}
+	houseChips := g.HouseChips
+	end := g.PlayHand(cpuProvider{}, nil)
+
+	h := handRecord{
+		bigBlind:  g.BigBlind,
+		dealtIn:   make([]bool, len(g.Players)),
+		deltas:    make([]int, len(g.Players)),
+		showdown:  end.Showdown,
+		shownDown: make([]bool, len(g.Players)),
+		chips:     g.Pot + g.HouseChips,
+		rake:      end.Rake,
+	}
+	h.timeCharges = g.HouseChips - houseChips - end.Rake
+	for _, result := range end.Results {
+		h.awarded += result.AmountWon
+	}
+	boards := end.Boards
+	if len(boards) == 0 {
+		boards = [][]poker.Card{g.CommunityCards}
+	}
+	for seat, p := range g.Players {
+		h.chips += p.Chips
+		h.deltas[seat] = p.Chips - before[seat]
+		h.dealtIn[seat] = p.Status != engine.PlayerStatusEliminated
+		// Eliminated players keep the bets of the hand they were knocked out in.
+		if h.dealtIn[seat] {
+			h.pot += p.TotalBetInHand
+		}
+		if end.Showdown && p.Status != engine.PlayerStatusFolded {
+			h.shownDown[seat] = true
+			for _, board := range boards {
+				if high, _ := poker.EvaluateHand(p.Hand, board, g.Rules); high != nil {
+					h.ranks = append(h.ranks, high.Rank)
+				}
+			}
+		}
+	}
+	return h
+}
+
+// forEach calls f with 0 to n-1, spread over the table's workers.
+func (t Table) forEach(n int, f func(i int)) {
+	if t.Workers < 2 {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(t.Workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// cpuProvider lets the AI decide for every seat.
+type cpuProvider struct{}
+
+func (cpuProvider) GetAction(g *engine.Game, p *engine.Player, r *rand.Rand) engine.PlayerAction {
+	return g.GetCPUAction(p, r)
+}
+
+// Estimate is a win rate measured over a number of hands.
+type Estimate struct {
+	// Hands is the number of hands played.
+	Hands int
+	// BBPer100 is the average number of big blinds won per 100 hands.
+	BBPer100 float64
+	// Margin is the half-width of the 95% confidence interval of BBPer100.
+	Margin float64
+}
+
+// NewEstimate estimates the win rate from the big blinds won in each hand.
+func NewEstimate(results []float64) Estimate {
+	n := float64(len(results))
+	if n == 0 {
+		return Estimate{}
+	}
+	var sum, sumSquares float64
+	for _, bb := range results {
+		sum += bb
+		sumSquares += bb * bb
+	}
+	mean := sum / n
+	e := Estimate{Hands: len(results), BBPer100: mean * 100}
+	if n > 1 {
+		variance := (sumSquares - n*mean*mean) / (n - 1)
+		e.Margin = 1.96 * math.Sqrt(math.Max(variance, 0)/n) * 100
+	}
+	return e
+}
+
+// String formats the estimate, e.g. "+12.3 ± 4.5 bb/100 (2000 hands)".
+func (e Estimate) String() string {
+	return fmt.Sprintf("%+.1f ± %.1f bb/100 (%d hands)", e.BBPer100, e.Margin, e.Hands)
+}
@@ -0,0 +1,340 @@
+package selfplay
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"pls7-cli/internal/config"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// newTestTable returns a heads-up No-Limit Hold'em table of the given profiles.
+func newTestTable(t *testing.T, profiles ...string) Table {
+	t.Helper()
+	rules, err := config.LoadGameRulesFromFile("../../rules/nlh.yml")
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	table := Table{Rules: rules, SmallBlind: 50, BigBlind: 100, Stack: 10000}
+	c := engine.DefaultAIConfig()
+	for _, name := range profiles {
+		profile, err := c.Profile(name)
+		if err != nil {
+			t.Fatalf("Failed to get profile: %v", err)
+		}
+		table.Profiles = append(table.Profiles, profile)
+	}
+	return table
+}
+
+func TestTable_PlayIsZeroSumAndRepeatable(t *testing.T) {
+	table := newTestTable(t, "Tight-Aggressive", "Loose-Passive")
+
+	results, err := table.Play(10, 42)
+	if err != nil {
+		t.Fatalf("Play returned unexpected error: %v", err)
+	}
+	if len(results) != 2 || len(results[0]) != 10 {
+		t.Fatalf("Expected results of 10 hands for 2 seats, got %d seats", len(results))
+	}
+	for hand := range results[0] {
+		if sum := results[0][hand] + results[1][hand]; math.Abs(sum) > 1e-9 {
+			t.Errorf("Expected hand %d to be zero-sum, got %.2f bb", hand+1, sum)
+		}
+	}
+
+	again, _ := table.Play(10, 42)
+	if !reflect.DeepEqual(results, again) {
+		t.Error("Expected the same seed to replay the same hands")
+	}
+}
+
+func TestTable_Validate(t *testing.T) {
+	table := newTestTable(t, "Tight-Aggressive")
+	if err := table.Validate(); err == nil {
+		t.Error("Expected an error for a table with one seat")
+	}
+	table = newTestTable(t, "Tight-Aggressive", "Loose-Passive")
+	table.Stack = 50
+	if err := table.Validate(); err == nil {
+		t.Error("Expected an error for a stack below the big blind")
+	}
+}
+
+func TestNewEstimate(t *testing.T) {
+	e := NewEstimate([]float64{1, -1, 1, -1, 3})
+	if math.Abs(e.BBPer100-60) > 1e-9 || e.Hands != 5 {
+		t.Errorf("Expected +60 bb/100 over 5 hands, got %+v", e)
+	}
+	// The sample standard deviation is 1.67, so the margin is 1.96 * 1.67 / sqrt(5) * 100.
+	if math.Abs(e.Margin-146.7) > 0.1 {
+		t.Errorf("Expected a margin of 146.7 bb/100, got %.1f", e.Margin)
+	}
+	if e := NewEstimate(nil); e != (Estimate{}) {
+		t.Errorf("Expected an empty estimate without hands, got %+v", e)
+	}
+}
+
+func TestParseGridAxis(t *testing.T) {
+	axis, err := ParseGridAxis("bluffing_frequency=0.1, 0.2")
+	if err != nil || axis.Param.Name != "bluffing_frequency" || !reflect.DeepEqual(axis.Values, []float64{0.1, 0.2}) {
+		t.Errorf("Expected bluffing_frequency=[0.1 0.2], got %+v (%v)", axis, err)
+	}
+	for _, spec := range []string{"bluffing", "unknown=1", "aggression_factor=high"} {
+		if _, err := ParseGridAxis(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
+
+func TestTuner_GridTriesValidCombinations(t *testing.T) {
+	tuner := &Tuner{Table: newTestTable(t, "Tight-Aggressive", "Loose-Passive"), Hands: 3, Seed: 1}
+	play, _ := LookupParam("play_hand_threshold")
+	bluff, _ := LookupParam("bluffing_frequency")
+	axes := []GridAxis{
+		{Param: play, Values: []float64{10, 30}}, // 30 is above the raise threshold of 25.
+		{Param: bluff, Values: []float64{0, 0.5}},
+	}
+
+	played := 0
+	tuner.Progress = func(Candidate) { played++ }
+	candidates, err := tuner.Grid(tuner.Table.Profiles[0], axes)
+	if err != nil {
+		t.Fatalf("Grid returned unexpected error: %v", err)
+	}
+	if len(candidates) != 2 || played != 2 {
+		t.Fatalf("Expected 2 valid combinations to be played, got %d (%d reported)", len(candidates), played)
+	}
+	if candidates[0].Estimate.BBPer100 < candidates[1].Estimate.BBPer100 {
+		t.Error("Expected the candidates to be sorted best first")
+	}
+	for _, c := range candidates {
+		if c.Profile.PlayHandThreshold != 10 {
+			t.Errorf("Expected only the valid play threshold, got %v", c.Profile.PlayHandThreshold)
+		}
+	}
+}
+
+func TestTuner_EvolveWritesLoadableProfiles(t *testing.T) {
+	tuner := &Tuner{Table: newTestTable(t, "Tight-Aggressive", "Loose-Passive"), Hands: 2, Seed: 1}
+	candidates, err := tuner.Evolve(tuner.Table.Profiles[0], 3, 2)
+	if err != nil {
+		t.Fatalf("Evolve returned unexpected error: %v", err)
+	}
+	if len(candidates) != 3 {
+		t.Fatalf("Expected a population of 3, got %d", len(candidates))
+	}
+	for _, c := range candidates {
+		if err := c.Profile.Validate(); err != nil {
+			t.Errorf("Expected valid profiles, got %v", err)
+		}
+	}
+
+	data, err := MarshalProfiles("Tuned", candidates[:2])
+	if err != nil {
+		t.Fatalf("MarshalProfiles returned unexpected error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "tuned.yml")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write profiles: %v", err)
+	}
+	c, err := engine.LoadAIConfig(path)
+	if err != nil {
+		t.Fatalf("Expected the tuned profiles to load, got %v", err)
+	}
+	for i, name := range []string{"Tuned", "Tuned-2"} {
+		profile, err := c.Profile(name)
+		expected := candidates[i].Profile
+		expected.Name = name
+		if err != nil || profile != expected {
+			t.Errorf("Expected profile %s to be %+v, got %+v (%v)", name, expected, profile, err)
+		}
+	}
+}
+
+func TestTable_Simulate(t *testing.T) {
+	table := newTestTable(t, "Tight-Aggressive", "Loose-Passive", "Tight-Aggressive")
+
+	r, err := table.Simulate(10, 7)
+	if err != nil {
+		t.Fatalf("Simulate returned unexpected error: %v", err)
+	}
+	if r.Hands != 10 || len(r.Violations) != 0 {
+		t.Errorf("Expected 10 hands without violations, got %d hands and %v", r.Hands, r.Violations)
+	}
+	if len(r.Profiles) != 2 || r.Profiles[0].Name != "Tight-Aggressive" || r.Profiles[0].Seats != 2 || r.Profiles[0].Hands != 20 {
+		t.Fatalf("Expected Tight-Aggressive to play 2 seats for 20 hands first, got %+v", r.Profiles[0])
+	}
+
+	// The seats' winnings add up to zero, and every player at a showdown shows a hand.
+	var won float64
+	showdownPlayers, shownHands := 0, 0
+	for _, p := range r.Profiles {
+		won += p.WinRate().BBPer100 * float64(p.Hands)
+		showdownPlayers += p.Showdowns
+	}
+	for _, n := range r.HandRanks {
+		shownHands += n
+	}
+	if math.Abs(won) > 1e-6 {
+		t.Errorf("Expected the profiles' winnings to add up to zero, got %.2f", won)
+	}
+	if shownHands != showdownPlayers || (r.Showdowns > 0) != (shownHands > 0) {
+		t.Errorf("Expected %d hands shown down, got %d", showdownPlayers, shownHands)
+	}
+	if r.AveragePot() < float64(table.SmallBlind+table.BigBlind) {
+		t.Errorf("Expected pots of at least the blinds, got %.1f", r.AveragePot())
+	}
+}
+
+func TestTable_SimulateDoubleBoard(t *testing.T) {
+	table := newTestTable(t, "Loose-Passive", "Loose-Passive")
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls7-db.yml")
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	table.Rules = rules
+
+	r, err := table.Simulate(6, 7)
+	if err != nil {
+		t.Fatalf("Simulate returned unexpected error: %v", err)
+	}
+	// Every player at a showdown shows a hand on each of the two boards.
+	showdownPlayers, shownHands := 0, 0
+	for _, p := range r.Profiles {
+		showdownPlayers += p.Showdowns
+	}
+	for _, n := range r.HandRanks {
+		shownHands += n
+	}
+	if showdownPlayers == 0 || shownHands != 2*showdownPlayers {
+		t.Errorf("Expected 2 hands shown down for each of %d players, got %d", showdownPlayers, shownHands)
+	}
+}
+
+func TestTable_PlaySameForAnyNumberOfWorkers(t *testing.T) {
+	table := newTestTable(t, "Tight-Aggressive", "Loose-Passive")
+	hands := 2*batchHands + 5 // Three batches, the last one short.
+
+	results, err := table.Play(hands, 7)
+	if err != nil {
+		t.Fatalf("Play returned unexpected error: %v", err)
+	}
+	table.Workers = 4
+	parallel, err := table.Play(hands, 7)
+	if err != nil {
+		t.Fatalf("Play returned unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(results, parallel) {
+		t.Error("Expected 4 workers to play the same hands as 1")
+	}
+}
+
+func TestTable_SimulateWithRake(t *testing.T) {
+	table := newTestTable(t, "Tight-Aggressive", "Loose-Passive", "Tight-Aggressive")
+	table.Rake = engine.Rake{Percent: 5, Cap: 300, TimeCharge: 10}
+
+	r, err := table.Simulate(20, 7)
+	if err != nil {
+		t.Fatalf("Simulate returned unexpected error: %v", err)
+	}
+	if len(r.Violations) != 0 {
+		t.Errorf("Expected the house's chips to be accounted for, got %v", r.Violations)
+	}
+	if r.TotalRake <= 0 || r.TotalTimeCharges != 20*3*10 {
+		t.Errorf("Expected rake and a time charge of 10 from 3 players in 20 hands, got %d and %d", r.TotalRake, r.TotalTimeCharges)
+	}
+
+	// The players lose what the house takes.
+	var won float64
+	for _, p := range r.Profiles {
+		won += p.WinRate().BBPer100 * float64(p.Hands) / 100 * float64(table.BigBlind)
+	}
+	if house := float64(r.TotalRake + r.TotalTimeCharges); math.Abs(won+house) > 1e-6 {
+		t.Errorf("Expected the players to lose %.0f to the house, got %.2f", house, won)
+	}
+
+	table.Rake.Percent = 101
+	if err := table.Validate(); err == nil {
+		t.Error("Expected an error for a rake above 100 percent")
+	}
+}
+
+func TestTable_SimulateGames(t *testing.T) {
+	table := newTestTable(t, "Loose-Aggressive", "Tight-Aggressive", "Loose-Passive")
+	table.Stack = 2000
+	table.BlindUpInterval = 5
+	table.Rake = engine.Rake{Percent: 5, Cap: 300}
+
+	r, err := table.SimulateGames(2, 7)
+	if err != nil {
+		t.Fatalf("SimulateGames returned unexpected error: %v", err)
+	}
+	if r.Games != 2 || r.Hands == 0 || len(r.Violations) != 0 {
+		t.Errorf("Expected 2 games without violations, got %d games of %d hands and %v", r.Games, r.Hands, r.Violations)
+	}
+	won, hands := 0, 0
+	for _, p := range r.Profiles {
+		won += p.GamesWon
+		hands += p.Hands
+	}
+	// Players knocked out before the last hand are not dealt in to the rest.
+	if won != 2 || hands >= 3*r.Hands {
+		t.Errorf("Expected a winner of each game and eliminated players to sit out, got %d winners and %d hands dealt in", won, hands)
+	}
+
+	again, _ := table.SimulateGames(2, 7)
+	if !reflect.DeepEqual(r, again) {
+		t.Error("Expected the same seed to replay the same games")
+	}
+}
+
+func TestTable_ChipsConservedToElimination(t *testing.T) {
+	// Unlike Play, the stacks are never refilled: the players start with
+	// different stacks, so short stacks build side pots, and they play on
+	// until one of them has every chip.
+	for _, rule := range []string{"nlh", "plo8-db"} {
+		t.Run(rule, func(t *testing.T) {
+			table := newTestTable(t, "Loose-Aggressive", "Tight-Aggressive", "Loose-Passive", "Loose-Aggressive", "Tight-Passive", "Loose-Aggressive")
+			rules, err := config.LoadGameRulesFromFile("../../rules/" + rule + ".yml")
+			if err != nil {
+				t.Fatalf("Failed to load rules: %v", err)
+			}
+			table.Rules = rules
+			table.Rake = engine.Rake{Percent: 5, Cap: 300}
+			g, err := table.newGame(poker.NewStreamRand(3, 0))
+			if err != nil {
+				t.Fatalf("newGame returned unexpected error: %v", err)
+			}
+			g.BlindUpInterval = 5
+			g.EquityWorkers = runtime.NumCPU()
+			g.TotalInitialChips = 0
+			for seat, p := range g.Players {
+				p.Chips = 400 * (seat + 1)
+				g.TotalInitialChips += p.Chips
+			}
+
+			for g.CountRemainingPlayers() > 1 {
+				if g.HandCount == 1000 {
+					t.Fatalf("Expected a winner within 1000 hands, %d players are left", g.CountRemainingPlayers())
+				}
+				g.PlayHand(cpuProvider{}, nil)
+				g.CleanupHand()
+				chips := g.Pot + g.HouseChips
+				for _, p := range g.Players {
+					if p.Chips < 0 {
+						t.Fatalf("Expected no negative stacks after hand %d, got %v", g.HandCount, p)
+					}
+					chips += p.Chips
+				}
+				if chips != g.TotalInitialChips {
+					t.Fatalf("Expected %d chips after hand %d, got %d", g.TotalInitialChips, g.HandCount, chips)
+				}
+			}
+		})
+	}
+}
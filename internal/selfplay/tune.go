@@ -0,0 +1,258 @@
+package selfplay
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"pls7-cli/pkg/engine"
+	"pls7-cli/pkg/poker"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Param is an AIProfile parameter the tuner can change, named after its YAML
+// key. Min and Max bound the values the evolutionary search tries.
+type Param struct {
+	Name     string
+	Min, Max float64
+	field    func(p *engine.AIProfile) *float64
+}
+
+// Get returns the parameter's value in the profile.
+func (p Param) Get(profile engine.AIProfile) float64 {
+	return *p.field(&profile)
+}
+
+// Set sets the parameter's value in the profile.
+func (p Param) Set(profile *engine.AIProfile, value float64) {
+	*p.field(profile) = value
+}
+
+// Params are the parameters the tuner can change.
+var Params = []Param{
+	{Name: "play_hand_threshold", Min: 0, Max: 40, field: func(p *engine.AIProfile) *float64 { return &p.PlayHandThreshold }},
+	{Name: "raise_hand_threshold", Min: 0, Max: 50, field: func(p *engine.AIProfile) *float64 { return &p.RaiseHandThreshold }},
+	{Name: "bluffing_frequency", Min: 0, Max: 1, field: func(p *engine.AIProfile) *float64 { return &p.BluffingFrequency }},
+	{Name: "aggression_factor", Min: 0, Max: 1, field: func(p *engine.AIProfile) *float64 { return &p.AggressionFactor }},
+	{Name: "min_raise_multiplier", Min: 1, Max: 8, field: func(p *engine.AIProfile) *float64 { return &p.MinRaiseMultiplier }},
+	{Name: "max_raise_multiplier", Min: 1, Max: 10, field: func(p *engine.AIProfile) *float64 { return &p.MaxRaiseMultiplier }},
+	{Name: "position_bonus", Min: 0, Max: 15, field: func(p *engine.AIProfile) *float64 { return &p.PositionBonus }},
+	{Name: "continuation_bet_frequency", Min: 0, Max: 1, field: func(p *engine.AIProfile) *float64 { return &p.ContinuationBetFrequency }},
+	{Name: "check_raise_frequency", Min: 0, Max: 1, field: func(p *engine.AIProfile) *float64 { return &p.CheckRaiseFrequency }},
+}
+
+// LookupParam returns the tunable parameter with the given name.
+func LookupParam(name string) (Param, bool) {
+	for _, p := range Params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Param{}, false
+}
+
+// GridAxis is a parameter and the values the grid search tries for it.
+type GridAxis struct {
+	Param  Param
+	Values []float64
+}
+
+// ParseGridAxis parses a "name=value,value,..." specification, e.g.
+// "bluffing_frequency=0.1,0.2,0.3".
+func ParseGridAxis(spec string) (GridAxis, error) {
+	name, list, found := strings.Cut(spec, "=")
+	param, ok := LookupParam(strings.TrimSpace(name))
+	if !found || !ok {
+		return GridAxis{}, fmt.Errorf("unknown parameter in grid %q", spec)
+	}
+	axis := GridAxis{Param: param}
+	for _, s := range strings.Split(list, ",") {
+		value, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return GridAxis{}, fmt.Errorf("invalid value in grid %q: %w", spec, err)
+		}
+		axis.Values = append(axis.Values, value)
+	}
+	return axis, nil
+}
+
+// Candidate is a profile tried by the tuner, with its measured win rate.
+type Candidate struct {
+	Profile  engine.AIProfile
+	Estimate Estimate
+	// Generation is the generation of the evolutionary search the candidate
+	// was created in, or 0 for grid searches.
+	Generation int
+}
+
+// Tuner searches for AI profiles that win the most against a field of other
+// profiles. Every candidate plays the same number of hands with the same seed
+// in seat 0 of the table, while the other seats keep their profiles.
+type Tuner struct {
+	// Table is the table candidates are tried at.
+	Table Table
+	// Hands is the number of hands each candidate plays.
+	Hands int
+	// Seed seeds the games and the search.
+	Seed int64
+	// Progress, if set, is called with every candidate once it has been played.
+	Progress func(c Candidate)
+}
+
+// Evaluate plays the profile in seat 0 of the table and measures its win rate.
+func (t *Tuner) Evaluate(profile engine.AIProfile) (Estimate, error) {
+	table := t.Table
+	table.Profiles = append([]engine.AIProfile{profile}, t.Table.Profiles[1:]...)
+	results, err := table.Play(t.Hands, t.Seed)
+	if err != nil {
+		return Estimate{}, err
+	}
+	return NewEstimate(results[0]), nil
+}
+
+// evaluate plays a candidate and reports it.
+func (t *Tuner) evaluate(profile engine.AIProfile, generation int) (Candidate, error) {
+	estimate, err := t.Evaluate(profile)
+	if err != nil {
+		return Candidate{}, err
+	}
+	c := Candidate{Profile: profile, Estimate: estimate, Generation: generation}
+	if t.Progress != nil {
+		t.Progress(c)
+	}
+	return c, nil
+}
+
+// Grid tries every combination of the axes' values on top of the base profile
+// and returns the candidates, best first. Combinations that do not make a valid
+// profile (e.g. a raise threshold below the play threshold) are skipped.
+func (t *Tuner) Grid(base engine.AIProfile, axes []GridAxis) ([]Candidate, error) {
+	var candidates []Candidate
+	var try func(profile engine.AIProfile, axes []GridAxis) error
+	try = func(profile engine.AIProfile, axes []GridAxis) error {
+		if len(axes) == 0 {
+			if profile.Validate() != nil {
+				return nil
+			}
+			c, err := t.evaluate(profile, 0)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, c)
+			return nil
+		}
+		for _, value := range axes[0].Values {
+			axes[0].Param.Set(&profile, value)
+			if err := try(profile, axes[1:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := try(base, axes); err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("the grid has no valid profiles")
+	}
+	sortCandidates(candidates)
+	return candidates, nil
+}
+
+// Evolve runs an evolutionary search starting from the base profile. Each
+// generation keeps the better half of the population and replaces the rest
+// with mutations of the survivors. It returns the last population, best first.
+func (t *Tuner) Evolve(base engine.AIProfile, population, generations int) ([]Candidate, error) {
+	if population < 2 || generations < 1 {
+		return nil, fmt.Errorf("the search needs a population of at least 2 and at least 1 generation, got %d and %d", population, generations)
+	}
+	if err := base.Validate(); err != nil {
+		return nil, fmt.Errorf("base profile: %w", err)
+	}
+	r := poker.NewRand(t.Seed)
+
+	first, err := t.evaluate(base, 1)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []Candidate{first}
+	for generation := 1; generation <= generations; generation++ {
+		survivors := candidates
+		if generation > 1 {
+			survivors = candidates[:max(len(candidates)/2, 1)]
+		}
+		candidates = append([]Candidate(nil), survivors...)
+		for len(candidates) < population {
+			parent := survivors[r.Intn(len(survivors))].Profile
+			c, err := t.evaluate(mutate(parent, r), generation)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, c)
+		}
+		sortCandidates(candidates)
+	}
+	return candidates, nil
+}
+
+// mutate returns a valid copy of the profile with some of its parameters moved
+// by a random amount, a tenth of their search range on average.
+func mutate(profile engine.AIProfile, r *rand.Rand) engine.AIProfile {
+	for {
+		mutant := profile
+		for _, p := range Params {
+			if r.Intn(2) == 0 {
+				continue
+			}
+			value := p.Get(mutant) + r.NormFloat64()*(p.Max-p.Min)/10
+			value = math.Round(math.Max(p.Min, math.Min(value, p.Max))*100) / 100
+			p.Set(&mutant, value)
+		}
+		// Keep the parameters that depend on each other consistent.
+		mutant.RaiseHandThreshold = math.Max(mutant.RaiseHandThreshold, mutant.PlayHandThreshold)
+		mutant.MaxRaiseMultiplier = math.Max(mutant.MaxRaiseMultiplier, mutant.MinRaiseMultiplier)
+		mutant.PositionBonus = math.Min(mutant.PositionBonus, mutant.PlayHandThreshold)
+		if mutant != profile && mutant.Validate() == nil {
+			return mutant
+		}
+	}
+}
+
+// sortCandidates sorts candidates by win rate, best first.
+func sortCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Estimate.BBPer100 > candidates[j].Estimate.BBPer100
+	})
+}
+
+// MarshalProfiles writes the candidates as YAML AI profiles that can be loaded
+// with --ai-profiles. The first candidate is named after name, the others get
+// a number appended ("Tuned", "Tuned-2", ...). A comment lists each profile's
+// measured win rate.
+func MarshalProfiles(name string, candidates []Candidate) ([]byte, error) {
+	var buf bytes.Buffer
+	c := engine.AIConfig{Profiles: make(map[string]engine.AIProfile)}
+	fmt.Fprintln(&buf, "# AI profiles found by pls7 tune.")
+	for i, candidate := range candidates {
+		profileName := name
+		if i > 0 {
+			profileName = fmt.Sprintf("%s-%d", name, i+1)
+		}
+		candidate.Profile.Name = profileName
+		c.Profiles[profileName] = candidate.Profile
+		fmt.Fprintf(&buf, "# %s: %s\n", profileName, candidate.Estimate)
+	}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&c); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -3,7 +3,12 @@
 // using the rules and data structures defined in the `poker` package.
 package engine
 
-import "math/rand"
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+)
 
 // ActionType defines the type of a player's action during a betting round.
 type ActionType int
@@ -23,14 +28,43 @@ func (at ActionType) String() string {
 	return []string{"Fold", "Check", "Call", "Bet", "Raise"}[at]
 }
 
+// MarshalText implements encoding.TextMarshaler, so action types appear as
+// lowercase names (e.g. "raise") in JSON messages.
+func (at ActionType) MarshalText() ([]byte, error) {
+	if at < ActionFold || at > ActionRaise {
+		return nil, fmt.Errorf("unknown action type: %d", int(at))
+	}
+	return []byte(strings.ToLower(at.String())), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names are case-insensitive.
+func (at *ActionType) UnmarshalText(text []byte) error {
+	parsed, err := ParseActionType(string(text))
+	if err != nil {
+		return err
+	}
+	*at = parsed
+	return nil
+}
+
+// ParseActionType converts an action name such as "call" or "Raise" into an ActionType.
+func ParseActionType(s string) (ActionType, error) {
+	for at := ActionFold; at <= ActionRaise; at++ {
+		if strings.EqualFold(s, at.String()) {
+			return at, nil
+		}
+	}
+	return ActionFold, fmt.Errorf("unknown action type: %q", s)
+}
+
 // PlayerAction represents an action taken by a player, including the type of action
 // and the amount for bets or raises.
 type PlayerAction struct {
 	// Type is the kind of action performed (e.g., Fold, Call, Raise).
-	Type ActionType
+	Type ActionType `json:"type"`
 	// Amount is the size of the bet or raise. It is only applicable for
 	// ActionBet and ActionRaise actions. For other actions, it should be 0.
-	Amount int
+	Amount int `json:"amount"`
 }
 
 // ActionProvider is a crucial interface that decouples the game engine from the
@@ -52,3 +86,24 @@ type ActionProvider interface {
 	// Returns a PlayerAction struct representing the player's chosen action.
 	GetAction(g *Game, p *Player, r *rand.Rand) PlayerAction
 }
+
+// ContextActionProvider is an ActionProvider whose decisions can be cut short.
+// When the game has a time control, PlayHand calls GetActionContext instead of
+// GetAction with a context that is canceled once the player's time is up.
+type ContextActionProvider interface {
+	ActionProvider
+	// GetActionContext works like GetAction, but must return promptly with the
+	// context's error once the context is done. The engine then acts on the
+	// player's behalf (see Game.DefaultAction).
+	GetActionContext(ctx context.Context, g *Game, p *Player, r *rand.Rand) (PlayerAction, error)
+}
+
+// RequestAction asks the provider for the player's action, passing the context
+// on if the provider supports it. It lets providers that wrap other providers
+// forward the context.
+func RequestAction(ctx context.Context, provider ActionProvider, g *Game, p *Player, r *rand.Rand) (PlayerAction, error) {
+	if cp, ok := provider.(ContextActionProvider); ok {
+		return cp.GetActionContext(ctx, g, p, r)
+	}
+	return provider.GetAction(g, p, r), nil
+}
@@ -1,218 +1,152 @@
 package engine
 
 import (
+	"math"
 	"math/rand"
 	"pls7-cli/pkg/poker"
-	"sort"
 	"time"
 )
 
-// byRank is a helper type that implements the sort.Interface for a slice of
-// poker.Rank, allowing them to be sorted. It sorts in descending order (Ace high).
-type byRank []poker.Rank
-
-func (a byRank) Len() int           { return len(a) }
-func (a byRank) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a byRank) Less(i, j int) bool { return a[i] > a[j] } // Sort descending
-
-// aiProfiles contains a set of predefined AI personalities that dictate how a CPU
-// player behaves. Each profile has different thresholds for playing, raising,
-// and bluffing, creating varied opponent styles.
-var aiProfiles = map[string]AIProfile{
-	"Tight-Aggressive": {
-		Name:               "Tight-Aggressive",
-		PlayHandThreshold:  20,   // Plays only the top 20% of starting hands.
-		RaiseHandThreshold: 25,   // Raises with the top 15% of hands.
-		BluffingFrequency:  0.15, // Bluffs occasionally.
-		AggressionFactor:   0.7,  // Highly likely to bet or raise with strong hands.
-		MinRaiseMultiplier: 2.5,
-		MaxRaiseMultiplier: 4.0,
-	},
-	"Loose-Aggressive": {
-		Name:               "Loose-Aggressive",
-		PlayHandThreshold:  10,   // Plays a wide range of hands (top 40%).
-		RaiseHandThreshold: 20,   // Raises often.
-		BluffingFrequency:  0.35, // Bluffs frequently.
-		AggressionFactor:   0.9,  // Very aggressive.
-		MinRaiseMultiplier: 2.0,
-		MaxRaiseMultiplier: 3.5,
-	},
-	"Tight-Passive": {
-		Name:               "Tight-Passive",
-		PlayHandThreshold:  22,   // Very selective with starting hands.
-		RaiseHandThreshold: 28,   // Rarely raises, only with premium hands.
-		BluffingFrequency:  0.05, // Almost never bluffs.
-		AggressionFactor:   0.3,  // Prefers to call rather than bet or raise.
-		MinRaiseMultiplier: 2.0,
-		MaxRaiseMultiplier: 2.5,
-	},
-	"Loose-Passive": {
-		Name:               "Loose-Passive",
-		PlayHandThreshold:  8,    // Plays many hands (calling station).
-		RaiseHandThreshold: 24,   // Rarely raises.
-		BluffingFrequency:  0.10, // Bluffs infrequently.
-		AggressionFactor:   0.2,  // Very passive, calls often, folds to aggression.
-		MinRaiseMultiplier: 2.0,
-		MaxRaiseMultiplier: 3.0,
-	},
-}
-
 // GetCPUAction determines the action for an AI-controlled player based on their
 // assigned profile and the current game state. This method implements the
 // ActionProvider interface for CPU players.
-// The logic is divided into pre-flop and post-flop stages.
+// The logic is divided into pre-flop and post-flop stages, both of which take
+// the player's position into account.
 func (g *Game) GetCPUAction(player *Player, r *rand.Rand) PlayerAction {
-	// First, evaluate the strength of the player's hand.
-	strength := g.handEvaluator(g, player)
-	canCheck := player.CurrentBet == g.BetToCall
-
 	// Simulate thinking time for a more realistic game pace.
 	time.Sleep(g.CPUThinkTime())
 
 	// --- Pre-Flop Logic ---
 	// Based on a simplified hand strength score.
 	if g.Phase == PhasePreFlop {
+		strength := g.handEvaluator(g, player)
+		playThreshold := player.Profile.PlayHandThreshold
+		raiseThreshold := player.Profile.RaiseHandThreshold
+		// Open wider the fewer players are left to act while no one has raised.
+		if g.Aggressor == nil {
+			bonus := player.Profile.PositionBonus * g.positionFactor(player)
+			playThreshold -= bonus
+			raiseThreshold -= bonus
+		}
 		// Fold if hand strength is below the profile's play threshold.
-		if strength < player.Profile.PlayHandThreshold {
+		if strength < playThreshold {
 			return PlayerAction{Type: ActionFold}
 		}
-		// Raise if hand strength is above the profile's raise threshold.
-		if strength >= player.Profile.RaiseHandThreshold {
-			return PlayerAction{Type: ActionRaise, Amount: g.minRaiseAmount() * 2}
+		// Raise if hand strength is above the profile's raise threshold, to
+		// twice the minimum raise within the legal betting limits.
+		if legal := g.LegalActions(player); strength >= raiseThreshold && legal.CanRaise {
+			amount := min(max(g.minRaiseAmount()*2, legal.MinAmount), legal.MaxAmount)
+			return PlayerAction{Type: ActionRaise, Amount: amount}
 		}
 		// Otherwise, just call.
 		return PlayerAction{Type: ActionCall}
 	}
 
 	// --- Post-Flop Logic ---
-	// Based on the actual rank of the 5-card hand.
+	// Based on the hand's equity against the opponents still in the hand.
+	return g.postFlopAction(player, r)
+}
 
-	// 1. Bluffing Logic: Decide whether to bluff based on profile frequency.
-	// A bluff is only attempted with a weak hand (less than OnePair).
-	isBluffing := r.Float64() < player.Profile.BluffingFrequency
-	if isBluffing && strength < float64(poker.OnePair) {
-		if canCheck {
-			// A "probe" bet when checked to.
-			return PlayerAction{Type: ActionBet, Amount: g.Pot / 2}
+// postFlopAction decides a post-flop action from the player's estimated equity.
+// A hand with more than its fair share of the pot is bet or raised for value as
+// often as the profile's aggression allows. Otherwise the player calls when the
+// equity beats the pot odds, bluffs now and then, and checks or folds. The pot
+// odds and bluffing frequency are adjusted to the opponents' statistics.
+//
+// Position and initiative matter too: the player who made the last bet or raise
+// of the previous round follows it up with a continuation bet, and a player out
+// of position may check a strong hand to raise when someone bets.
+func (g *Game) postFlopAction(player *Player, r *rand.Rand) PlayerAction {
+	legal := g.LegalActions(player)
+	equity := g.equityEstimator(g, player, r)
+	potOdds := poker.CalculateBreakEvenEquityBasedOnPotOdds(g.Pot, legal.CallAmount)
+	profile := player.Profile
+
+	var opponents []*Player
+	for _, p := range g.Players {
+		if p != player && p.Status != PlayerStatusFolded && p.IsActive() {
+			opponents = append(opponents, p)
 		}
-		// A bluff raise.
-		return PlayerAction{Type: ActionRaise, Amount: g.minRaiseAmount() * 2}
 	}
+	// A value hand wins clearly more than a fair share of the pot, which shrinks
+	// as more opponents stay in.
+	valueEquity := math.Min(1.5/float64(len(opponents)+1), 0.8)
+	// Bluffs work less often the more opponents there are to get through, and
+	// more often against opponents who fold a lot. With the initiative, the
+	// player bets at least as often as its continuation betting frequency.
+	bluffFrequency := profile.BluffingFrequency
+	if legal.CanCheck && g.PreviousAggressor == player {
+		bluffFrequency = math.Max(bluffFrequency, profile.ContinuationBetFrequency)
+	}
+	bluffFrequency *= bluffAdjustment(opponents) / float64(max(len(opponents), 1))
+	// Bets from players who bluff a lot are called with less equity.
+	if !legal.CanCheck {
+		potOdds *= callAdjustment(g.Aggressor)
+	}
+	outOfPosition := g.playersLeftToAct(player) > 0
 
-	// 2. Value Betting/Raising Logic (based on hand strength).
-	if strength >= float64(poker.TwoPair) { // Strong hands (Two Pair or better).
-		// Decide whether to be aggressive or "slow play" (trap).
-		if r.Float64() < player.Profile.AggressionFactor {
-			return PlayerAction{Type: ActionRaise, Amount: g.minRaiseAmount() * 2}
-		} else {
-			return PlayerAction{Type: ActionCall} // Slow play.
-		}
-	} else if strength >= float64(poker.OnePair) { // Decent, but vulnerable hands.
-		// Prefer to see the next card cheaply.
-		if canCheck {
+	isValue := equity >= valueEquity && r.Float64() < profile.AggressionFactor
+	isBluff := !isValue && r.Float64() < bluffFrequency
+	if legal.CanCheck {
+		// Check a strong hand out of position now and then to check-raise.
+		if isValue && outOfPosition && r.Float64() < profile.CheckRaiseFrequency {
 			return PlayerAction{Type: ActionCheck}
 		}
-		return PlayerAction{Type: ActionCall}
-	} else { // Weak hands / draws.
-		if canCheck {
-			return PlayerAction{Type: ActionCheck}
-		}
-		// Decide whether to fold or call based on a simplified version of pot odds.
-		potOdds := float64(g.BetToCall) / float64(g.Pot+g.BetToCall)
-		// A very rough estimation of equity.
-		if potOdds < player.Profile.BluffingFrequency*0.5 { // Call if pot odds are favorable.
-			return PlayerAction{Type: ActionCall}
+		if (isValue || isBluff) && legal.CanBet {
+			return PlayerAction{Type: ActionBet, Amount: g.betSize(player, legal, r)}
 		}
-		return PlayerAction{Type: ActionFold}
+		return PlayerAction{Type: ActionCheck}
+	}
+	// Having checked this round, a strong hand springs the check-raise.
+	checkRaise := player.LastActionDesc == "Check" && equity >= valueEquity
+	if (isValue || checkRaise || (isBluff && equity < potOdds)) && legal.CanRaise {
+		return PlayerAction{Type: ActionRaise, Amount: g.betSize(player, legal, r)}
+	}
+	if equity >= potOdds {
+		return PlayerAction{Type: ActionCall}
 	}
+	return PlayerAction{Type: ActionFold}
+}
+
+// betSize returns the amount for a bet, or the total to raise to, as a fraction
+// of the pot chosen between the profile's raise multipliers: a multiplier of 2
+// bets half the pot and a multiplier of 4 bets the whole pot. When raising, the
+// pot includes the call. The amount is kept within the legal betting limits.
+func (g *Game) betSize(player *Player, legal LegalActions, r *rand.Rand) int {
+	profile := player.Profile
+	multiplier := profile.MinRaiseMultiplier + r.Float64()*(profile.MaxRaiseMultiplier-profile.MinRaiseMultiplier)
+	potAfterCall := g.Pot + legal.CallAmount
+	amount := g.BetToCall + int(float64(potAfterCall)*multiplier/4)
+	if amount < legal.MinAmount {
+		amount = legal.MinAmount
+	}
+	if amount > legal.MaxAmount {
+		amount = legal.MaxAmount
+	}
+	return amount
 }
 
 // evaluateHandStrength calculates a numerical score for a player's hand to guide
 // AI decision-making. The evaluation method differs between pre-flop and post-flop.
 //
-// Post-flop, the score is simply the rank of the player's best 5-card hand.
+// Post-flop, the score is simply the rank of the player's best 5-card hand,
+// averaged over the boards in games with several boards.
 //
-// Pre-flop, it uses a custom scoring system to assess the potential of the hole
-// cards, considering:
-// - High card values (points for cards Ten and above).
-// - A significant bonus for pairs.
-// - A small bonus for suited cards.
-// - A bonus for connected cards (cards in sequence).
+// Pre-flop, it scores the potential of the hole cards with the heuristic for the
+// game variant; see preflopScore.
 func evaluateHandStrength(g *Game, player *Player) float64 {
 	// Post-Flop: The strength is the actual rank of the hand.
 	if g.Phase > PhasePreFlop {
-		highHand, _ := poker.EvaluateHand(player.Hand, g.CommunityCards, g.Rules)
-		if highHand != nil {
-			return float64(highHand.Rank)
-		}
-		return 0
-	}
-
-	// Pre-Flop: Evaluate potential based on hole cards using a custom heuristic.
-	var score float64
-	hand := player.Hand
-
-	// 1. High card points for cards Ten or higher.
-	rankPoints := map[poker.Rank]float64{
-		poker.Ace: 10, poker.King: 8, poker.Queen: 7, poker.Jack: 6, poker.Ten: 5,
-	}
-	for _, c := range hand {
-		score += rankPoints[c.Rank]
-	}
-
-	// 2. Add a large bonus for having a pair in the hole cards.
-	if len(hand) >= 3 {
-		if hand[0].Rank == hand[1].Rank || hand[0].Rank == hand[2].Rank || hand[1].Rank == hand[2].Rank {
-			pairRank := hand[0].Rank
-			if hand[1].Rank == hand[2].Rank {
-				pairRank = hand[1].Rank
+		boards := g.boards()
+		total := 0.0
+		for _, board := range boards {
+			if highHand, _ := poker.EvaluateHand(player.Hand, board, g.Rules); highHand != nil {
+				total += float64(highHand.Rank)
 			}
-			score += 15 + float64(pairRank) // Major bonus for pairs
-		}
-	} else if len(hand) == 2 {
-		if hand[0].Rank == hand[1].Rank {
-			score += 15 + float64(hand[0].Rank)
 		}
+		return total / float64(len(boards))
 	}
 
-	// 3. Add a small bonus if any cards are suited.
-	if len(hand) >= 3 {
-		if hand[0].Suit == hand[1].Suit || hand[0].Suit == hand[2].Suit || hand[1].Suit == hand[2].Suit {
-			score += 2
-		}
-	} else if len(hand) == 2 {
-		if hand[0].Suit == hand[1].Suit {
-			score += 2
-		}
-	}
-
-	// 4. Add a bonus for card connectivity (potential to make a straight).
-	if len(hand) >= 3 {
-		ranks := []poker.Rank{hand[0].Rank, hand[1].Rank, hand[2].Rank}
-		// Sort ranks in descending order for consistent gap calculation.
-		sort.Sort(byRank(ranks))
-
-		// Check for connectors.
-		if ranks[0] == ranks[1]+1 && ranks[1] == ranks[2]+1 { // 3-card straight
-			score += 5
-		} else if (ranks[0] == ranks[1]+1) || (ranks[1] == ranks[2]+1) { // 2-card connector
-			score += 2
-		}
-
-		// Bonus for cards being high and close together.
-		if ranks[0] >= poker.Ten && (ranks[0]-ranks[2] < 5) {
-			score += 1
-		}
-	} else if len(hand) == 2 {
-		ranks := []poker.Rank{hand[0].Rank, hand[1].Rank}
-		sort.Sort(byRank(ranks))
-		if ranks[0] == ranks[1]+1 { // connectors
-			score += 2
-		}
-		if ranks[0] >= poker.Ten && (int(ranks[0])-int(ranks[1]) < 5) {
-			score += 1
-		}
-	}
-
-	return score
+	// Pre-Flop: Evaluate potential based on hole cards using a custom heuristic.
+	return preflopScore(player.Hand, g.Rules)
 }
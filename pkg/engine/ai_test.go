@@ -2,6 +2,7 @@ package engine
 
 import (
 	"math/rand"
+	"pls7-cli/internal/config"
 	"pls7-cli/pkg/poker"
 	"testing"
 )
@@ -38,8 +39,7 @@ func TestEvaluateHandStrength(t *testing.T) {
 }
 
 func TestCPUActionProfileBased(t *testing.T) {
-	lagProfile := aiProfiles["Loose-Aggressive"]
-	tpProfile := aiProfiles["Tight-Passive"]
+	tpProfile := defaultAIConfig.Profiles["Tight-Passive"]
 
 	testCases := []struct {
 		name           string
@@ -50,25 +50,25 @@ func TestCPUActionProfileBased(t *testing.T) {
 		canCheck       bool
 		expectedAction ActionType
 	}{
-		{name: "LAG AI - Bluffs with weak hand", seed: 2, profile: &lagProfile, phase: PhaseFlop, handStrength: float64(poker.HighCard), canCheck: true, expectedAction: ActionBet},
-		{name: "LAG AI - No Bluff on high random", seed: 12345, profile: &lagProfile, phase: PhaseFlop, handStrength: float64(poker.HighCard), canCheck: true, expectedAction: ActionCheck},
-		{name: "TP AI - No Bluff even with low random", seed: 1, profile: &tpProfile, phase: PhaseFlop, handStrength: float64(poker.HighCard), canCheck: true, expectedAction: ActionCheck},
 		{name: "Pre-Flop - Folds below threshold", seed: 1, profile: &tpProfile, phase: PhasePreFlop, handStrength: 21, canCheck: false, expectedAction: ActionFold},
 		{name: "Pre-Flop - Raises above threshold", seed: 1, profile: &tpProfile, phase: PhasePreFlop, handStrength: 29, canCheck: false, expectedAction: ActionRaise},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			player := &Player{Profile: tc.profile, Chips: 1000}
 			g := &Game{
-				Phase:     tc.phase,
-				Pot:       100,
-				BetToCall: 0,
-				Rules:     &poker.GameRules{LowHand: poker.LowHandRules{Enabled: false}},
+				Players:           []*Player{player},
+				Phase:             tc.phase,
+				Pot:               100,
+				BetToCall:         0,
+				BigBlind:          10,
+				Rules:             &poker.GameRules{LowHand: poker.LowHandRules{Enabled: false}},
+				BettingCalculator: &NoLimitCalculator{},
 			}
 			if !tc.canCheck {
 				g.BetToCall = 10
 			}
-			player := &Player{Profile: tc.profile}
 
 			g.handEvaluator = func(g *Game, p *Player) float64 { return tc.handStrength }
 
@@ -81,3 +81,199 @@ func TestCPUActionProfileBased(t *testing.T) {
 		})
 	}
 }
+
+func TestCPUPreFlopRaiseWithinLimits(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rule           string
+		chips          int
+		expectedAmount int
+	}{
+		// Twice the minimum raise is 400, but the pot after calling is only 250.
+		{name: "Pot-Limit - Clamped to the pot", rule: "PLS", chips: 10000, expectedAmount: 350},
+		{name: "No-Limit - Clamped to the stack", rule: "NLH", chips: 250, expectedAmount: 250},
+		{name: "No-Limit - Twice the minimum raise", rule: "NLH", chips: 10000, expectedAmount: 400},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100, tc.rule)
+			g.StartNewHand()
+			g.handEvaluator = func(*Game, *Player) float64 { return 100 }
+			player := g.Players[g.CurrentTurnPos]
+			profile := defaultAIConfig.Profiles["Tight-Aggressive"]
+			player.Profile = &profile
+			player.Chips = tc.chips
+
+			action := g.GetCPUAction(player, rand.New(rand.NewSource(1)))
+			if action.Type != ActionRaise || action.Amount != tc.expectedAmount {
+				t.Errorf("Expected a raise to %d, got %v to %d", tc.expectedAmount, action.Type, action.Amount)
+			}
+			if err := g.LegalActions(player).Validate(action); err != nil {
+				t.Errorf("Expected a legal raise, got %v", err)
+			}
+		})
+	}
+}
+
+// newPostFlopGame sets up a heads-up game on the flop with 1000 in the pot, where
+// CPU1 is to act facing the given bet and has the given equity.
+func newPostFlopGame(betToCall int, equity float64) (*Game, *Player) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.Phase = PhaseFlop
+	for _, p := range g.Players {
+		p.CurrentBet = 0
+	}
+	g.Players[0].CurrentBet = betToCall
+	g.Pot = 1000 + betToCall
+	g.BetToCall = betToCall
+	g.LastRaiseAmount = betToCall
+	g.CurrentTurnPos = 1
+	g.equityEstimator = func(*Game, *Player, *rand.Rand) float64 { return equity }
+	return g, g.Players[1]
+}
+
+func TestCPUPostFlopAction(t *testing.T) {
+	testCases := []struct {
+		name           string
+		betToCall      int
+		equity         float64
+		aggression     float64
+		bluffing       float64
+		expectedAction ActionType
+	}{
+		{name: "Bets a strong hand for value", equity: 0.9, aggression: 1, expectedAction: ActionBet},
+		{name: "Slow-plays a strong hand", equity: 0.9, aggression: 0, expectedAction: ActionCheck},
+		{name: "Bluffs a weak hand", equity: 0.1, bluffing: 1, expectedAction: ActionBet},
+		{name: "Checks a weak hand", equity: 0.1, expectedAction: ActionCheck},
+		{name: "Raises a strong hand", betToCall: 500, equity: 0.9, aggression: 1, expectedAction: ActionRaise},
+		{name: "Calls with equity above the pot odds", betToCall: 500, equity: 0.3, expectedAction: ActionCall},
+		{name: "Folds with equity below the pot odds", betToCall: 500, equity: 0.2, expectedAction: ActionFold},
+		{name: "Bluff-raises a hand it would fold", betToCall: 500, equity: 0.2, bluffing: 1, expectedAction: ActionRaise},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, player := newPostFlopGame(tc.betToCall, tc.equity)
+			player.Profile = &AIProfile{
+				AggressionFactor:   tc.aggression,
+				BluffingFrequency:  tc.bluffing,
+				MinRaiseMultiplier: 2.0,
+				MaxRaiseMultiplier: 3.0,
+			}
+
+			action := g.GetCPUAction(player, rand.New(rand.NewSource(1)))
+			if action.Type != tc.expectedAction {
+				t.Fatalf("Expected action %v, but got %v", tc.expectedAction, action.Type)
+			}
+			if err := g.ValidateAction(player, action); err != nil {
+				t.Errorf("Expected a legal action, got %+v: %v", action, err)
+			}
+		})
+	}
+}
+
+func TestBetSize_UsesProfileMultipliers(t *testing.T) {
+	g, player := newPostFlopGame(0, 0.9)
+	player.Profile = &AIProfile{MinRaiseMultiplier: 2.0, MaxRaiseMultiplier: 3.0}
+	legal := g.LegalActions(player)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		// Multipliers of 2 to 3 bet half to three quarters of the 1000 pot.
+		if amount := g.betSize(player, legal, r); amount < 500 || amount > 750 {
+			t.Fatalf("Expected a bet between 500 and 750, got %d", amount)
+		}
+	}
+
+	// Bets never exceed the pot limit.
+	player.Profile = &AIProfile{MinRaiseMultiplier: 8.0, MaxRaiseMultiplier: 8.0}
+	if amount := g.betSize(player, legal, r); amount != legal.MaxAmount {
+		t.Errorf("Expected the bet to be capped at %d, got %d", legal.MaxAmount, amount)
+	}
+}
+
+func TestPotShare(t *testing.T) {
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls7.yml")
+	if err != nil {
+		t.Fatalf("Failed to load game rules: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		hole     string
+		opponent string
+		board    string
+		expected float64
+	}{
+		{name: "Scoops with the best high and low", hole: "Ah 2h 3c", opponent: "Tc Jd 8s", board: "4h 5h 9h Kd Qs", expected: 1},
+		{name: "Wins the low half only", hole: "Ah 2c 3d", opponent: "Ks Qd Jd", board: "4s 6h Kd Kc Qs", expected: 0.5},
+		{name: "Wins the whole pot when nobody has a low", hole: "Kh Kd 3c", opponent: "As Ad 2c", board: "Ks Qh Jd 9c 8s", expected: 1},
+		{name: "Loses when nobody has a low", hole: "As Ad 2c", opponent: "Kh Kd 3c", board: "Ks Qh Jd 9c 8s", expected: 0},
+		{name: "Splits a tied high hand", hole: "2c 3d 4h", opponent: "5c 6d 9h", board: "As Ks Qs Js Ts", expected: 0.5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opponents := [][]poker.Card{poker.CardsFromStrings(tc.opponent)}
+			share := potShare(poker.CardsFromStrings(tc.hole), opponents, poker.CardsFromStrings(tc.board), rules)
+			if share != tc.expected {
+				t.Errorf("Expected a pot share of %.2f, got %.2f", tc.expected, share)
+			}
+		})
+	}
+}
+
+func TestEstimateEquity(t *testing.T) {
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls.yml")
+	if err != nil {
+		t.Fatalf("Failed to load game rules: %v", err)
+	}
+	names := []string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"}
+	g := NewGame(names, 10000, 50, 100, DifficultyMedium, rules, true, false, 0)
+	g.Phase = PhaseFlop
+	g.CommunityCards = poker.CardsFromStrings("Ac Kd 2h")
+	r := rand.New(rand.NewSource(1))
+
+	player := g.Players[0]
+	player.Hand = poker.CardsFromStrings("As Ah Ad")
+	if equity := estimateEquity(g, player, r); equity < 0.9 {
+		t.Errorf("Expected quads to have an equity above 0.9, got %.2f", equity)
+	}
+
+	// A weak pair loses equity as more opponents stay in the hand.
+	player.Hand = poker.CardsFromStrings("2c 7d 9s")
+	multiway := estimateEquity(g, player, r)
+	for _, p := range g.Players[2:] {
+		p.Status = PlayerStatusFolded
+	}
+	headsUp := estimateEquity(g, player, r)
+	if multiway >= headsUp {
+		t.Errorf("Expected less equity against 4 opponents (%.2f) than against 1 (%.2f)", multiway, headsUp)
+	}
+}
+
+func TestEstimateEquity_SameForAnyNumberOfWorkers(t *testing.T) {
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls.yml")
+	if err != nil {
+		t.Fatalf("Failed to load game rules: %v", err)
+	}
+	names := []string{"YOU", "CPU1", "CPU2"}
+	g := NewGame(names, 10000, 50, 100, DifficultyMedium, rules, true, false, 0)
+	g.Phase = PhaseFlop
+	g.CommunityCards = poker.CardsFromStrings("Ac Kd 2h")
+	player := g.Players[0]
+	player.Hand = poker.CardsFromStrings("2c 7d 9s")
+
+	var estimates []float64
+	for _, workers := range []int{1, 2, 4} {
+		g.EquityWorkers = workers
+		estimates = append(estimates, estimateEquity(g, player, rand.New(rand.NewSource(1))))
+	}
+	for i, equity := range estimates[1:] {
+		if equity != estimates[0] {
+			t.Errorf("Expected the same estimate with any number of workers, got %v with 1 and %v with %d", estimates[0], equity, []int{2, 4}[i])
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestStartNewHand_PostsAntes(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.Ante = 25
+	g.StartNewHand()
+
+	sbPos := g.FindNextActivePlayer(g.DealerPos)
+	bbPos := g.FindNextActivePlayer(sbPos)
+	if g.Pot != 4*25+50+100 {
+		t.Errorf("Expected a pot of 4 antes and the blinds (250), got %d", g.Pot)
+	}
+	if g.BetToCall != 100 {
+		t.Errorf("Expected the antes not to count toward the bet to call, got %d", g.BetToCall)
+	}
+	for i, p := range g.Players {
+		blind := map[int]int{sbPos: 50, bbPos: 100}[i]
+		if p.CurrentBet != blind || p.TotalBetInHand != blind+25 || p.Chips != 10000-blind-25 || p.DeadMoney != 0 {
+			t.Errorf("%s: expected a current bet of %d and %d in the hand, got %d and %d (dead money %d)",
+				p.Name, blind, blind+25, p.CurrentBet, p.TotalBetInHand, p.DeadMoney)
+		}
+	}
+}
+
+func TestStartNewHand_PostsBigBlindAnte(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.Ante = 100
+	g.BigBlindAnte = true
+	g.StartNewHand()
+
+	bbPos := g.FindNextActivePlayer(g.FindNextActivePlayer(g.DealerPos))
+	bb := g.Players[bbPos]
+	if g.Pot != 50+100+100 {
+		t.Errorf("Expected a pot of the blinds and one ante (250), got %d", g.Pot)
+	}
+	if bb.CurrentBet != 100 || bb.TotalBetInHand != 200 || bb.DeadMoney != 100 {
+		t.Errorf("Expected the big blind to bet 100 and post 100 of dead money, got a current bet of %d, %d in the hand and %d of dead money",
+			bb.CurrentBet, bb.TotalBetInHand, bb.DeadMoney)
+	}
+	for _, p := range g.Players {
+		if p != bb && p.TotalBetInHand != p.CurrentBet {
+			t.Errorf("Expected only the big blind to post an ante, %s has %d in the hand", p.Name, p.TotalBetInHand)
+		}
+	}
+}
+
+func TestStartNewHand_BlindsComeBeforeBigBlindAnte(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Ante = 100
+	g.BigBlindAnte = true
+	bbPos := g.FindNextActivePlayer(g.FindNextActivePlayer(g.FindNextActivePlayer(g.DealerPos)))
+	g.Players[bbPos].Chips = 150
+	g.StartNewHand()
+
+	bb := g.Players[bbPos]
+	if bb.CurrentBet != 100 || bb.DeadMoney != 50 || bb.Status != PlayerStatusAllIn {
+		t.Errorf("Expected the big blind to post the full blind and 50 of the ante, got a current bet of %d and %d of dead money (%v)",
+			bb.CurrentBet, bb.DeadMoney, bb.Status)
+	}
+}
+
+func TestStartNewHand_AllInForTheAnte(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.Ante = 25
+	g.Players[0].Chips = 10
+	g.StartNewHand()
+
+	you := g.Players[0]
+	if you.Chips != 0 || you.TotalBetInHand != 10 || you.Status != PlayerStatusAllIn {
+		t.Errorf("Expected YOU to be all-in for 10, got %d chips, %d in the hand (%v)", you.Chips, you.TotalBetInHand, you.Status)
+	}
+}
+
+func TestStartNewHand_AnteDoublesWithBlinds(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Ante = 100
+	g.BigBlindAnte = true
+	g.BlindUpInterval = 1
+	g.StartNewHand()
+
+	event := g.StartNewHand()
+	if event == nil || event.Ante != 200 || !event.BigBlindAnte || event.BigBlind != 200 {
+		t.Errorf("Expected the blinds and the big blind ante to double, got %+v", event)
+	}
+}
+
+func TestStructure_SetsAntes(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	s := &Structure{Levels: []Level{
+		{SmallBlind: 50, BigBlind: 100, Ante: 10, Hands: 1},
+		{SmallBlind: 100, BigBlind: 200, Ante: 200, BigBlindAnte: true},
+	}}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Expected the structure to be valid, got %v", err)
+	}
+	g.SetStructure(s, g.LevelStart)
+	if g.Ante != 10 || g.BigBlindAnte {
+		t.Errorf("Expected an ante of 10 at level 1, got %d (big blind ante: %t)", g.Ante, g.BigBlindAnte)
+	}
+
+	g.StartNewHand()
+	event := g.StartNewHand()
+	if event == nil || event.Ante != 200 || !event.BigBlindAnte {
+		t.Errorf("Expected level 2 to announce a big blind ante of 200, got %+v", event)
+	}
+
+	s.Levels[0].BigBlindAnte = true
+	s.Levels[0].Ante = 0
+	if err := s.Validate(); err == nil {
+		t.Error("Expected an error for a big blind ante without an ante")
+	}
+}
+
+func TestDistributePot_AllInForTheAnte(t *testing.T) {
+	// YOU was all-in for 10 of a 25 ante and has the best hand. YOU can win 10
+	// from each player; the rest goes to CPU1, who beats CPU2.
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 50, 100, DifficultyMedium, loadRule(t, "nlh.yml"), true, false, 0)
+	g.CommunityCards = poker.CardsFromStrings("2s 7d 9c Jh Kd")
+	g.Players[0].Hand = poker.CardsFromStrings("Ks Kc")
+	g.Players[0].TotalBetInHand = 10
+	g.Players[0].Status = PlayerStatusAllIn
+	g.Players[1].Hand = poker.CardsFromStrings("Js Jc")
+	g.Players[1].TotalBetInHand = 125
+	g.Players[2].Hand = poker.CardsFromStrings("2c 3c")
+	g.Players[2].TotalBetInHand = 125
+	g.Pot = 260
+
+	g.DistributePot()
+
+	if g.Players[0].Chips != 30 || g.Players[1].Chips != 230 || g.Players[2].Chips != 0 {
+		t.Errorf("Expected YOU to win 30 and CPU1 230, got %d, %d and %d",
+			g.Players[0].Chips, g.Players[1].Chips, g.Players[2].Chips)
+	}
+}
+
+func TestDistributePot_BigBlindAnteGoesToMainPot(t *testing.T) {
+	// CPU2 posted a big blind of 100 and a big blind ante of 100, then folded.
+	// YOU was all-in for 100 and wins the main pot with the ante in it; CPU1's
+	// uncalled 400 goes back to CPU1.
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 50, 100, DifficultyMedium, loadRule(t, "nlh.yml"), true, false, 0)
+	g.CommunityCards = poker.CardsFromStrings("2s 7d 9c Jh Kd")
+	g.Players[0].Hand = poker.CardsFromStrings("Ks Kc")
+	g.Players[0].TotalBetInHand = 100
+	g.Players[0].Status = PlayerStatusAllIn
+	g.Players[1].Hand = poker.CardsFromStrings("Js Jc")
+	g.Players[1].TotalBetInHand = 500
+	g.Players[2].Status = PlayerStatusFolded
+	g.Players[2].TotalBetInHand = 200
+	g.Players[2].DeadMoney = 100
+	g.Pot = 800
+
+	g.DistributePot()
+
+	if g.Players[0].Chips != 400 || g.Players[1].Chips != 400 {
+		t.Errorf("Expected YOU to win 400 and CPU1 to get back 400, got %d and %d", g.Players[0].Chips, g.Players[1].Chips)
+	}
+	if g.Pot != 0 {
+		t.Errorf("Expected the pot to be empty, got %d", g.Pot)
+	}
+}
+
+func TestAnte_SurvivesSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Ante = 100
+	g.BigBlindAnte = true
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if loaded.Ante != 100 || !loaded.BigBlindAnte {
+		t.Errorf("Expected the big blind ante of 100 to be restored, got %d (big blind ante: %t)", loaded.Ante, loaded.BigBlindAnte)
+	}
+}
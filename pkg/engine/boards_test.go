@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"pls7-cli/pkg/poker"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDealCommunityCards_DealsEveryBoard(t *testing.T) {
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 1000, 50, 100, DifficultyMedium, loadRulesForTest(t, "plo8-db"), false, false, 0)
+	g.StartNewHand()
+
+	g.dealCommunityCards(3) // Flop
+	g.dealCommunityCards(1) // Turn
+	g.dealCommunityCards(1) // River
+
+	if len(g.Boards) != 2 || len(g.Boards[0]) != 5 || len(g.Boards[1]) != 5 {
+		t.Fatalf("Expected two full boards, got %v", g.Boards)
+	}
+	if !reflect.DeepEqual(g.CommunityCards, g.Boards[0]) {
+		t.Errorf("Expected the community cards to be the first board, got %v and %v", g.CommunityCards, g.Boards[0])
+	}
+	seen := make(map[poker.Card]bool)
+	for _, c := range append(append([]poker.Card{}, g.Boards[0]...), g.Boards[1]...) {
+		if seen[c] {
+			t.Errorf("Expected every card to be dealt once, got %v twice", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestDistributePot_QuartersHiLoPotOnTwoBoards(t *testing.T) {
+	// On the first board, YOU makes three kings and CPU1 the only low, so they
+	// share its half. Nobody makes a low on the second board, where YOU's full
+	// house takes the other half.
+	g := NewGame([]string{"YOU", "CPU1"}, 0, 50, 100, DifficultyMedium, loadRulesForTest(t, "plo8-db"), true, false, 0)
+	g.Boards = [][]poker.Card{
+		poker.CardsFromStrings("Ks Kd 7c 2h 3d"),
+		poker.CardsFromStrings("Qs Qd Jc Td 9h"),
+	}
+	g.CommunityCards = g.Boards[0]
+	hands := []string{"Kc Qh Jh Ts", "Ah 4c 9s 9d"}
+	for i, p := range g.Players {
+		p.Hand = poker.CardsFromStrings(hands[i])
+		p.TotalBetInHand = 200
+		g.Pot += 200
+	}
+
+	results := g.DistributePot()
+
+	if g.Players[0].Chips != 300 || g.Players[1].Chips != 100 {
+		t.Errorf("Expected YOU to win 300 and CPU1 100, got %d and %d", g.Players[0].Chips, g.Players[1].Chips)
+	}
+	for _, result := range results {
+		if result.PlayerName == "CPU1" && !strings.HasPrefix(result.HandDesc, "Board 1: ") {
+			t.Errorf("Expected CPU1 to win on the first board, got %q", result.HandDesc)
+		}
+	}
+}
+
+func TestPlayHand_DoubleBoard(t *testing.T) {
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 1000, 50, 100, DifficultyMedium, loadRulesForTest(t, "pls7-db"), false, false, 0)
+	g.RunItTimes = 2
+	provider := &allInProvider{agree: true}
+
+	end := g.PlayHand(provider, nil)
+
+	if len(provider.asked) != 0 {
+		t.Errorf("Expected nobody to be asked to run it twice on two boards, got %v", provider.asked)
+	}
+	if len(end.Boards) != 2 || len(end.Boards[0]) != 5 || len(end.Boards[1]) != 5 {
+		t.Fatalf("Expected two full boards, got %v", end.Boards)
+	}
+	total := 0
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	if total != g.TotalInitialChips || g.Pot != 0 {
+		t.Errorf("Expected every chip to be awarded, got %d of %d", total, g.TotalInitialChips)
+	}
+}
+
+func TestBoards_InvalidSaveRejected(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+
+	saveData := g.ToSaveData()
+	saveData.GameRules.Boards = poker.MaxBoards + 1
+	if _, err := FromSaveData(saveData); err == nil {
+		t.Error("Expected an error for too many boards")
+	}
+}
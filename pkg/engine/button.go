@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ButtonPolicy decides how the dealer button and the blinds move on when
+// players are eliminated.
+type ButtonPolicy int
+
+// ButtonPolicy constants.
+const (
+	// ButtonMoving moves the button to the next player in the game, and the
+	// blinds follow it. A player the big blind skips over because of an
+	// elimination owes it, and posts it as dead money in their next hand.
+	ButtonMoving ButtonPolicy = iota
+	// ButtonDead moves the big blind on to the next player in the game every
+	// hand, so nobody skips or pays it twice. The small blind and the button
+	// follow it to the seats before it, even if those players have been
+	// eliminated: the small blind is then dead, and the button is left on an
+	// empty seat.
+	ButtonDead
+)
+
+// String returns the human-readable name of the button policy.
+func (b ButtonPolicy) String() string {
+	switch b {
+	case ButtonMoving:
+		return "Moving"
+	case ButtonDead:
+		return "Dead"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseButtonPolicy parses the name of a button policy: moving or dead.
+func ParseButtonPolicy(s string) (ButtonPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "moving":
+		return ButtonMoving, nil
+	case "dead":
+		return ButtonDead, nil
+	default:
+		return ButtonMoving, fmt.Errorf("unknown button policy %q (moving or dead)", s)
+	}
+}
+
+// moveButton moves the dealer button and the blinds on for a new hand,
+// following the ButtonPolicy. With three or more players, the button is
+// followed by the small and the big blind. Heads-up, the dealer posts the
+// small blind, acting first pre-flop and last after the flop. The big blind
+// moves on as usual, so when a table goes heads-up nobody posts the big
+// blind twice in a row.
+//
+// It returns the players a moving button made the big blind skip.
+func (g *Game) moveButton() (skipped []*Player) {
+	switch {
+	case g.CountRemainingPlayers() == 2:
+		if g.BigBlindPos < 0 {
+			g.BigBlindPos = g.FindNextActivePlayer(g.FindNextActivePlayer(g.DealerPos))
+		} else {
+			g.BigBlindPos = g.FindNextActivePlayer(g.BigBlindPos)
+		}
+		g.DealerPos = g.FindNextActivePlayer(g.BigBlindPos)
+		g.SmallBlindPos = g.DealerPos
+	case g.ButtonPolicy == ButtonDead && g.BigBlindPos >= 0:
+		// The last hand's small blind seat gets the button, and its big
+		// blind seat the small blind.
+		g.DealerPos = g.SmallBlindPos
+		g.SmallBlindPos = g.BigBlindPos
+		g.BigBlindPos = g.FindNextActivePlayer(g.BigBlindPos)
+	default:
+		fairBigBlindPos := -1
+		if g.BigBlindPos >= 0 {
+			fairBigBlindPos = g.FindNextActivePlayer(g.BigBlindPos)
+		}
+		g.DealerPos = g.FindNextActivePlayer(g.DealerPos)
+		g.SmallBlindPos = g.FindNextActivePlayer(g.DealerPos)
+		g.BigBlindPos = g.FindNextActivePlayer(g.SmallBlindPos)
+		for pos := fairBigBlindPos; pos >= 0 && pos != g.BigBlindPos; pos = g.FindNextActivePlayer(pos) {
+			skipped = append(skipped, g.Players[pos])
+		}
+	}
+	return skipped
+}
+
+// postMissedBlinds makes the players who owe blinds from earlier hands post
+// them. Missed blinds are dead money: they go into the main pot without
+// counting as a bet.
+func (g *Game) postMissedBlinds() {
+	for _, p := range g.Players {
+		if !p.IsActive() || p.MissedBlinds == 0 {
+			continue
+		}
+		posted := g.postAnte(p, p.MissedBlinds)
+		p.DeadMoney += posted
+		p.LastActionDesc = fmt.Sprintf("Missed blind %d", posted)
+		p.MissedBlinds = 0
+	}
+}
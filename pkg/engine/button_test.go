@@ -0,0 +1,162 @@
+package engine
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseButtonPolicy(t *testing.T) {
+	for s, expected := range map[string]ButtonPolicy{"moving": ButtonMoving, "Dead": ButtonDead} {
+		if policy, err := ParseButtonPolicy(s); err != nil || policy != expected {
+			t.Errorf("ParseButtonPolicy(%q) = %v, %v; expected %v", s, policy, err, expected)
+		}
+	}
+	if _, err := ParseButtonPolicy("floating"); err == nil {
+		t.Error("Expected an error for an unknown button policy")
+	}
+}
+
+func TestMoveButton_DeadButton(t *testing.T) {
+	testCases := []struct {
+		name       string
+		eliminated int // Seat eliminated in a hand where seat 0 has the button.
+		smallBlind int // Small blind posted in the next hand, 0 if it is dead.
+		firstToAct int // First player to act after the flop in the next hand.
+	}{
+		{name: "Small blind eliminated", eliminated: 1, smallBlind: 50, firstToAct: 2},
+		{name: "Big blind eliminated", eliminated: 2, smallBlind: 0, firstToAct: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"}, 10000, 50, 100)
+			g.ButtonPolicy = ButtonDead
+			g.StartNewHand() // YOU has the button, CPU1 and CPU2 post the blinds.
+			g.Players[tc.eliminated].Status = PlayerStatusEliminated
+
+			g.StartNewHand()
+			if g.DealerPos != 1 || g.SmallBlindPos != 2 || g.BigBlindPos != 3 {
+				t.Fatalf("Expected the button on seat 1, the small blind on seat 2 and the big blind on seat 3, got %d, %d and %d",
+					g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+			}
+			if sb := g.Players[2]; sb.Status != PlayerStatusEliminated && sb.CurrentBet != tc.smallBlind {
+				t.Errorf("Expected a small blind of %d, got %d", tc.smallBlind, sb.CurrentBet)
+			}
+			if g.Pot != tc.smallBlind+100 {
+				t.Errorf("Expected a pot of %d, got %d", tc.smallBlind+100, g.Pot)
+			}
+			g.Phase = PhaseFlop
+			g.PrepareNewBettingRound()
+			if g.CurrentTurnPos != tc.firstToAct {
+				t.Errorf("Expected seat %d to act first after the flop, got %d", tc.firstToAct, g.CurrentTurnPos)
+			}
+		})
+	}
+}
+
+func TestMoveButton_DeadButtonNobodySkipsTheBigBlind(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}, 10000, 50, 100)
+	g.ButtonPolicy = ButtonDead
+	eliminations := map[int]int{2: 4, 3: 2, 5: 0} // Hand number: seat eliminated after it.
+	var bigBlinds []int
+	for hand := 1; hand <= 8; hand++ {
+		g.StartNewHand()
+		bigBlinds = append(bigBlinds, g.BigBlindPos)
+		if seat, ok := eliminations[hand]; ok {
+			g.Players[seat].Status = PlayerStatusEliminated
+		}
+	}
+	// Seats 4, 2 and 0 drop out; the big blind never skips a remaining player.
+	expected := []int{2, 3, 5, 0, 1, 3, 5, 1}
+	for i := range expected {
+		if bigBlinds[i] != expected[i] {
+			t.Fatalf("Expected the big blinds %v, got %v", expected, bigBlinds)
+		}
+	}
+	for _, p := range g.Players {
+		if p.MissedBlinds != 0 {
+			t.Errorf("Expected nobody to miss a blind with a dead button, %s owes %d", p.Name, p.MissedBlinds)
+		}
+	}
+}
+
+func TestMoveButton_MovingButtonTracksMissedBlinds(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"}, 10000, 50, 100)
+	g.StartNewHand() // YOU has the button, CPU1 and CPU2 post the blinds.
+	g.Players[1].Status = PlayerStatusEliminated
+
+	// The button moves to CPU2, so CPU3 posts the small blind instead of the
+	// big blind it was due.
+	g.StartNewHand()
+	if g.DealerPos != 2 || g.SmallBlindPos != 3 || g.BigBlindPos != 4 {
+		t.Fatalf("Expected the button on seat 2 and the blinds on seats 3 and 4, got %d, %d and %d", g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+	}
+	if owed := g.Players[3].MissedBlinds; owed != 100 {
+		t.Fatalf("Expected CPU3 to owe the big blind of 100, got %d", owed)
+	}
+
+	g.StartNewHand()
+	cpu3 := g.Players[3]
+	if cpu3.MissedBlinds != 0 || cpu3.DeadMoney != 100 || cpu3.TotalBetInHand != 100 || cpu3.CurrentBet != 0 {
+		t.Errorf("Expected CPU3 to post the missed big blind as dead money, got %d in the hand (%d dead, %d bet) and %d still owed",
+			cpu3.TotalBetInHand, cpu3.DeadMoney, cpu3.CurrentBet, cpu3.MissedBlinds)
+	}
+	if g.Pot != 250 {
+		t.Errorf("Expected a pot of the blinds and the missed blind (250), got %d", g.Pot)
+	}
+}
+
+func TestButton_SurvivesSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.ButtonPolicy = ButtonDead
+	g.StartNewHand()
+	g.Players[1].Status = PlayerStatusEliminated
+	g.Players[3].MissedBlinds = 100
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if loaded.ButtonPolicy != ButtonDead || loaded.Players[3].MissedBlinds != 100 {
+		t.Errorf("Expected the dead button and the missed blinds to be restored, got %v and %d", loaded.ButtonPolicy, loaded.Players[3].MissedBlinds)
+	}
+	loaded.StartNewHand()
+	if loaded.DealerPos != 1 || loaded.BigBlindPos != 3 {
+		t.Errorf("Expected the dead button on seat 1 and the big blind on seat 3, got %d and %d", loaded.DealerPos, loaded.BigBlindPos)
+	}
+
+	saveData := g.ToSaveData()
+	outOfRange := 4
+	saveData.GameMetadata.BigBlindPos = &outOfRange
+	if _, err := FromSaveData(saveData); err == nil {
+		t.Error("Expected an error for a big blind position out of range")
+	}
+}
+
+func TestButton_LoadsSaveWithoutBlindPositions(t *testing.T) {
+	// The save is from before the blinds' seats were saved: YOU had the button.
+	data, err := os.ReadFile("testdata/save_without_blind_positions.json")
+	if err != nil {
+		t.Fatalf("Failed to read the save: %v", err)
+	}
+	saveData, err := LoadFromJSON(data)
+	if err != nil {
+		t.Fatalf("LoadFromJSON returned unexpected error: %v", err)
+	}
+	g, err := FromSaveData(saveData)
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+
+	g.StartNewHand()
+	if g.DealerPos != 1 || g.SmallBlindPos != 2 || g.BigBlindPos != 3 {
+		t.Errorf("Expected the button on seat 1 and the blinds on seats 2 and 3, got %d, %d and %d", g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+	}
+	for _, p := range g.Players {
+		if p.MissedBlinds != 0 || p.DeadMoney != 0 {
+			t.Errorf("Expected %s to owe no blinds, got %d owed and %d posted", p.Name, p.MissedBlinds, p.DeadMoney)
+		}
+	}
+	if g.Pot != 150 {
+		t.Errorf("Expected a pot of the blinds (150), got %d", g.Pot)
+	}
+}
@@ -0,0 +1,203 @@
+package engine
+
+import "fmt"
+
+// CashRules are the buy-in limits of a cash game. In a cash game the blinds
+// never go up, players may rebuy or top up their stacks between hands and sit
+// out, and busted CPU players are replaced by new ones.
+type CashRules struct {
+	// BuyIn is the number of chips new players buy in for.
+	BuyIn int `json:"buy_in"`
+	// MinBuyIn is the fewest chips a player may buy in for after busting.
+	MinBuyIn int `json:"min_buy_in"`
+	// MaxBuyIn is the most chips a player may have after buying more.
+	MaxBuyIn int `json:"max_buy_in"`
+}
+
+// Validate checks that the buy-in lies within the buy-in limits.
+func (c CashRules) Validate() error {
+	if c.MinBuyIn <= 0 {
+		return fmt.Errorf("the minimum buy-in must be positive, got %d", c.MinBuyIn)
+	}
+	if c.MinBuyIn > c.MaxBuyIn {
+		return fmt.Errorf("the minimum buy-in (%d) is above the maximum (%d)", c.MinBuyIn, c.MaxBuyIn)
+	}
+	if c.BuyIn < c.MinBuyIn || c.BuyIn > c.MaxBuyIn {
+		return fmt.Errorf("the buy-in (%d) must be between %d and %d", c.BuyIn, c.MinBuyIn, c.MaxBuyIn)
+	}
+	return nil
+}
+
+// SessionResult is a player's profit or loss over a cash game session.
+type SessionResult struct {
+	// Name is the player's name.
+	Name string `json:"name"`
+	// BoughtIn is the total number of chips the player bought.
+	BoughtIn int `json:"bought_in"`
+	// Chips is the player's stack, or 0 for a player who busted and left.
+	Chips int `json:"chips"`
+}
+
+// Profit returns the chips the player won, or lost if it is negative.
+func (r SessionResult) Profit() int {
+	return r.Chips - r.BoughtIn
+}
+
+// SetCash makes the game a cash game with the given buy-in limits. The blinds
+// no longer go up, and every player is counted as having bought in for their
+// current stack. Cash games use a dead button, so players who sit out and
+// come back never make the big blind skip anyone.
+func (g *Game) SetCash(rules CashRules) error {
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	g.Cash = &rules
+	g.BlindUpInterval = 0
+	g.Structure = nil
+	g.ButtonPolicy = ButtonDead
+	for _, p := range g.Players {
+		p.BoughtIn = p.Chips
+	}
+	return nil
+}
+
+// BuyIn adds chips to the stack of the player at the given seat between hands
+// of a cash game. A player who busted rebuys for at least MinBuyIn and is
+// dealt in again; a player with chips tops up. Either way the stack may not
+// grow beyond MaxBuyIn.
+func (g *Game) BuyIn(pos, amount int) error {
+	p, err := g.cashSeat(pos)
+	if err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return fmt.Errorf("the amount must be positive, got %d", amount)
+	}
+	if p.Chips == 0 && amount < g.Cash.MinBuyIn {
+		return fmt.Errorf("a rebuy must be at least %d, got %d", g.Cash.MinBuyIn, amount)
+	}
+	if p.Chips+amount > g.Cash.MaxBuyIn {
+		return fmt.Errorf("%s may buy at most %d more chips", p.Name, max(g.Cash.MaxBuyIn-p.Chips, 0))
+	}
+	p.Chips += amount
+	p.BoughtIn += amount
+	g.TotalInitialChips += amount
+	if p.Status == PlayerStatusEliminated {
+		p.Status = PlayerStatusPlaying
+	}
+	return nil
+}
+
+// SitOut lets the player at the given seat keep their seat in a cash game
+// without being dealt in. At least two players must stay in.
+func (g *Game) SitOut(pos int) error {
+	p, err := g.cashSeat(pos)
+	if err != nil {
+		return err
+	}
+	if !p.IsActive() {
+		return fmt.Errorf("%s is not dealt in", p.Name)
+	}
+	if g.CountRemainingPlayers() <= 2 {
+		return fmt.Errorf("at least two players must stay in")
+	}
+	p.Status = PlayerStatusSittingOut
+	p.Hand = nil
+	p.CurrentBet = 0
+	p.TotalBetInHand = 0
+	p.DeadMoney = 0
+	p.LastActionDesc = ""
+	return nil
+}
+
+// SitIn deals the player at the given seat in again from the next hand. They
+// post the blinds they missed while they were sitting out.
+func (g *Game) SitIn(pos int) error {
+	p, err := g.cashSeat(pos)
+	if err != nil {
+		return err
+	}
+	if p.Status != PlayerStatusSittingOut {
+		return fmt.Errorf("%s is not sitting out", p.Name)
+	}
+	p.Status = PlayerStatusPlaying
+	return nil
+}
+
+// SessionResults returns the results of the players at the table, followed by
+// those of the players who busted and left.
+func (g *Game) SessionResults() []SessionResult {
+	results := make([]SessionResult, 0, len(g.Players)+len(g.Departed))
+	for _, p := range g.Players {
+		results = append(results, SessionResult{Name: p.Name, BoughtIn: p.BoughtIn, Chips: p.Chips})
+	}
+	return append(results, g.Departed...)
+}
+
+// cashSeat returns the player at the given seat of a cash game.
+func (g *Game) cashSeat(pos int) (*Player, error) {
+	if g.Cash == nil {
+		return nil, fmt.Errorf("this is not a cash game")
+	}
+	if pos < 0 || pos >= len(g.Players) {
+		return nil, fmt.Errorf("seat %d is out of range (0-%d)", pos, len(g.Players)-1)
+	}
+	return g.Players[pos], nil
+}
+
+// chargeMissedBlinds makes the players the big blind passed while they were
+// sitting out or busted owe both blinds. They post them once when they are
+// dealt in again, however long they were away.
+func (g *Game) chargeMissedBlinds(previousBigBlindPos int) {
+	if g.Cash == nil || previousBigBlindPos < 0 {
+		return
+	}
+	owed := g.SmallBlind + g.BigBlind
+	for pos := (previousBigBlindPos + 1) % len(g.Players); pos != g.BigBlindPos; pos = (pos + 1) % len(g.Players) {
+		if p := g.Players[pos]; !p.IsActive() && p.MissedBlinds < owed {
+			p.MissedBlinds = owed
+		}
+	}
+}
+
+// refillSeats replaces the busted CPU players of a cash game with new CPU
+// players, who play the seat's AI profile and buy in for BuyIn. It returns
+// messages announcing them.
+func (g *Game) refillSeats() []string {
+	var events []string
+	for i, p := range g.Players {
+		if !p.IsCPU || p.Status != PlayerStatusEliminated {
+			continue
+		}
+		g.Departed = append(g.Departed, SessionResult{Name: p.Name, BoughtIn: p.BoughtIn})
+		newcomer := &Player{
+			Name:     g.newCPUName(),
+			Chips:    g.Cash.BuyIn,
+			BoughtIn: g.Cash.BuyIn,
+			IsCPU:    true,
+			Profile:  p.Profile,
+			Position: i,
+			TimeBank: g.TimeControl.TimeBank,
+		}
+		g.Players[i] = newcomer
+		g.TotalInitialChips += newcomer.Chips
+		events = append(events, fmt.Sprintf("%s takes %s's seat with %d chips.", newcomer.Name, p.Name, newcomer.Chips))
+	}
+	return events
+}
+
+// newCPUName returns the first "CPU n" name no player of the session has had.
+func (g *Game) newCPUName() string {
+	taken := make(map[string]bool)
+	for _, p := range g.Players {
+		taken[p.Name] = true
+	}
+	for _, r := range g.Departed {
+		taken[r.Name] = true
+	}
+	for n := 1; ; n++ {
+		if name := fmt.Sprintf("CPU %d", n); !taken[name] {
+			return name
+		}
+	}
+}
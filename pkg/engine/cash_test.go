@@ -0,0 +1,296 @@
+package engine
+
+import (
+	"pls7-cli/pkg/poker"
+	"reflect"
+	"testing"
+)
+
+func newCashGameForTests(t *testing.T, names []string) *Game {
+	t.Helper()
+	g := newGameForBettingTests(names, 10000, 50, 100)
+	g.BlindUpInterval = 1
+	if err := g.SetCash(CashRules{BuyIn: 10000, MinBuyIn: 5000, MaxBuyIn: 20000}); err != nil {
+		t.Fatalf("SetCash returned unexpected error: %v", err)
+	}
+	return g
+}
+
+func TestCashRules_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rules CashRules
+		valid bool
+	}{
+		{name: "Valid", rules: CashRules{BuyIn: 10000, MinBuyIn: 5000, MaxBuyIn: 20000}, valid: true},
+		{name: "No minimum", rules: CashRules{BuyIn: 10000, MaxBuyIn: 20000}},
+		{name: "Minimum above maximum", rules: CashRules{BuyIn: 10000, MinBuyIn: 20000, MaxBuyIn: 10000}},
+		{name: "Buy-in above maximum", rules: CashRules{BuyIn: 30000, MinBuyIn: 5000, MaxBuyIn: 20000}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.rules.Validate(); (err == nil) != tc.valid {
+				t.Errorf("Validate() = %v, expected valid: %t", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestCash_BlindsStayFixed(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2"})
+	for i := 0; i < 3; i++ {
+		if event := g.StartNewHand(); event != nil {
+			t.Errorf("Expected no blind increase in a cash game, got %+v", event)
+		}
+	}
+	if g.SmallBlind != 50 || g.BigBlind != 100 {
+		t.Errorf("Expected the blinds to stay at 50/100, got %d/%d", g.SmallBlind, g.BigBlind)
+	}
+}
+
+func TestCash_BuyIn(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2"})
+	you := g.Players[0]
+
+	if err := g.BuyIn(0, 10001); err == nil {
+		t.Error("Expected an error for a top-up beyond the maximum buy-in")
+	}
+	if err := g.BuyIn(0, 10000); err != nil || you.Chips != 20000 || you.BoughtIn != 20000 {
+		t.Errorf("Expected a top-up to 20000, got %v with %d chips bought for %d", err, you.Chips, you.BoughtIn)
+	}
+
+	you.Chips = 0
+	you.Status = PlayerStatusEliminated
+	if err := g.BuyIn(0, 4000); err == nil {
+		t.Error("Expected an error for a rebuy below the minimum buy-in")
+	}
+	if err := g.BuyIn(0, 5000); err != nil || you.Status != PlayerStatusPlaying {
+		t.Errorf("Expected the rebuy to deal YOU in again, got %v (%v)", err, you.Status)
+	}
+	if g.TotalInitialChips != 30000+10000+5000 {
+		t.Errorf("Expected the chips bought to count toward the total, got %d", g.TotalInitialChips)
+	}
+
+	tournament := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	if err := tournament.BuyIn(0, 1000); err == nil {
+		t.Error("Expected an error for a buy-in outside a cash game")
+	}
+}
+
+func TestCash_BustedCPUsAreReplaced(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU 1", "CPU 2"})
+	profile := &AIProfile{Name: "Tight-Aggressive"}
+	g.Players[1].Profile = profile
+	g.Players[1].Chips = 0
+	g.Players[2].Chips = 20000
+	g.Players[0].Chips = 0
+
+	g.CleanupHand()
+
+	newcomer := g.Players[1]
+	if newcomer.Name != "CPU 3" || newcomer.Chips != 10000 || newcomer.Status != PlayerStatusPlaying || newcomer.Profile != profile {
+		t.Errorf("Expected CPU 3 to take the seat with 10000 chips and the seat's profile, got %v", newcomer)
+	}
+	if g.Players[0].Status != PlayerStatusEliminated {
+		t.Errorf("Expected the busted human to wait for a rebuy, got %v", g.Players[0].Status)
+	}
+	if len(g.Eliminations) != 0 {
+		t.Errorf("Expected no finishing places in a cash game, got %+v", g.Eliminations)
+	}
+
+	expected := []SessionResult{
+		{Name: "YOU", BoughtIn: 10000},
+		{Name: "CPU 3", BoughtIn: 10000, Chips: 10000},
+		{Name: "CPU 2", BoughtIn: 10000, Chips: 20000},
+		{Name: "CPU 1", BoughtIn: 10000},
+	}
+	if results := g.SessionResults(); !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected session results %+v, got %+v", expected, results)
+	}
+	if profit := expected[2].Profit(); profit != 10000 {
+		t.Errorf("Expected CPU 2 to be 10000 up, got %d", profit)
+	}
+}
+
+func TestCash_SittingOut(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2", "CPU3"})
+	g.StartNewHand() // YOU has the button, CPU1 and CPU2 post the blinds.
+	if err := g.SitOut(3); err != nil {
+		t.Fatalf("SitOut returned unexpected error: %v", err)
+	}
+
+	// The big blind passes CPU3, who is not dealt in.
+	g.StartNewHand()
+	cpu3 := g.Players[3]
+	if g.BigBlindPos != 0 || len(cpu3.Hand) != 0 || cpu3.Status != PlayerStatusSittingOut {
+		t.Fatalf("Expected YOU to post the big blind and CPU3 to sit out, got the big blind on seat %d and %v with %d cards",
+			g.BigBlindPos, cpu3.Status, len(cpu3.Hand))
+	}
+	g.StartNewHand()
+	if cpu3.MissedBlinds != 150 {
+		t.Errorf("Expected CPU3 to owe both blinds once (150), got %d", cpu3.MissedBlinds)
+	}
+
+	if err := g.SitIn(3); err != nil {
+		t.Fatalf("SitIn returned unexpected error: %v", err)
+	}
+	g.StartNewHand()
+	if cpu3.MissedBlinds != 0 || cpu3.DeadMoney != 150 || len(cpu3.Hand) == 0 {
+		t.Errorf("Expected CPU3 to be dealt in and post the missed blinds, got %d dead and %d still owed", cpu3.DeadMoney, cpu3.MissedBlinds)
+	}
+	// The big blind moves on as usual, and nobody else owes a blind.
+	if g.BigBlindPos != 2 {
+		t.Errorf("Expected the big blind on seat 2, got %d", g.BigBlindPos)
+	}
+	for _, p := range g.Players[:3] {
+		if p.MissedBlinds != 0 || p.DeadMoney != 0 {
+			t.Errorf("Expected %s not to owe a blind, got %d owed and %d dead", p.Name, p.MissedBlinds, p.DeadMoney)
+		}
+	}
+}
+
+func TestCash_SitOutKeepsTwoPlayersIn(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1"})
+	if err := g.SitOut(0); err == nil {
+		t.Error("Expected an error for sitting out heads-up")
+	}
+	if err := g.SitIn(0); err == nil {
+		t.Error("Expected an error for sitting in while dealt in")
+	}
+}
+
+func TestCash_SurvivesSaveAndLoad(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2"})
+	g.Players[0].BoughtIn = 15000
+	g.Departed = []SessionResult{{Name: "CPU 7", BoughtIn: 10000}}
+	if err := g.SitOut(2); err != nil {
+		t.Fatalf("SitOut returned unexpected error: %v", err)
+	}
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.SessionResults(), g.SessionResults()) || *loaded.Cash != *g.Cash {
+		t.Errorf("Expected the cash game to be restored, got %+v and %+v", loaded.Cash, loaded.SessionResults())
+	}
+	if loaded.Players[2].Status != PlayerStatusSittingOut {
+		t.Errorf("Expected CPU2 to still sit out, got %v", loaded.Players[2].Status)
+	}
+
+	saveData := g.ToSaveData()
+	saveData.GameMetadata.Cash.MinBuyIn = 0
+	if _, err := FromSaveData(saveData); err == nil {
+		t.Error("Expected an error for invalid cash game rules")
+	}
+}
+
+// totalChips returns the chips at the table and taken by the house.
+func totalChips(g *Game) int {
+	total := g.Pot + g.HouseChips
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	return total
+}
+
+func TestCash_SittingOutOfBombPot(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2", "CPU3"})
+	if err := g.SitOut(3); err != nil {
+		t.Fatalf("SitOut returned unexpected error: %v", err)
+	}
+	g.NextHandType = HandBombPot
+	provider := &callingProvider{}
+	end := g.PlayHand(provider, nil)
+
+	cpu3 := g.Players[3]
+	if cpu3.Chips != 10000 || len(cpu3.Hand) != 0 || cpu3.TotalBetInHand != 0 {
+		t.Errorf("Expected CPU3 not to ante or be dealt in, got %d chips, %v and %d in the hand", cpu3.Chips, cpu3.Hand, cpu3.TotalBetInHand)
+	}
+	for phase, seats := range provider.seats {
+		for _, seat := range seats {
+			if seat == 3 {
+				t.Errorf("Expected CPU3 not to be asked to act, got asked on %v", phase)
+			}
+		}
+	}
+	won := 0
+	for _, result := range end.Results {
+		won += result.AmountWon
+		if result.PlayerName == cpu3.Name {
+			t.Errorf("Expected CPU3 not to win any of the pot, got %+v", result)
+		}
+	}
+	if won != 300 {
+		t.Errorf("Expected the 3 antes (300) to be awarded, got %d", won)
+	}
+	if total := totalChips(g); total != 40000 {
+		t.Errorf("Expected 40000 chips at the table, got %d", total)
+	}
+}
+
+func TestCash_SittingOutWhileMissedBlindsArePosted(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"})
+	for _, seat := range []int{3, 4} {
+		if err := g.SitOut(seat); err != nil {
+			t.Fatalf("SitOut returned unexpected error: %v", err)
+		}
+	}
+	// The big blind passes CPU3 and CPU4 in the second hand.
+	provider := &callingProvider{}
+	g.PlayHand(provider, nil)
+	g.PlayHand(provider, nil)
+
+	if err := g.SitIn(3); err != nil {
+		t.Fatalf("SitIn returned unexpected error: %v", err)
+	}
+	provider = &callingProvider{}
+	end := g.PlayHand(provider, nil)
+
+	cpu3, cpu4 := g.Players[3], g.Players[4]
+	if cpu3.MissedBlinds != 0 || cpu3.DeadMoney != 150 {
+		t.Errorf("Expected CPU3 to post the missed blinds (150) as dead money, got %d dead and %d still owed", cpu3.DeadMoney, cpu3.MissedBlinds)
+	}
+	if cpu4.Chips != 10000 || cpu4.MissedBlinds != 150 || len(cpu4.Hand) != 0 || cpu4.TotalBetInHand != 0 {
+		t.Errorf("Expected CPU4 to keep 10000 chips and owe 150, got %d chips, %d owed, %v and %d in the hand",
+			cpu4.Chips, cpu4.MissedBlinds, cpu4.Hand, cpu4.TotalBetInHand)
+	}
+	for phase, seats := range provider.seats {
+		for _, seat := range seats {
+			if seat == 4 {
+				t.Errorf("Expected CPU4 not to be asked to act, got asked on %v", phase)
+			}
+		}
+	}
+	for _, result := range end.Results {
+		if result.PlayerName == cpu4.Name {
+			t.Errorf("Expected CPU4 not to win any of the pot, got %+v", result)
+		}
+	}
+	if total := totalChips(g); total != 50000 {
+		t.Errorf("Expected 50000 chips at the table, got %d", total)
+	}
+}
+
+func TestDistributePot_SkipsSittingOutPlayers(t *testing.T) {
+	g := newCashGameForTests(t, []string{"YOU", "CPU1", "CPU2"})
+	g.Players[0].TotalBetInHand = 1000
+	g.Players[0].Hand = poker.CardsFromStrings("Qs Qc 2d")
+	g.Players[1].TotalBetInHand = 1000
+	g.Players[1].Hand = poker.CardsFromStrings("Js Jc 3d")
+	// CPU2 sits out but still holds the cards and bet of an earlier hand.
+	g.Players[2].Status = PlayerStatusSittingOut
+	g.Players[2].TotalBetInHand = 1000
+	g.Players[2].Hand = poker.CardsFromStrings("As Ac Ad")
+	g.CommunityCards = poker.CardsFromStrings("Kh 9h 7c 5d 4s")
+	g.Pot = 2000
+
+	results := g.DistributePot()
+
+	if len(results) != 1 || results[0].PlayerName != "YOU" || results[0].AmountWon != 2000 {
+		t.Fatalf("Expected YOU to win the pot of 2000, got %+v", results)
+	}
+	if g.Players[2].Chips != 10000 || g.Pot != 0 {
+		t.Errorf("Expected CPU2 to keep 10000 chips and the pot to be emptied, got %d and %d", g.Players[2].Chips, g.Pot)
+	}
+}
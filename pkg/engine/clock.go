@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TimeControl limits the time players have to decide, like the shot clock of
+// an online poker room.
+type TimeControl struct {
+	// DecisionTime is the time every decision may take. 0 disables time limits.
+	DecisionTime time.Duration
+	// TimeBank is the extra time each player gets for the whole game. It is
+	// used up by decisions that take longer than DecisionTime.
+	TimeBank time.Duration
+}
+
+// SetTimeControl sets the time limits of the table and fills every player's
+// time bank.
+func (g *Game) SetTimeControl(tc TimeControl) {
+	g.TimeControl = tc
+	for _, p := range g.Players {
+		p.TimeBank = tc.TimeBank
+	}
+}
+
+// TimeLimit returns the time the player has for their next decision: the
+// decision time plus whatever is left in their time bank. It returns 0 if the
+// table has no time limits.
+func (g *Game) TimeLimit(p *Player) time.Duration {
+	if g.TimeControl.DecisionTime <= 0 {
+		return 0
+	}
+	return g.TimeControl.DecisionTime + p.TimeBank
+}
+
+// TimeLeft returns the time the current player has left to act, or 0 if the
+// current decision has no time limit.
+func (g *Game) TimeLeft() time.Duration {
+	if g.turnDeadline.IsZero() {
+		return 0
+	}
+	if left := time.Until(g.turnDeadline); left > 0 {
+		return left
+	}
+	return time.Nanosecond // Time is up, but the decision still has a limit.
+}
+
+// requestTimedAction asks the provider for the player's action within the
+// player's time limit. Time spent beyond the decision time is taken from the
+// player's time bank. If the player runs out of time, the default action is
+// taken instead and timedOut is true.
+func (g *Game) requestTimedAction(provider ActionProvider, p *Player) (action PlayerAction, timedOut bool) {
+	limit := g.TimeLimit(p)
+	if limit <= 0 {
+		action, _ = RequestAction(context.Background(), provider, g, p, g.Rand)
+		return action, false
+	}
+
+	start := time.Now()
+	g.turnDeadline = start.Add(limit)
+	ctx, cancel := context.WithDeadline(context.Background(), g.turnDeadline)
+	action, err := RequestAction(ctx, provider, g, p, g.Rand)
+	cancel()
+	g.turnDeadline = time.Time{}
+
+	if overtime := time.Since(start) - g.TimeControl.DecisionTime; overtime > 0 {
+		p.TimeBank -= overtime
+		if p.TimeBank < 0 {
+			p.TimeBank = 0
+		}
+	}
+	if err != nil || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return g.DefaultAction(p), true
+	}
+	return action, false
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// slowActionProvider answers after a delay, or gives up when the context is done.
+type slowActionProvider struct {
+	delay time.Duration
+	// seats and timeLeft record who was asked to decide and the view's time left.
+	seats    []int
+	timeLeft []int64
+}
+
+func (p *slowActionProvider) GetAction(g *Game, pl *Player, r *rand.Rand) PlayerAction {
+	action, _ := p.GetActionContext(context.Background(), g, pl, r)
+	return action
+}
+
+func (p *slowActionProvider) GetActionContext(ctx context.Context, g *Game, pl *Player, _ *rand.Rand) (PlayerAction, error) {
+	p.seats = append(p.seats, pl.Position)
+	p.timeLeft = append(p.timeLeft, g.PublicView().TimeLeftMs)
+	select {
+	case <-time.After(p.delay):
+		if pl.CurrentBet == g.BetToCall {
+			return PlayerAction{Type: ActionCheck}, nil
+		}
+		return PlayerAction{Type: ActionCall}, nil
+	case <-ctx.Done():
+		return PlayerAction{}, ctx.Err()
+	}
+}
+
+func TestPlayHand_TimedOutPlayerFolds(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	g.SetTimeControl(TimeControl{DecisionTime: 20 * time.Millisecond, TimeBank: 30 * time.Millisecond})
+	provider := &slowActionProvider{delay: time.Hour}
+
+	var actions []*ActionEvent
+	start := time.Now()
+	g.PlayHand(provider, HandObserverFunc(func(_ *Game, e Event) {
+		if action, ok := e.(*ActionEvent); ok {
+			actions = append(actions, action)
+		}
+	}))
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the decision to be cut short, took %v", elapsed)
+	}
+	if len(actions) != 1 || actions[0].Action != ActionFold || !actions[0].TimedOut {
+		t.Fatalf("Expected a single timed-out fold, got %+v", actions)
+	}
+	if len(provider.timeLeft) != 1 || provider.timeLeft[0] <= 0 || provider.timeLeft[0] > 50 {
+		t.Errorf("Expected the view to show at most 50ms left, got %v", provider.timeLeft)
+	}
+	if p := g.Players[provider.seats[0]]; p.TimeBank != 0 {
+		t.Errorf("Expected %s's time bank to be used up, got %v", p.Name, p.TimeBank)
+	}
+	if g.TimeLeft() != 0 {
+		t.Errorf("Expected no time limit outside a decision, got %v", g.TimeLeft())
+	}
+}
+
+func TestPlayHand_SlowDecisionUsesTimeBank(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	g.SetTimeControl(TimeControl{DecisionTime: 10 * time.Millisecond, TimeBank: time.Second})
+	provider := &slowActionProvider{delay: 30 * time.Millisecond}
+
+	end := g.PlayHand(provider, nil)
+
+	if !end.Showdown {
+		t.Fatalf("Expected the players to act within their time banks and reach showdown, got %+v", end)
+	}
+	for _, p := range g.Players {
+		if p.TimeBank >= time.Second || p.TimeBank <= 0 {
+			t.Errorf("Expected %s's time bank to be partly used, got %v", p.Name, p.TimeBank)
+		}
+	}
+}
+
+func TestPlayHand_NoTimeControl(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	provider := &slowActionProvider{delay: time.Millisecond}
+
+	end := g.PlayHand(provider, nil)
+
+	if !end.Showdown {
+		t.Fatalf("Expected the hand to reach showdown without time limits, got %+v", end)
+	}
+	for _, left := range provider.timeLeft {
+		if left != 0 {
+			t.Errorf("Expected no time limit in the views, got %dms", left)
+		}
+	}
+}
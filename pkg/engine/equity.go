@@ -0,0 +1,200 @@
+package engine
+
+import (
+	"math/rand"
+	"pls7-cli/pkg/poker"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// equityEvaluations is the number of hands evaluated to estimate a hand's
+	// equity. The run-outs simulated are shared among the player and their
+	// opponents, so the estimate takes about the same time at any table size.
+	// More evaluations give a more accurate estimate at the cost of the AI's
+	// thinking time.
+	equityEvaluations = 480
+	// minEquitySamples is the fewest run-outs simulated, however many opponents
+	// there are.
+	minEquitySamples = 60
+	// equityBlockSamples is the number of run-outs simulated with each random
+	// stream. Blocks of run-outs are spread over the game's equity workers; since
+	// each block has its own stream, the estimate does not depend on how many
+	// workers there are.
+	equityBlockSamples = 20
+	// plausibleHandScore is the pre-flop score an opponent's hole cards are
+	// expected to have for them to still be in the hand. Opponents' hands below
+	// it are redrawn, so the simulation favors hands people actually play.
+	plausibleHandScore = 10
+	// plausibleHandAttempts limits how often an implausible hand is redrawn, so
+	// the simulation also works when few plausible hands are left in the deck.
+	plausibleHandAttempts = 10
+)
+
+// estimateEquity estimates the share of the pot the player can expect to win at
+// showdown against the opponents still in the hand. It simulates random run-outs
+// of the board, dealing each opponent hole cards from a range of plausible
+// starting hands, and counts how often the player wins or ties each half of the
+// pot. In hi-lo games, the high and low halves are valued separately, so a hand
+// that can only scoop the low half has an equity of about one half. In games
+// with several boards, every board is run out, and each is worth an equal
+// share of the pot.
+func estimateEquity(g *Game, player *Player, r *rand.Rand) float64 {
+	opponents := 0
+	for _, p := range g.Players {
+		if p != player && p.Status != PlayerStatusFolded && p.IsActive() {
+			opponents++
+		}
+	}
+	if opponents == 0 {
+		return 1
+	}
+
+	// Every card the player cannot see may be in an opponent's hand or still to come.
+	seen := make(map[poker.Card]bool)
+	for _, c := range player.Hand {
+		seen[c] = true
+	}
+	boards := g.boards()
+	for _, board := range boards {
+		for _, c := range board {
+			seen[c] = true
+		}
+	}
+	var unseen []poker.Card
+	for _, c := range poker.NewDeck().Cards {
+		if !seen[c] {
+			unseen = append(unseen, c)
+		}
+	}
+
+	holeCount := len(player.Hand)
+	boardCount := 5 - len(boards[0])
+	if boardCount < 0 {
+		boardCount = 0
+	}
+	if opponents*holeCount+len(boards)*boardCount > len(unseen) {
+		return 1 / float64(opponents+1) // Not enough cards to simulate; assume a fair share.
+	}
+
+	samples := max(equityEvaluations/(opponents+1), minEquitySamples)
+	blocks := (samples + equityBlockSamples - 1) / equityBlockSamples
+	seed := r.Int63()
+	totals := make([]float64, blocks)
+	runParallel(blocks, g.EquityWorkers, func(block int) {
+		br := poker.NewStreamRand(seed, block)
+		deck := append([]poker.Card(nil), unseen...)
+		hands := make([][]poker.Card, opponents)
+		for i := block * equityBlockSamples; i < min((block+1)*equityBlockSamples, samples); i++ {
+			dealt := 0
+			for o := range hands {
+				hands[o] = dealPlausibleHand(deck, dealt, holeCount, g.Rules, br)
+				dealt += holeCount
+			}
+			share := 0.0
+			for _, board := range boards {
+				runout := append(append([]poker.Card{}, board...), drawCards(deck, dealt, boardCount, br)...)
+				dealt += boardCount
+				share += potShare(player.Hand, hands, runout, g.Rules)
+			}
+			totals[block] += share / float64(len(boards))
+		}
+	})
+	// Add the blocks up in order, so the rounding is the same every time.
+	var total float64
+	for _, t := range totals {
+		total += t
+	}
+	return total / float64(samples)
+}
+
+// runParallel calls f with each of 0 to n-1 on up to the given number of
+// goroutines and returns once all calls are done. With fewer than two workers,
+// f is called on the calling goroutine.
+func runParallel(n, workers int, f func(i int)) {
+	if workers < 2 || n < 2 {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1)) - 1; i < n; i = int(next.Add(1)) - 1 {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// drawCards moves n random cards from deck[start:] to deck[start:start+n] and
+// returns them. The cards before start are left alone, so several hands can be
+// drawn from the same deck without dealing a card twice.
+func drawCards(deck []poker.Card, start, n int, r *rand.Rand) []poker.Card {
+	for i := start; i < start+n; i++ {
+		j := i + r.Intn(len(deck)-i)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return deck[start : start+n]
+}
+
+// dealPlausibleHand draws hole cards for an opponent from deck[start:], redrawing
+// hands a player would usually fold before the flop.
+func dealPlausibleHand(deck []poker.Card, start, n int, rules *poker.GameRules, r *rand.Rand) []poker.Card {
+	hand := drawCards(deck, start, n, r)
+	for attempt := 1; attempt < plausibleHandAttempts && preflopScore(hand, rules) < plausibleHandScore; attempt++ {
+		hand = drawCards(deck, start, n, r)
+	}
+	return hand
+}
+
+// potShare returns the share of the pot the hole cards win against the
+// opponents' hands on the given board: 1 for a scoop, 0 for a loss, and the
+// corresponding fraction for ties and split pots.
+func potShare(hole []poker.Card, opponents [][]poker.Card, board []poker.Card, rules *poker.GameRules) float64 {
+	high, low := poker.EvaluateHand(hole, board, rules)
+	highShare, lowShare := 0.0, 0.0
+	if high != nil {
+		highShare = 1
+	}
+	if low != nil {
+		lowShare = 1
+	}
+	highTies, lowTies := 1, 1
+	anyLow := low != nil
+
+	for _, hand := range opponents {
+		oppHigh, oppLow := poker.EvaluateHand(hand, board, rules)
+		if oppHigh != nil && highShare > 0 {
+			switch compareHandResults(oppHigh, high) {
+			case 1:
+				highShare = 0
+			case 0:
+				highTies++
+			}
+		}
+		if oppLow != nil {
+			anyLow = true
+			if lowShare > 0 {
+				// For low hands, a lower result is better.
+				switch compareHandResults(oppLow, low) {
+				case -1:
+					lowShare = 0
+				case 0:
+					lowTies++
+				}
+			}
+		}
+	}
+
+	highShare /= float64(highTies)
+	if !anyLow {
+		return highShare // Nobody qualifies for low; the high hand takes the whole pot.
+	}
+	return highShare/2 + lowShare/float64(lowTies)/2
+}
@@ -1,23 +1,103 @@
 package engine
 
+import "pls7-cli/pkg/poker"
+
 // ActionEvent represents a significant action taken by a player during a betting
 // round. It is intended to be used for logging, display, or broadcasting game
 // state changes to observers like a UI.
 type ActionEvent struct {
 	// PlayerName is the name of the player who performed the action.
-	PlayerName string
+	PlayerName string `json:"player_name"`
 	// Action is the type of action taken (e.g., Fold, Call, Raise).
-	Action ActionType
+	Action ActionType `json:"action"`
 	// Amount is the value associated with the action, such as the size of a
 	// bet or raise. It is 0 for actions like Fold and Check.
-	Amount int
+	Amount int `json:"amount"`
+	// TimedOut is true if the player ran out of time and the action was taken
+	// on their behalf.
+	TimedOut bool `json:"timed_out,omitempty"`
 }
 
-// BlindEvent represents the posting of the small and big blinds at the beginning
-// of a hand. It can be used to announce the current blind levels.
+// BlindEvent represents the posting of the blinds and antes at the beginning of
+// a hand. It can be used to announce the current blind levels.
 type BlindEvent struct {
 	// SmallBlind is the size of the small blind.
-	SmallBlind int
+	SmallBlind int `json:"small_blind"`
 	// BigBlind is the size of the big blind.
-	BigBlind int
+	BigBlind int `json:"big_blind"`
+	// Ante is the ante every player posts, or with BigBlindAnte the ante the
+	// big blind posts for the table. It is 0 if there is no ante.
+	Ante int `json:"ante,omitempty"`
+	// BigBlindAnte is true if the big blind posts the ante for the table.
+	BigBlindAnte bool `json:"big_blind_ante,omitempty"`
+	// Level is the number of the tournament level the blinds belong to, or 0
+	// if the game has no tournament structure.
+	Level int `json:"level,omitempty"`
+}
+
+// HandStartEvent is emitted after a new hand has been set up: the button has
+// moved, the blinds are posted and the hole cards are dealt.
+type HandStartEvent struct {
+	// HandCount is the number of the hand that has just started.
+	HandCount int `json:"hand_count"`
+	// DealerPos is the seat holding the dealer button for this hand.
+	DealerPos int `json:"dealer_pos"`
+	// Blind is set when the blinds went up at the start of this hand.
+	Blind *BlindEvent `json:"blind,omitempty"`
+	// HandType is the kind of hand that has started.
+	HandType HandType `json:"hand_type,omitempty"`
+	// TimeCharges is the total of the time charges the players paid to be
+	// dealt into this hand.
+	TimeCharges int `json:"time_charges,omitempty"`
+}
+
+// PhaseEvent is emitted when the hand moves to a new phase, after any community
+// cards for that phase have been dealt.
+type PhaseEvent struct {
+	// Phase is the phase the hand has moved to.
+	Phase GamePhase `json:"phase"`
+	// CommunityCards are the cards on the board after the phase change.
+	CommunityCards []poker.Card `json:"community_cards"`
+}
+
+// HandEndEvent is emitted when the pot of a hand has been awarded.
+type HandEndEvent struct {
+	// Showdown is true if two or more players reached the showdown.
+	Showdown bool `json:"showdown"`
+	// Results describes how the pot was distributed.
+	Results []DistributionResult `json:"results"`
+	// Boards are the boards the pot was shown down on if the game has several
+	// boards or the hand was run more than once, the first being the
+	// community cards.
+	Boards [][]poker.Card `json:"boards,omitempty"`
+	// Rake is the rake the house took from the pot.
+	Rake int `json:"rake,omitempty"`
+}
+
+// Event is implemented by every event emitted while PlayHand drives a hand:
+// *HandStartEvent, *ActionEvent, *PhaseEvent and *HandEndEvent.
+type Event interface {
+	isEvent()
+}
+
+func (*HandStartEvent) isEvent() {}
+func (*ActionEvent) isEvent()    {}
+func (*PhaseEvent) isEvent()     {}
+func (*HandEndEvent) isEvent()   {}
+
+// HandObserver receives the events of a hand as PlayHand drives it. Frontends
+// implement it to display or broadcast the progress of the game.
+type HandObserver interface {
+	// OnEvent is called synchronously from the game loop, so the game state
+	// reflects the event when it is called.
+	OnEvent(g *Game, event Event)
+}
+
+// HandObserverFunc is an adapter that allows an ordinary function to be used as
+// a HandObserver.
+type HandObserverFunc func(g *Game, event Event)
+
+// OnEvent calls f(g, event).
+func (f HandObserverFunc) OnEvent(g *Game, event Event) {
+	f(g, event)
 }
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"os"
 	"pls7-cli/pkg/poker"
+	"runtime"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -39,12 +40,29 @@ type Game struct {
 	// Deck is the deck of cards for the current hand. It is created new and shuffled
 	// at the beginning of each hand.
 	Deck *poker.Deck
-	// CommunityCards are the shared cards dealt face-up on the board.
+	// CommunityCards are the shared cards dealt face-up on the board. In a game
+	// with several boards, they are the cards of the first board.
 	CommunityCards []poker.Card
+	// RunItTimes is how many times the rest of the board is dealt when the
+	// players still in a hand are all-in before the river and all agree. 0
+	// and 1 deal the board once.
+	RunItTimes int
+	// Boards are the boards of the current hand, the first being
+	// CommunityCards, if the rules deal several boards or the hand was run
+	// more than once. It is nil if the hand has a single board.
+	Boards [][]poker.Card
 	// Pot holds the total amount of chips wagered by all players in the current hand.
 	Pot int
 	// DealerPos is the index in the Players slice corresponding to the player with the dealer button.
 	DealerPos int
+	// SmallBlindPos and BigBlindPos are the seats of the blinds in the current
+	// hand, or -1 before the first hand. With a dead button, the small blind
+	// seat may be an eliminated player's, and the small blind is dead.
+	SmallBlindPos int
+	BigBlindPos   int
+	// ButtonPolicy decides how the button and the blinds move on when players
+	// are eliminated.
+	ButtonPolicy ButtonPolicy
 	// CurrentTurnPos is the index in the Players slice for the player whose turn it is to act.
 	CurrentTurnPos int
 	// Phase indicates the current stage of the hand (e.g., Pre-Flop, Flop, Turn).
@@ -60,11 +78,36 @@ type Game struct {
 	SmallBlind int
 	// BigBlind is the size of the big blind for the current hand.
 	BigBlind int
+	// Ante is the ante every player posts before a hand is dealt, or with
+	// BigBlindAnte the ante the big blind posts for the whole table. 0 means
+	// no ante.
+	Ante int
+	// BigBlindAnte makes the big blind post the ante for the whole table.
+	BigBlindAnte bool
+	// BombPotAnte is the ante every player posts in a bomb pot. 0 means the
+	// big blind.
+	BombPotAnte int
+	// NextHandType is the kind of hand StartNewHand deals next. It is reset
+	// to HandRegular once the hand has started.
+	NextHandType HandType
+	// HandType is the kind of the current hand. A straddle that could not be
+	// posted makes it a regular hand.
+	HandType HandType
+	// StraddlePos is the seat of the player who straddled in the current
+	// hand, or -1 if nobody did.
+	StraddlePos int
 	// Difficulty determines the skill level of the AI opponents.
 	Difficulty Difficulty
 	// handEvaluator is a function used to determine hand strength, primarily for AI decisions.
 	// It can be replaced in tests for predictable outcomes.
 	handEvaluator func(g *Game, player *Player) float64
+	// equityEstimator is a function used to estimate a player's share of the pot
+	// at showdown for post-flop AI decisions. It can be replaced in tests as well.
+	equityEstimator func(g *Game, player *Player, r *rand.Rand) float64
+	// EquityWorkers is the number of goroutines the AI's equity estimates are
+	// spread over. Values below 2 estimate on the calling goroutine, which suits
+	// games that are themselves played in parallel, such as simulations.
+	EquityWorkers int
 	// DevMode enables development-specific features like detailed logging or predictable card dealing.
 	DevMode bool
 	// ShowsOuts enables a helper feature for human players to see their potential "outs" cards.
@@ -75,28 +118,66 @@ type Game struct {
 	Rand *rand.Rand
 	// BlindUpInterval is the number of hands after which the blinds increase. 0 disables this.
 	BlindUpInterval int
+	// Structure is the tournament structure the blinds follow, or nil if they
+	// double every BlindUpInterval hands instead.
+	Structure *Structure
+	// LevelIndex is the index of the current level in Structure.Levels.
+	LevelIndex int
+	// LevelHands is the number of hands dealt at the current level.
+	LevelHands int
+	// LevelStart is when the current level started.
+	LevelStart time.Time
+	// announcedLevel is the index of the level a BlindEvent was last sent for.
+	announcedLevel int
+	// Eliminations are the finishing places of the players eliminated so far,
+	// best first.
+	Eliminations []Standing
+	// Cash holds the buy-in limits of a cash game, or is nil in a tournament.
+	Cash *CashRules
+	// Departed are the session results of the CPU players who busted in a
+	// cash game and whose seats were given to new players.
+	Departed []SessionResult
 	// BettingCalculator is an interface that calculates valid bet/raise sizes based on the game's betting limit.
 	BettingCalculator BettingLimitCalculator
 	// Aggressor points to the player who made the last aggressive action (bet or raise).
 	// This is key to determining when a betting round ends.
 	Aggressor *Player
+	// PreviousAggressor is the player who made the last bet or raise of the
+	// previous betting round, and so has the initiative in the current one. It
+	// is nil pre-flop and after a round without bets.
+	PreviousAggressor *Player
 	// ActionCloserPos is the position of the player who can close the action in a round
-	// if no one raises. Pre-flop, this is the Big Blind. Post-flop, it's the first active
-	// player to the left of the dealer.
+	// if no one raises. Pre-flop, this is the Big Blind, or the straddler in a straddled
+	// hand. Post-flop, it's the dealer, or the closest active player before them.
 	ActionCloserPos int
 	// ActionsTakenThisRound counts player actions to help determine the end of a betting round.
 	ActionsTakenThisRound int
 	// TotalInitialChips stores the sum of all players' starting chips, used for sanity checks
 	// to ensure chip conservation.
 	TotalInitialChips int
+	// Rake is what the house takes from the game. The zero value takes nothing.
+	Rake Rake
+	// HouseChips is the total of the rake and time charges the house has taken.
+	// Together with the players' chips and the pot, it adds up to
+	// TotalInitialChips.
+	HouseChips int
+	// TimeControl limits the time players have to decide. The zero value means no limits.
+	TimeControl TimeControl
+	// turnDeadline is the time by which the current player must have acted, or
+	// the zero time if the current decision has no time limit.
+	turnDeadline time.Time
 }
 
 // CPUThinkTime returns the delay used to simulate CPU "thinking" for a more
-// realistic game pace. In development mode, this delay is zero.
+// realistic game pace. In development mode, and at tables without human
+// players to watch the game, this delay is zero.
 func (g *Game) CPUThinkTime() time.Duration {
 	if g.DevMode {
 		return 0 // No delay in dev mode.
 	}
+	if g.CountHumanPlayers() == 0 {
+		return 0 // Nobody is watching.
+	}
 	return 500 * time.Millisecond // Default delay.
 }
 
@@ -116,7 +197,7 @@ func NewGame(
 ) *Game {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	players := make([]*Player, len(playerNames))
-	cpuProfilesToAssign, err := cpuProfiles(difficulty, len(playerNames)-1)
+	cpuProfilesToAssign, err := defaultAIConfig.LineUp(difficulty, len(playerNames)-1)
 	if err != nil {
 		logrus.Errorf("Failed to get CPU profiles: %v", err)
 		os.Exit(1)
@@ -141,7 +222,7 @@ func NewGame(
 		}
 
 		if isCPU {
-			if profile, ok := aiProfiles[cpuProfilesToAssign[i-1]]; ok {
+			if profile, ok := defaultAIConfig.Profiles[cpuProfilesToAssign[i-1]]; ok {
 				players[i].Profile = &profile
 			} else {
 				logrus.Errorf("Unknown AI profile: %s", cpuProfilesToAssign[i-1])
@@ -164,6 +245,9 @@ func NewGame(
 	g := &Game{
 		Players:           players,
 		DealerPos:         -1, // Dealer position is set at the start of the first hand.
+		SmallBlindPos:     -1,
+		BigBlindPos:       -1,
+		StraddlePos:       -1,
 		SmallBlind:        smallBlind,
 		BigBlind:          bigBlind,
 		Difficulty:        difficulty,
@@ -174,9 +258,11 @@ func NewGame(
 		BlindUpInterval:   blindUpInterval,
 		BettingCalculator: calculator,
 		TotalInitialChips: initialChips * len(playerNames),
+		EquityWorkers:     runtime.NumCPU(),
 	}
 	// Set the default hand evaluator function.
 	g.handEvaluator = evaluateHandStrength
+	g.equityEstimator = estimateEquity
 	return g
 }
 
@@ -214,12 +300,40 @@ func (g *Game) CalculateBettingLimits() (minRaiseTotal int, maxRaiseTotal int) {
 // CanShowOuts determines if the "show outs" helper should be displayed for a player.
 // It is typically only enabled for the human player in development or easy modes.
 func (g *Game) CanShowOuts(p *Player) bool {
-	humanPlayerInPlay := p.Name == "YOU" && p.Status != PlayerStatusFolded
+	humanPlayerInPlay := !p.IsCPU && p.Status != PlayerStatusFolded
 	availablePhase := g.Phase == PhaseFlop || g.Phase == PhaseTurn
 	optionEnabled := g.DevMode || g.ShowsOuts
 	return humanPlayerInPlay && optionEnabled && availablePhase
 }
 
+// SetHuman turns the player at the given seat into a human-controlled player with
+// the given name, dropping any AI profile assigned by NewGame. It is used to set up
+// hot-seat games where several people share one terminal.
+func (g *Game) SetHuman(pos int, name string) error {
+	if pos < 0 || pos >= len(g.Players) {
+		return fmt.Errorf("seat %d is out of range (0-%d)", pos, len(g.Players)-1)
+	}
+	p := g.Players[pos]
+	p.Name = name
+	p.IsCPU = false
+	p.Profile = nil
+	return nil
+}
+
+// SetCPU turns the player at the given seat into a CPU player with the given name
+// and AI profile. It is used to set up tables without human players, such as the
+// self-play tables that tune AI profiles.
+func (g *Game) SetCPU(pos int, name string, profile AIProfile) error {
+	if pos < 0 || pos >= len(g.Players) {
+		return fmt.Errorf("seat %d is out of range (0-%d)", pos, len(g.Players)-1)
+	}
+	p := g.Players[pos]
+	p.Name = name
+	p.IsCPU = true
+	p.Profile = &profile
+	return nil
+}
+
 // minRaiseAmount calculates the minimum total bet required for a valid raise.
 func (g *Game) minRaiseAmount() int {
 	minRaiseIncrease := g.LastRaiseAmount
@@ -231,35 +345,3 @@ func (g *Game) minRaiseAmount() int {
 	}
 	return g.BetToCall + minRaiseIncrease
 }
-
-// cpuProfiles returns a slice of AI profile names to be assigned to CPU players,
-// based on the selected game difficulty and the number of CPUs.
-func cpuProfiles(difficulty Difficulty, numCPUs int) ([]string, error) {
-	if numCPUs < 1 || numCPUs > 5 {
-		return []string{}, fmt.Errorf("numCPUs must be between 1 and 5, got %d", numCPUs)
-	}
-
-	switch difficulty {
-	case DifficultyEasy:
-		// Easy difficulty features more passive opponents.
-		return []string{
-			"Loose-Passive", "Loose-Passive",
-			"Loose-Passive", "Loose-Passive", "Loose-Passive",
-		}[:numCPUs], nil
-	case DifficultyMedium:
-		// Medium difficulty introduces a mix of passive styles.
-		return []string{
-			"Loose-Passive", "Loose-Passive",
-			"Tight-Passive", "Tight-Passive", "Tight-Passive",
-		}[:numCPUs], nil
-	case DifficultyHard:
-		// Hard difficulty features more aggressive and varied opponents.
-		return []string{
-			"Tight-Passive",
-			"Loose-Aggressive", "Loose-Aggressive",
-			"Tight-Aggressive", "Tight-Aggressive",
-		}[:numCPUs], nil
-	default:
-		return []string{}, fmt.Errorf("unknown difficulty: %v", difficulty)
-	}
-}
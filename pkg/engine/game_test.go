@@ -82,3 +82,58 @@ func TestNewGame_AssignsCorrectCalculator(t *testing.T) {
 		})
 	}
 }
+
+func TestSetCPU_TableWithoutHumans(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	profile := defaultAIConfig.Profiles["Loose-Aggressive"]
+
+	if err := g.SetCPU(0, "CPU0", profile); err != nil {
+		t.Fatalf("SetCPU returned unexpected error: %v", err)
+	}
+	if err := g.SetCPU(2, "CPU2", profile); err == nil {
+		t.Error("Expected an error when seating a CPU outside the table")
+	}
+
+	p := g.Players[0]
+	if p.Name != "CPU0" || !p.IsCPU || p.Profile == nil || p.Profile.Name != "Loose-Aggressive" {
+		t.Errorf("Expected seat 0 to be CPU0 with the Loose-Aggressive profile, got %+v", p)
+	}
+	g.DevMode = false
+	if think := g.CPUThinkTime(); think != 0 {
+		t.Errorf("Expected no thinking time without human players, got %v", think)
+	}
+}
+
+func TestSetHuman_HotSeatPlayers(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 500, 1000)
+
+	if err := g.SetHuman(1, "Alice"); err != nil {
+		t.Fatalf("SetHuman returned unexpected error: %v", err)
+	}
+	if err := g.SetHuman(4, "Bob"); err == nil {
+		t.Error("Expected an error when seating a human outside the table")
+	}
+
+	alice := g.Players[1]
+	if alice.Name != "Alice" || alice.IsCPU || alice.Profile != nil {
+		t.Errorf("Expected seat 1 to be human player Alice without a profile, got %+v", alice)
+	}
+	if got := g.CountHumanPlayers(); got != 2 {
+		t.Errorf("Expected 2 human players, got %d", got)
+	}
+
+	g.Players[0].Status = PlayerStatusEliminated
+	if got := g.CountRemainingHumans(); got != 1 {
+		t.Errorf("Expected 1 remaining human after YOU is eliminated, got %d", got)
+	}
+
+	// Outs are available to every human seat, not only to "YOU".
+	g.Phase = PhaseFlop
+	g.ShowsOuts = true
+	if !g.CanShowOuts(alice) {
+		t.Error("Expected outs to be available for hot-seat player Alice")
+	}
+	if g.CanShowOuts(g.Players[2]) {
+		t.Error("Expected outs to be unavailable for CPU players")
+	}
+}
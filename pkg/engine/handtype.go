@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HandType is the kind of hand StartNewHand deals. Home games pick it per hand
+// by setting Game.NextHandType.
+type HandType int
+
+// HandType constants list the kinds of hands.
+const (
+	// HandRegular is a hand with blinds and pre-flop betting.
+	HandRegular HandType = iota
+	// HandUTGStraddle is a hand in which the player after the big blind
+	// straddles: they post twice the big blind and act last pre-flop.
+	HandUTGStraddle
+	// HandButtonStraddle is a hand in which the dealer straddles. Pre-flop
+	// action starts with the small blind, and the dealer acts last.
+	HandButtonStraddle
+	// HandBombPot is a hand in which every player antes BombPotAnte instead
+	// of posting blinds, and the hand starts on the flop.
+	HandBombPot
+)
+
+// String returns the human-readable name of the hand type.
+func (t HandType) String() string {
+	switch t {
+	case HandRegular:
+		return "Regular"
+	case HandUTGStraddle:
+		return "UTG Straddle"
+	case HandButtonStraddle:
+		return "Button Straddle"
+	case HandBombPot:
+		return "Bomb Pot"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseHandType parses the name of a hand type: regular, utg (straddle),
+// button (straddle) or bomb (pot).
+func ParseHandType(s string) (HandType, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "regular":
+		return HandRegular, nil
+	case "utg", "straddle", "utg-straddle":
+		return HandUTGStraddle, nil
+	case "button", "button-straddle":
+		return HandButtonStraddle, nil
+	case "bomb", "bomb-pot":
+		return HandBombPot, nil
+	default:
+		return HandRegular, fmt.Errorf("unknown hand type %q (regular, utg, button or bomb)", s)
+	}
+}
+
+// straddle returns the size of a straddle: twice the big blind.
+func (g *Game) straddle() int {
+	return 2 * g.BigBlind
+}
+
+// bombPotAnte returns the ante every player posts in a bomb pot.
+func (g *Game) bombPotAnte() int {
+	if g.BombPotAnte > 0 {
+		return g.BombPotAnte
+	}
+	return g.BigBlind
+}
+
+// postStraddle posts the straddle of a straddled hand, once the blinds are
+// in. Straddles need at least three players, and a player who would be
+// all-in does not straddle; the hand is then played as a regular hand.
+func (g *Game) postStraddle() {
+	var pos int
+	switch g.HandType {
+	case HandUTGStraddle:
+		pos = g.FindNextActivePlayer(g.BigBlindPos)
+	case HandButtonStraddle:
+		pos = g.DealerPos
+	default:
+		return
+	}
+	player := g.Players[pos]
+	amount := g.straddle()
+	if g.CountRemainingPlayers() < 3 || player.Status != PlayerStatusPlaying || player.Chips <= amount {
+		g.HandType = HandRegular
+		return
+	}
+	g.postBet(player, amount)
+	player.LastActionDesc = fmt.Sprintf("Straddle %d", amount)
+	g.BetToCall = amount
+	g.StraddlePos = pos
+}
+
+// postBombPotAntes posts every player's bomb pot ante. There is no pre-flop
+// betting, so nothing is left to call.
+func (g *Game) postBombPotAntes() {
+	for _, p := range g.Players {
+		if p.IsActive() {
+			g.postAnte(p, g.bombPotAnte())
+		}
+	}
+	g.BetToCall = 0
+}
+
+// preflopCloser returns the seat that acts last pre-flop: the straddler, or
+// else the big blind.
+func (g *Game) preflopCloser() int {
+	if g.StraddlePos >= 0 {
+		return g.StraddlePos
+	}
+	return g.BigBlindPos
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// callingProvider calls every bet and checks otherwise, recording the seats
+// asked to act in each phase.
+type callingProvider struct {
+	seats map[GamePhase][]int
+}
+
+func (p *callingProvider) GetAction(g *Game, pl *Player, _ *rand.Rand) PlayerAction {
+	if p.seats == nil {
+		p.seats = make(map[GamePhase][]int)
+	}
+	p.seats[g.Phase] = append(p.seats[g.Phase], pl.Position)
+	if pl.CurrentBet < g.BetToCall {
+		return PlayerAction{Type: ActionCall}
+	}
+	return PlayerAction{Type: ActionCheck}
+}
+
+func TestParseHandType(t *testing.T) {
+	testCases := map[string]HandType{"": HandRegular, "utg": HandUTGStraddle, "Button": HandButtonStraddle, "bomb": HandBombPot}
+	for s, expected := range testCases {
+		if handType, err := ParseHandType(s); err != nil || handType != expected {
+			t.Errorf("ParseHandType(%q) = %v, %v; expected %v", s, handType, err, expected)
+		}
+	}
+	if _, err := ParseHandType("mississippi"); err == nil {
+		t.Error("Expected an error for an unknown hand type")
+	}
+}
+
+func TestStartNewHand_UTGStraddle(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.NextHandType = HandUTGStraddle
+	g.StartNewHand()
+
+	utgPos := g.FindNextActivePlayer(g.FindNextActivePlayer(g.FindNextActivePlayer(g.DealerPos)))
+	if g.HandType != HandUTGStraddle || g.NextHandType != HandRegular {
+		t.Errorf("Expected a UTG straddle and a regular next hand, got %v and %v", g.HandType, g.NextHandType)
+	}
+	if g.StraddlePos != utgPos || g.Players[utgPos].CurrentBet != 200 || g.BetToCall != 200 {
+		t.Fatalf("Expected seat %d to straddle to 200, got seat %d and a bet to call of %d", utgPos, g.StraddlePos, g.BetToCall)
+	}
+	if g.CurrentTurnPos != g.DealerPos {
+		t.Errorf("Expected the player after the straddler (%d) to act first, got %d", g.DealerPos, g.CurrentTurnPos)
+	}
+	g.PrepareNewBettingRound()
+	if g.ActionCloserPos != utgPos {
+		t.Errorf("Expected the straddler to close the action, got %d", g.ActionCloserPos)
+	}
+	if minRaise := g.minRaiseAmount(); minRaise != 400 {
+		t.Errorf("Expected the minimum raise to be to twice the straddle (400), got %d", minRaise)
+	}
+}
+
+func TestStartNewHand_ButtonStraddle(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.NextHandType = HandButtonStraddle
+	g.StartNewHand()
+
+	if g.StraddlePos != g.DealerPos || g.BetToCall != 200 {
+		t.Fatalf("Expected the dealer to straddle to 200, got seat %d and a bet to call of %d", g.StraddlePos, g.BetToCall)
+	}
+	if sbPos := g.FindNextActivePlayer(g.DealerPos); g.CurrentTurnPos != sbPos {
+		t.Errorf("Expected the small blind (%d) to act first, got %d", sbPos, g.CurrentTurnPos)
+	}
+}
+
+func TestPlayHand_StraddlerActsLastPreFlop(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.NextHandType = HandUTGStraddle
+	provider := &callingProvider{}
+	g.PlayHand(provider, nil)
+
+	preflop := provider.seats[PhasePreFlop]
+	if len(preflop) != 4 || preflop[len(preflop)-1] != g.StraddlePos {
+		t.Errorf("Expected all 4 players to act pre-flop with the straddler (%d) last, got %v", g.StraddlePos, preflop)
+	}
+}
+
+func TestStartNewHand_StraddleNeedsThreePlayersAndChips(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	g.NextHandType = HandButtonStraddle
+	g.StartNewHand()
+	if g.HandType != HandRegular || g.StraddlePos != -1 || g.BetToCall != 100 {
+		t.Errorf("Expected no straddle heads-up, got %v at seat %d", g.HandType, g.StraddlePos)
+	}
+
+	g = newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Players[g.FindNextActivePlayer(g.DealerPos)].Chips = 200 // The next dealer.
+	g.NextHandType = HandButtonStraddle
+	g.StartNewHand()
+	if g.HandType != HandRegular || g.Players[g.DealerPos].Chips != 200 {
+		t.Errorf("Expected a dealer who would be all-in not to straddle, got %v", g.HandType)
+	}
+}
+
+func TestPlayHand_BombPot(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Ante = 10
+	g.NextHandType = HandBombPot
+	g.StartNewHand()
+
+	if g.Pot != 300 || g.BetToCall != 0 {
+		t.Errorf("Expected a pot of 3 bomb pot antes (300) and nothing to call, got %d and %d", g.Pot, g.BetToCall)
+	}
+	for _, p := range g.Players {
+		if p.CurrentBet != 0 || p.TotalBetInHand != 100 {
+			t.Errorf("Expected %s to ante 100 without betting, got a current bet of %d and %d in the hand", p.Name, p.CurrentBet, p.TotalBetInHand)
+		}
+	}
+
+	g = newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.BombPotAnte = 500
+	g.NextHandType = HandBombPot
+	provider := &callingProvider{}
+	end := g.PlayHand(provider, nil)
+	if len(provider.seats[PhasePreFlop]) != 0 || len(provider.seats[PhaseFlop]) != 3 {
+		t.Errorf("Expected no pre-flop action and 3 players to act on the flop, got %v", provider.seats)
+	}
+	won := 0
+	for _, result := range end.Results {
+		won += result.AmountWon
+	}
+	if won != 1500 {
+		t.Errorf("Expected the bomb pot of 1500 to be awarded, got %d", won)
+	}
+	for _, p := range g.Players {
+		if p.Stats.Hands != 0 {
+			t.Errorf("Expected bomb pots not to count toward %s's pre-flop statistics, got %d hands", p.Name, p.Stats.Hands)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package engine
+
+import "pls7-cli/pkg/poker"
+
+// PlayHand drives a complete hand: it starts a new hand, runs every betting round
+// by asking the provider for each player's action, deals the board, and awards
+// the pot. With a time control, providers implementing ContextActionProvider
+// are given a deadline, and players who miss it check or fold. With RunItTimes
+// set, players who are all-in before the river are asked through a
+// RunoutProvider whether to run the board more than once. The observer, which
+// may be nil, is notified of every event as it happens, after the event has
+// been recorded in the players' statistics.
+//
+// PlayHand does not call CleanupHand, so the caller can still inspect the final
+// state of the hand (e.g. the shown-down hands) before eliminated players are
+// marked.
+func (g *Game) PlayHand(provider ActionProvider, observer HandObserver) *HandEndEvent {
+	notify := func(event Event) {
+		g.recordStats(event)
+		if observer != nil {
+			observer.OnEvent(g, event)
+		}
+	}
+
+	houseChips := g.HouseChips
+	blind := g.StartNewHand()
+	notify(&HandStartEvent{
+		HandCount:   g.HandCount,
+		DealerPos:   g.DealerPos,
+		Blind:       blind,
+		HandType:    g.HandType,
+		TimeCharges: g.HouseChips - houseChips,
+	})
+
+	// runouts is the number of times the rest of the board is dealt, once the
+	// players have been asked, and shared the number of cards dealt by then.
+	runouts, shared := 0, 0
+	for g.Phase != PhaseShowdown && g.Phase != PhaseHandOver {
+		if g.CountNonFoldedPlayers() <= 1 {
+			break
+		}
+		g.PrepareNewBettingRound()
+
+		// Turn-by-turn betting loop.
+		for !g.IsBettingRoundOver() {
+			player := g.CurrentPlayer()
+			if player.Status != PlayerStatusPlaying {
+				g.AdvanceTurn()
+				continue
+			}
+
+			action, timedOut := g.requestTimedAction(provider, player)
+			_, event := g.ProcessAction(player, action)
+			if event != nil {
+				event.TimedOut = timedOut
+				notify(event)
+			}
+			g.AdvanceTurn()
+		}
+		if runouts == 0 && g.runoutsOffered() {
+			runouts, shared = g.agreeToRunouts(provider), len(g.CommunityCards)
+		}
+		g.Advance()
+		notify(&PhaseEvent{Phase: g.Phase, CommunityCards: append([]poker.Card{}, g.CommunityCards...)})
+	}
+
+	end := &HandEndEvent{}
+	houseChips = g.HouseChips
+	if g.CountNonFoldedPlayers() > 1 {
+		if runouts > 1 {
+			g.dealRunouts(runouts, shared)
+		}
+		if len(g.Boards) > 1 {
+			end.Boards = g.Boards
+		}
+		end.Showdown = true
+		end.Results = g.DistributePot()
+	} else {
+		end.Results = g.AwardPotToLastPlayer()
+	}
+	end.Rake = g.HouseChips - houseChips
+	notify(end)
+	return end
+}
@@ -0,0 +1,67 @@
+package engine
+
+import "testing"
+
+func TestPlayHand_EmitsEventsAndAwardsPot(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	provider := &TestActionProvider{Actions: []PlayerAction{
+		{Type: ActionFold}, // Dealer (YOU) folds pre-flop.
+		{Type: ActionFold}, // Small blind (CPU1) folds, the big blind wins.
+	}}
+
+	var events []Event
+	end := g.PlayHand(provider, HandObserverFunc(func(_ *Game, e Event) {
+		events = append(events, e)
+	}))
+
+	// The round still advances to the flop before the hand ends, as in the CLI loop.
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 events (start, 2 actions, phase, end), got %d: %+v", len(events), events)
+	}
+	if start, ok := events[0].(*HandStartEvent); !ok || start.HandCount != 1 {
+		t.Errorf("Expected the first event to start hand #1, got %+v", events[0])
+	}
+	if action, ok := events[1].(*ActionEvent); !ok || action.Action != ActionFold {
+		t.Errorf("Expected the second event to be a fold, got %+v", events[1])
+	}
+	if events[4] != Event(end) {
+		t.Errorf("Expected the last event to be the returned HandEndEvent")
+	}
+	if end.Showdown || len(end.Results) != 1 || end.Results[0].PlayerName != "CPU2" || end.Results[0].AmountWon != 1500 {
+		t.Errorf("Expected CPU2 to take the 1500 pot without a showdown, got %+v", end)
+	}
+	if g.Players[2].Chips != 10500 {
+		t.Errorf("Expected CPU2 to have 10500 chips, got %d", g.Players[2].Chips)
+	}
+}
+
+func TestPlayHand_ReachesShowdown(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	// Everyone calls or checks down to the river.
+	var actions []PlayerAction
+	actions = append(actions, PlayerAction{Type: ActionCall}, PlayerAction{Type: ActionCheck})
+	for i := 0; i < 6; i++ {
+		actions = append(actions, PlayerAction{Type: ActionCheck})
+	}
+	provider := &TestActionProvider{Actions: actions}
+
+	phases := 0
+	end := g.PlayHand(provider, HandObserverFunc(func(_ *Game, e Event) {
+		if _, ok := e.(*PhaseEvent); ok {
+			phases++
+		}
+	}))
+
+	if !end.Showdown {
+		t.Fatalf("Expected the hand to reach a showdown, got %+v", end)
+	}
+	if phases != 4 {
+		t.Errorf("Expected 4 phase changes (flop, turn, river, showdown), got %d", phases)
+	}
+	if len(g.CommunityCards) != 5 {
+		t.Errorf("Expected 5 community cards, got %d", len(g.CommunityCards))
+	}
+	if g.Players[0].Chips+g.Players[1].Chips != 20000 {
+		t.Errorf("Expected chips to be conserved, got %d", g.Players[0].Chips+g.Players[1].Chips)
+	}
+}
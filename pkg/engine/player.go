@@ -3,6 +3,7 @@ package engine
 import (
 	"fmt"
 	"pls7-cli/pkg/poker"
+	"time"
 )
 
 // PlayerStatus defines the current state of a player within a single hand of poker.
@@ -14,6 +15,7 @@ const (
 	PlayerStatusFolded                         // PlayerStatusFolded indicates the player has folded and is no longer in the hand.
 	PlayerStatusAllIn                          // PlayerStatusAllIn indicates the player has bet all their remaining chips.
 	PlayerStatusEliminated                     // PlayerStatusEliminated indicates the player has run out of chips and is out of the game entirely.
+	PlayerStatusSittingOut                     // PlayerStatusSittingOut indicates the player keeps their seat in a cash game but is not dealt in.
 )
 
 // String returns the human-readable representation of a PlayerStatus.
@@ -28,6 +30,8 @@ func (s PlayerStatus) String() string {
 		return "All In"
 	case PlayerStatusEliminated:
 		return "Eliminated"
+	case PlayerStatusSittingOut:
+		return "Sitting Out"
 	default:
 		return "Unknown"
 	}
@@ -37,25 +41,38 @@ func (s PlayerStatus) String() string {
 // for a CPU-controlled player. It allows for creating different "personalities"
 // for AI opponents, from tight and passive to loose and aggressive.
 type AIProfile struct {
-	// Name is the identifier for the profile, e.g., "Tight-Aggressive".
-	Name string
+	// Name is the identifier for the profile, e.g., "Tight-Aggressive". In YAML,
+	// it is the key the profile is listed under.
+	Name string `yaml:"-"`
 	// PlayHandThreshold is the minimum hand strength score required for the AI to
 	// consider playing a hand pre-flop. A higher value means the AI is "tighter"
 	// and plays fewer hands.
-	PlayHandThreshold float64
+	PlayHandThreshold float64 `yaml:"play_hand_threshold"`
 	// RaiseHandThreshold is the minimum hand strength score required for the AI
 	// to open with a raise pre-flop.
-	RaiseHandThreshold float64
+	RaiseHandThreshold float64 `yaml:"raise_hand_threshold"`
 	// BluffingFrequency is the probability (0.0 to 1.0) that the AI will attempt
 	// a bluff with a weak hand.
-	BluffingFrequency float64
+	BluffingFrequency float64 `yaml:"bluffing_frequency"`
 	// AggressionFactor is the probability (0.0 to 1.0) that the AI will choose
 	// to bet or raise instead of check or call when it has a reasonably strong hand.
-	AggressionFactor float64
-	// MinRaiseMultiplier is the minimum multiplier for a raise amount, e.g., 2.0x the bet.
-	MinRaiseMultiplier float64
-	// MaxRaiseMultiplier is the maximum multiplier for a raise amount.
-	MaxRaiseMultiplier float64
+	AggressionFactor float64 `yaml:"aggression_factor"`
+	// MinRaiseMultiplier is the minimum multiplier for a bet or raise size
+	// relative to the pot, e.g., 2.0 bets half the pot and 4.0 the whole pot.
+	MinRaiseMultiplier float64 `yaml:"min_raise_multiplier"`
+	// MaxRaiseMultiplier is the maximum multiplier for a bet or raise size.
+	MaxRaiseMultiplier float64 `yaml:"max_raise_multiplier"`
+	// PositionBonus is how many points the pre-flop thresholds drop when no one
+	// has raised yet and no players are left to act behind the AI, which widens
+	// its opening range in late position. Earlier seats get a proportional share.
+	PositionBonus float64 `yaml:"position_bonus"`
+	// ContinuationBetFrequency is the probability (0.0 to 1.0) that the AI bets
+	// again, whatever its hand, when it made the last bet or raise of the
+	// previous betting round and is checked to.
+	ContinuationBetFrequency float64 `yaml:"continuation_bet_frequency"`
+	// CheckRaiseFrequency is the probability (0.0 to 1.0) that the AI checks a
+	// strong hand out of position, to raise when another player bets.
+	CheckRaiseFrequency float64 `yaml:"check_raise_frequency"`
 }
 
 // Player represents a single participant in the poker game. It holds all state
@@ -73,6 +90,15 @@ type Player struct {
 	// TotalBetInHand is the cumulative amount of chips the player has put into the
 	// pot throughout the entire current hand (across all betting rounds).
 	TotalBetInHand int
+	// MissedBlinds is the amount of blinds the player skipped and still owes.
+	// They are posted as dead money in the player's next hand.
+	MissedBlinds int
+	// BoughtIn is the total number of chips the player has bought in a cash
+	// game: the starting stack, rebuys and top-ups.
+	BoughtIn int
+	// DeadMoney is the part of TotalBetInHand that no other player has to
+	// match, such as a big blind ante. It goes into the main pot.
+	DeadMoney int
 	// Status indicates the player's current state in the hand (e.g., Playing, Folded).
 	Status PlayerStatus
 	// IsCPU is true if the player is controlled by the AI.
@@ -83,6 +109,11 @@ type Player struct {
 	Profile *AIProfile
 	// Position is the player's seat at the table, represented by an index in the Game.Players slice.
 	Position int
+	// TimeBank is the extra time the player may still spend on decisions that
+	// take longer than the table's decision time.
+	TimeBank time.Duration
+	// Stats are the statistics the CPU players keep about the player's play.
+	Stats PlayerStats
 }
 
 // String provides a formatted string representation of the Player's state,
@@ -93,3 +124,9 @@ func (p *Player) String() string {
 		p.Name, p.Chips, p.Status, p.CurrentBet, p.IsCPU,
 	)
 }
+
+// IsActive reports whether the player is dealt into hands: they have neither
+// been eliminated nor are sitting out.
+func (p *Player) IsActive() bool {
+	return p.Status != PlayerStatusEliminated && p.Status != PlayerStatusSittingOut
+}
@@ -0,0 +1,32 @@
+package engine
+
+// playersLeftToAct returns how many players who can still act are seated
+// between the player and the end of the betting round's order: the big blind
+// or the straddler pre-flop, the dealer (or the closest seat before it) after the flop. A player
+// with none left to act is in position.
+func (g *Game) playersLeftToAct(player *Player) int {
+	if player.Position < 0 || player.Position >= len(g.Players) || g.Players[player.Position] != player {
+		return 0
+	}
+	count := 0
+	for pos := player.Position; pos != g.ActionCloserPos; {
+		pos = (pos + 1) % len(g.Players)
+		if pos == player.Position {
+			break // The closer is not at the table; stop after one orbit.
+		}
+		if g.Players[pos].Status == PlayerStatusPlaying {
+			count++
+		}
+	}
+	return count
+}
+
+// positionFactor returns how late the player acts in the betting round, from 0
+// when every other player at the table is still to act to 1 when no one is.
+func (g *Game) positionFactor(player *Player) float64 {
+	others := g.CountRemainingPlayers() - 1
+	if others <= 0 {
+		return 0
+	}
+	return 1 - float64(g.playersLeftToAct(player))/float64(others)
+}
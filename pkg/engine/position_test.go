@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPlayersLeftToAct(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}, 10000, 50, 100)
+	g.StartNewHand() // YOU has the button, CPU1 and CPU2 post the blinds.
+	g.PrepareNewBettingRound()
+
+	preFlop := map[int]int{3: 5, 0: 2, 1: 1, 2: 0}
+	for pos, expected := range preFlop {
+		if left := g.playersLeftToAct(g.Players[pos]); left != expected {
+			t.Errorf("Pre-flop: expected %d players left to act behind seat %d, got %d", expected, pos, left)
+		}
+	}
+	if factor := g.positionFactor(g.Players[0]); factor != 0.6 {
+		t.Errorf("Expected the button's position factor to be 0.6, got %.2f", factor)
+	}
+
+	g.Players[4].Status = PlayerStatusFolded
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	postFlop := map[int]int{1: 4, 3: 2, 5: 1, 0: 0}
+	for pos, expected := range postFlop {
+		if left := g.playersLeftToAct(g.Players[pos]); left != expected {
+			t.Errorf("Flop: expected %d players left to act behind seat %d, got %d", expected, pos, left)
+		}
+	}
+}
+
+func TestCPUPreFlopAction_OpensWiderInPosition(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	g.handEvaluator = func(*Game, *Player) float64 { return 18 }
+	profile := defaultAIConfig.Profiles["Tight-Aggressive"]
+	for _, p := range g.Players {
+		p.Profile = &profile
+	}
+	r := rand.New(rand.NewSource(1))
+
+	if action := g.GetCPUAction(g.Players[3], r); action.Type != ActionFold {
+		t.Errorf("Expected a fold under the gun, got %v", action.Type)
+	}
+	if action := g.GetCPUAction(g.Players[0], r); action.Type != ActionCall {
+		t.Errorf("Expected the button to play the hand, got %v", action.Type)
+	}
+	g.Aggressor = g.Players[3]
+	if action := g.GetCPUAction(g.Players[0], r); action.Type != ActionFold {
+		t.Errorf("Expected the button to fold to a raise, got %v", action.Type)
+	}
+}
+
+func TestCPUPostFlopAction_PositionAndInitiative(t *testing.T) {
+	testCases := []struct {
+		name           string
+		betToCall      int
+		equity         float64
+		profile        AIProfile
+		initiative     bool
+		inPosition     bool
+		checked        bool
+		expectedAction ActionType
+	}{
+		{name: "Continuation bets with the initiative", equity: 0.1, profile: AIProfile{ContinuationBetFrequency: 1}, initiative: true, expectedAction: ActionBet},
+		{name: "Checks without the initiative", equity: 0.1, profile: AIProfile{ContinuationBetFrequency: 1}, expectedAction: ActionCheck},
+		{name: "Checks a strong hand out of position", equity: 0.9, profile: AIProfile{AggressionFactor: 1, CheckRaiseFrequency: 1}, expectedAction: ActionCheck},
+		{name: "Bets a strong hand in position", equity: 0.9, profile: AIProfile{AggressionFactor: 1, CheckRaiseFrequency: 1}, inPosition: true, expectedAction: ActionBet},
+		{name: "Check-raises a strong hand", betToCall: 500, equity: 0.9, checked: true, expectedAction: ActionRaise},
+		{name: "Calls with a strong hand without having checked", betToCall: 500, equity: 0.9, expectedAction: ActionCall},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, player := newPostFlopGame(tc.betToCall, tc.equity)
+			g.ActionCloserPos = 0 // YOU has the button and acts last.
+			if tc.inPosition {
+				g.ActionCloserPos = 1
+			}
+			if tc.initiative {
+				g.PreviousAggressor = player
+			}
+			if tc.checked {
+				player.LastActionDesc = "Check"
+			}
+			tc.profile.MinRaiseMultiplier, tc.profile.MaxRaiseMultiplier = 2.0, 3.0
+			player.Profile = &tc.profile
+
+			action := g.GetCPUAction(player, rand.New(rand.NewSource(1)))
+			if action.Type != tc.expectedAction {
+				t.Fatalf("Expected action %v, but got %v", tc.expectedAction, action.Type)
+			}
+			if err := g.ValidateAction(player, action); err != nil {
+				t.Errorf("Expected a legal action, got %+v: %v", action, err)
+			}
+		})
+	}
+}
+
+func TestPrepareNewBettingRound_KeepsPreviousAggressor(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	g.Aggressor = g.Players[0]
+
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	if g.PreviousAggressor != g.Players[0] || g.Aggressor != nil {
+		t.Errorf("Expected the pre-flop raiser to have the initiative on the flop, got %v", g.PreviousAggressor)
+	}
+	g.Phase = PhaseTurn
+	g.PrepareNewBettingRound()
+	if g.PreviousAggressor != nil {
+		t.Errorf("Expected no initiative after a checked-through flop, got %v", g.PreviousAggressor)
+	}
+}
+
+func TestStartNewHand_HeadsUpDealerPostsSmallBlind(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	for hand := 1; hand <= 3; hand++ {
+		g.StartNewHand()
+		dealer, other := g.Players[g.DealerPos], g.Players[1-g.DealerPos]
+		if g.SmallBlindPos != g.DealerPos || dealer.CurrentBet != 50 || other.CurrentBet != 100 {
+			t.Fatalf("Hand %d: expected the dealer to post the small blind, got bets of %d (dealer) and %d", hand, dealer.CurrentBet, other.CurrentBet)
+		}
+
+		g.PrepareNewBettingRound()
+		if g.CurrentTurnPos != g.DealerPos || g.ActionCloserPos != g.BigBlindPos {
+			t.Errorf("Hand %d: expected the dealer to act first pre-flop and the big blind last, got %d and %d", hand, g.CurrentTurnPos, g.ActionCloserPos)
+		}
+		g.Phase = PhaseFlop
+		g.PrepareNewBettingRound()
+		if g.CurrentTurnPos != g.BigBlindPos || g.ActionCloserPos != g.DealerPos {
+			t.Errorf("Hand %d: expected the big blind to act first after the flop and the dealer last, got %d and %d", hand, g.CurrentTurnPos, g.ActionCloserPos)
+		}
+	}
+}
+
+func TestStartNewHand_GoingHeadsUp(t *testing.T) {
+	testCases := []struct {
+		name       string
+		eliminated int // Seat eliminated in a hand where seat 0 has the button.
+		dealer     int // Dealer and small blind of the first heads-up hand.
+		bigBlind   int
+	}{
+		{name: "Dealer eliminated", eliminated: 0, dealer: 2, bigBlind: 1},
+		{name: "Small blind eliminated", eliminated: 1, dealer: 2, bigBlind: 0},
+		{name: "Big blind eliminated", eliminated: 2, dealer: 1, bigBlind: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+			g.StartNewHand() // YOU has the button, CPU1 and CPU2 post the blinds.
+			if g.DealerPos != 0 || g.BigBlindPos != 2 {
+				t.Fatalf("Expected YOU to have the button and CPU2 the big blind, got seats %d and %d", g.DealerPos, g.BigBlindPos)
+			}
+			g.Players[tc.eliminated].Status = PlayerStatusEliminated
+
+			g.StartNewHand()
+			if g.DealerPos != tc.dealer || g.SmallBlindPos != tc.dealer || g.BigBlindPos != tc.bigBlind {
+				t.Errorf("Expected seat %d to have the button and the small blind and seat %d the big blind, got %d, %d and %d",
+					tc.dealer, tc.bigBlind, g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+			}
+		})
+	}
+}
+
+func TestHeadsUpBlinds_SurviveSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	g.StartNewHand()
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	loaded.StartNewHand()
+	if loaded.BigBlindPos == g.BigBlindPos {
+		t.Errorf("Expected the big blind to move on after loading, stayed at seat %d", loaded.BigBlindPos)
+	}
+}
@@ -5,17 +5,16 @@ import (
 	"pls7-cli/pkg/poker"
 	"sort"
 	"strings"
-
-	"github.com/sirupsen/logrus"
 )
 
 // DistributionResult is a data structure that holds the outcome of a pot
 // distribution for a single player. It's used to communicate the results
 // back to the UI or logger.
 type DistributionResult struct {
-	PlayerName string // The name of the player who won a share of the pot.
-	AmountWon  int    // The total amount of chips won by the player.
-	HandDesc   string // A description of the winning hand (e.g., "High: Flush", "Low: 8-7-6-5-4").
+	PlayerName string `json:"player_name"`    // The name of the player who won a share of the pot.
+	AmountWon  int    `json:"amount_won"`     // The total amount of chips won by the player.
+	HandDesc   string `json:"hand_desc"`      // A description of the winning hand (e.g., "High: Flush", "Low: 8-7-6-5-4").
+	Rake       int    `json:"rake,omitempty"` // The chips the house raked from the player's share of the pot, on top of AmountWon.
 }
 
 // PotTier represents a single pot (either the main pot or a side pot) that is
@@ -25,25 +24,30 @@ type PotTier struct {
 	Amount  int       // The total chip amount in this specific pot tier.
 	Players []*Player // The slice of players who are eligible to win this pot tier.
 	MaxBet  int       // The maximum bet amount that players in this tier have contributed.
+	Rake    int       // The chips the house takes from this pot tier, already deducted from Amount.
 }
 
 // AwardPotToLastPlayer handles the simple scenario where all but one player have
-// folded. The remaining player wins the entire pot without a showdown.
+// folded. The remaining player wins the entire pot, less the rake, without a
+// showdown.
 func (g *Game) AwardPotToLastPlayer() []DistributionResult {
 	var winner *Player
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusFolded && p.Status != PlayerStatusEliminated {
+		if p.Status != PlayerStatusFolded && p.IsActive() {
 			winner = p
 			break
 		}
 	}
 
 	if winner != nil {
-		winner.Chips += g.Pot
+		rake := g.potRake()
+		g.HouseChips += rake
+		winner.Chips += g.Pot - rake
 		result := DistributionResult{
 			PlayerName: winner.Name,
-			AmountWon:  g.Pot,
+			AmountWon:  g.Pot - rake,
 			HandDesc:   "takes the pot as the last remaining player",
+			Rake:       rake,
 		}
 		g.Pot = 0
 		return []DistributionResult{result}
@@ -59,15 +63,22 @@ func (g *Game) AwardPotToLastPlayer() []DistributionResult {
 //  1. It identifies all players who contributed to the pot and are eligible for a showdown.
 //  2. It creates "bet tiers" based on the unique amounts players have bet. For example,
 //     if P1 bets 100, P2 bets 200, and P3 bets 200, the tiers are 100 and 200.
+//     Antes count as bets, except for dead money such as a big blind ante, which
+//     nobody has to match.
 //  3. It iterates through these tiers to build one or more `PotTier` objects (side pots).
 //     The first pot tier's amount is calculated from the lowest all-in amount, and only
 //     players who bet at least that much are eligible. Subsequent tiers are built from
-//     the remaining amounts.
-//  4. It then distributes each `PotTier` individually. For each pot, it finds the best
-//     high hand and, if applicable, the best low hand among the eligible players.
-//  5. It splits the pot tier's amount among the high and low winners (or scoops to high
-//     if no qualifying low). It handles ties by splitting the shares further.
-//  6. Finally, it aggregates the results into a slice of DistributionResult for display.
+//     the remaining amounts. The dead money goes into the first tier, the main pot.
+//  4. It takes the rake from the pot tiers, starting with the main pot.
+//  5. It then distributes each `PotTier` individually. If the game has several boards or
+//     the board was run more than once, the pot tier is first split evenly between the
+//     boards (see Game.Boards).
+//     For each pot and board, it finds the best high hand and, if applicable, the best
+//     low hand among the eligible players.
+//  6. It splits the pot tier's amount among the high and low winners (or scoops to high
+//     if no qualifying low). It handles ties by splitting the shares further. The
+//     tier's rake is split the same way, to record how much was raked from each winner.
+//  7. Finally, it aggregates the results into a slice of DistributionResult for display.
 func (g *Game) DistributePot() []DistributionResult {
 	var results []DistributionResult
 	showdownPlayers := g.getShowdownPlayers()
@@ -76,18 +87,22 @@ func (g *Game) DistributePot() []DistributionResult {
 		return results
 	}
 
-	// Create a list of all players who contributed to the pot.
+	// Create a list of all players who contributed to the pot, and set their
+	// dead money aside for the main pot.
 	var allContributors []*Player
+	deadMoney := 0
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusEliminated && p.TotalBetInHand > 0 {
+		if p.IsActive() && p.TotalBetInHand > 0 {
 			allContributors = append(allContributors, p)
+			deadMoney += p.DeadMoney
 		}
 	}
+	liveBet := func(p *Player) int { return p.TotalBetInHand - p.DeadMoney }
 
 	// Create a set of unique bet amounts from all contributors to define the tiers.
 	betTiers := make(map[int]bool)
 	for _, p := range allContributors {
-		betTiers[p.TotalBetInHand] = true
+		betTiers[liveBet(p)] = true
 	}
 
 	// Create a sorted list of the bet tiers (from smallest to largest bet).
@@ -100,8 +115,6 @@ func (g *Game) DistributePot() []DistributionResult {
 	var pots []PotTier
 	lastBet := 0
 
-	logrus.Debugf("DistributePot: Initial Pot: %d, All Contributors: %v, Bet Tiers: %v", g.Pot, getPlayerNames(allContributors), sortedTiers)
-
 	// Build the main and side pots based on the bet tiers.
 	for _, tierBet := range sortedTiers {
 		contribution := tierBet - lastBet
@@ -112,16 +125,19 @@ func (g *Game) DistributePot() []DistributionResult {
 		// Count players who contributed at least this much.
 		numPlayersInTier := 0
 		for _, p := range allContributors {
-			if p.TotalBetInHand >= tierBet {
+			if liveBet(p) >= tierBet {
 				numPlayersInTier++
 			}
 		}
 		tierAmount := contribution * numPlayersInTier
+		if len(pots) == 0 {
+			tierAmount += deadMoney
+		}
 
 		// Find which of the showdown players are eligible for this tier.
 		var eligiblePlayers []*Player
 		for _, sp := range showdownPlayers {
-			if sp.TotalBetInHand >= tierBet {
+			if liveBet(sp) >= tierBet {
 				eligiblePlayers = append(eligiblePlayers, sp)
 			}
 		}
@@ -132,86 +148,35 @@ func (g *Game) DistributePot() []DistributionResult {
 				Players: eligiblePlayers,
 				MaxBet:  tierBet,
 			})
-			logrus.Debugf(
-				"  New PotTier created: Amount: %d, MaxBet: %d, Players: %v",
-				tierAmount, tierBet, getPlayerNames(eligiblePlayers),
-			)
-			if len(eligiblePlayers) == 1 {
-				logrus.Warnf(
-					"  Single player %s eligible for PotTier with amount %d", eligiblePlayers[0].Name, tierAmount,
-				)
-			}
 		}
 		lastBet = tierBet
 	}
 
+	// Take the rake, starting with the main pot.
+	rake := g.potRake()
+	for i := range pots {
+		pots[i].Rake = min(rake, pots[i].Amount)
+		pots[i].Amount -= pots[i].Rake
+		rake -= pots[i].Rake
+		g.HouseChips += pots[i].Rake
+	}
+
 	winnerChipMap := make(map[string]int)
-	winnerHandDescMap := make(map[string]string)
+	winnerRakeMap := make(map[string]int)
+	boards := g.boards()
+	boardDescMaps := make([]map[string]string, len(boards))
+	for b := range boards {
+		boardDescMaps[b] = make(map[string]string)
+	}
 
-	// Distribute each pot tier, starting with the main pot.
+	// Distribute each pot tier, starting with the main pot. With several
+	// boards, the pot is split evenly between them, and each share is won on
+	// its own board.
 	for _, pot := range pots {
-		logrus.Debugf("Distributing PotTier: Amount: %d, MaxBet: %d, Eligible Players: %v", pot.Amount, pot.MaxBet, getPlayerNames(pot.Players))
-		highWinners, bestHighHand := findBestHighHand(pot.Players, g)
-		lowWinners, bestLowHand := findBestLowHand(pot.Players, g)
-		logrus.Debugf(
-			"DistributePot: High Winners: %v, Best High Hand: %s",
-			getPlayerNames(highWinners), bestHighHand,
-		)
-		logrus.Debugf(
-			"DistributePot: Low Winners: %v, Best Low Hand: %s",
-			getPlayerNames(lowWinners), bestLowHand,
-		)
-
-		// Check for a Hi-Lo split if the game rules allow it and there's a qualifying low hand.
-		if g.Rules.LowHand.Enabled && len(lowWinners) > 0 {
-			// Split the pot between high and low winners.
-			lowPot := pot.Amount / 2
-			highPot := pot.Amount - lowPot
-
-			logrus.Debugf("  Split Pot: lowPot: %d, highPot: %d", lowPot, highPot)
-
-			// Distribute the low half of the pot.
-			lowShare := lowPot / len(lowWinners)
-			var lowHandRanks []string
-			for _, c := range bestLowHand.Cards {
-				lowHandRanks = append(lowHandRanks, c.Rank.String())
-			}
-			if len(lowHandRanks) > 0 && lowHandRanks[0] == poker.Ace.String() {
-				lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
-			}
-			lowHandDesc := fmt.Sprintf("Low: %s-High", strings.Join(lowHandRanks, "-"))
-
-			for _, winner := range lowWinners {
-				winner.Chips += lowShare
-				winnerChipMap[winner.Name] += lowShare
-				winnerHandDescMap[winner.Name] = lowHandDesc
-				logrus.Debugf("    %s wins %d from low pot", winner.Name, lowShare)
-			}
-
-			// Distribute the high half of the pot.
-			highShare := highPot / len(highWinners)
-			highHandDesc := fmt.Sprintf("High: %s", bestHighHand.String())
-			for _, winner := range highWinners {
-				winner.Chips += highShare
-				winnerChipMap[winner.Name] += highShare
-				// If a player won both high and low, they "scoop" the pot.
-				if desc, exists := winnerHandDescMap[winner.Name]; exists && strings.HasPrefix(desc, "Low") {
-					winnerHandDescMap[winner.Name] = fmt.Sprintf("Scoop! %s, %s", highHandDesc, desc)
-				} else {
-					winnerHandDescMap[winner.Name] = highHandDesc
-				}
-				logrus.Debugf("    %s wins %d from high pot", winner.Name, highShare)
-			}
-		} else {
-			// If no qualifying low hand, the high hand "scoops" the entire pot.
-			highShare := pot.Amount / len(highWinners)
-			highHandDesc := fmt.Sprintf("High: %s (Scoop)", bestHighHand.String())
-			for _, winner := range highWinners {
-				winner.Chips += highShare
-				winnerChipMap[winner.Name] += highShare
-				winnerHandDescMap[winner.Name] = highHandDesc
-				logrus.Debugf("    %s scoops %d from pot", winner.Name, highShare)
-			}
+		amounts := splitBoards(pot.Amount, len(boards))
+		rakes := splitBoards(pot.Rake, len(boards))
+		for b, board := range boards {
+			g.awardPotShare(pot.Players, amounts[b], rakes[b], board, winnerChipMap, winnerRakeMap, boardDescMaps[b])
 		}
 	}
 
@@ -220,21 +185,114 @@ func (g *Game) DistributePot() []DistributionResult {
 		results = append(results, DistributionResult{
 			PlayerName: name,
 			AmountWon:  amount,
-			HandDesc:   winnerHandDescMap[name],
+			HandDesc:   g.boardsHandDesc(boardDescMaps, name),
+			Rake:       winnerRakeMap[name],
 		})
 	}
 
 	g.Pot = 0
-	logrus.Debugf("DistributePot: Final results: %+v", results)
 	return results
 }
 
+// awardPotShare awards a share of a pot tier to the best high and low hands
+// among the eligible players on the given board, and records the chips, the
+// rake and the hand descriptions in the maps.
+func (g *Game) awardPotShare(players []*Player, amount, rake int, board []poker.Card, winnerChipMap, winnerRakeMap map[string]int, winnerHandDescMap map[string]string) {
+	highWinners, bestHighHand := findBestHighHand(players, board, g)
+	lowWinners, bestLowHand := findBestLowHand(players, board, g)
+
+	// Check for a Hi-Lo split if the game rules allow it and there's a qualifying low hand.
+	if g.Rules.LowHand.Enabled && len(lowWinners) > 0 {
+		// Split the pot between high and low winners.
+		lowPot := amount / 2
+		highPot := amount - lowPot
+		lowRake := rake / 2
+		highRake := rake - lowRake
+
+		// Distribute the low half of the pot.
+		lowShares := g.splitShares(lowPot, lowWinners)
+		lowRakes := g.splitShares(lowRake, lowWinners)
+		var lowHandRanks []string
+		for _, c := range bestLowHand.Cards {
+			lowHandRanks = append(lowHandRanks, c.Rank.String())
+		}
+		if len(lowHandRanks) > 0 && lowHandRanks[0] == poker.Ace.String() {
+			lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
+		}
+		lowHandDesc := fmt.Sprintf("Low: %s-High", strings.Join(lowHandRanks, "-"))
+
+		for i, winner := range lowWinners {
+			winner.Chips += lowShares[i]
+			winnerChipMap[winner.Name] += lowShares[i]
+			winnerRakeMap[winner.Name] += lowRakes[i]
+			winnerHandDescMap[winner.Name] = lowHandDesc
+		}
+
+		// Distribute the high half of the pot.
+		highShares := g.splitShares(highPot, highWinners)
+		highRakes := g.splitShares(highRake, highWinners)
+		highHandDesc := fmt.Sprintf("High: %s", bestHighHand.String())
+		for i, winner := range highWinners {
+			winner.Chips += highShares[i]
+			winnerChipMap[winner.Name] += highShares[i]
+			winnerRakeMap[winner.Name] += highRakes[i]
+			// If a player won both high and low, they "scoop" the pot.
+			if desc, exists := winnerHandDescMap[winner.Name]; exists && strings.HasPrefix(desc, "Low") {
+				winnerHandDescMap[winner.Name] = fmt.Sprintf("Scoop! %s, %s", highHandDesc, desc)
+			} else {
+				winnerHandDescMap[winner.Name] = highHandDesc
+			}
+		}
+	} else {
+		// If no qualifying low hand, the high hand "scoops" the entire pot.
+		highShares := g.splitShares(amount, highWinners)
+		highRakes := g.splitShares(rake, highWinners)
+		highHandDesc := fmt.Sprintf("High: %s (Scoop)", bestHighHand.String())
+		for i, winner := range highWinners {
+			winner.Chips += highShares[i]
+			winnerChipMap[winner.Name] += highShares[i]
+			winnerRakeMap[winner.Name] += highRakes[i]
+			winnerHandDescMap[winner.Name] = highHandDesc
+		}
+	}
+}
+
+// splitBoards divides an amount evenly between the boards of a pot. Chips
+// that cannot be divided evenly go to the first boards.
+func splitBoards(amount, boards int) []int {
+	shares := make([]int, boards)
+	for i := range shares {
+		shares[i] = amount / boards
+		if i < amount%boards {
+			shares[i]++
+		}
+	}
+	return shares
+}
+
+// boardsHandDesc describes the hands with which a player won. With several
+// boards, each board the player won on is named, as in "Board 1: High: Flush
+// (Scoop); Board 2: Low: 7-5-4-3-A-High", or "Run 1: ..." for a hand run more
+// than once.
+func (g *Game) boardsHandDesc(boardDescMaps []map[string]string, name string) string {
+	if len(boardDescMaps) == 1 {
+		return boardDescMaps[0][name]
+	}
+	var descs []string
+	for b, descMap := range boardDescMaps {
+		if desc, ok := descMap[name]; ok {
+			descs = append(descs, fmt.Sprintf("%s %d: %s", g.boardLabel(), b+1, desc))
+		}
+	}
+	return strings.Join(descs, "; ")
+}
+
 // getShowdownPlayers returns a slice of players who are still active in the
 // hand and thus eligible to participate in the showdown.
 func (g *Game) getShowdownPlayers() []*Player {
 	var active []*Player
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusFolded && p.Status != PlayerStatusEliminated {
+		if p.Status != PlayerStatusFolded && p.IsActive() {
 			active = append(active, p)
 		}
 	}
@@ -242,11 +300,11 @@ func (g *Game) getShowdownPlayers() []*Player {
 }
 
 // findBestHighHand iterates through a list of players and determines who has the
-// best high hand according to the game's rules. It returns the winning player(s)
-// (in case of a tie) and the best hand result.
-func findBestHighHand(players []*Player, g *Game) (winners []*Player, bestHand *poker.HandResult) {
+// best high hand on the board according to the game's rules. It returns the
+// winning player(s) (in case of a tie) and the best hand result.
+func findBestHighHand(players []*Player, board []poker.Card, g *Game) (winners []*Player, bestHand *poker.HandResult) {
 	for _, p := range players {
-		highHand, _ := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
+		highHand, _ := poker.EvaluateHand(p.Hand, board, g.Rules)
 		if highHand == nil {
 			continue
 		}
@@ -261,11 +319,11 @@ func findBestHighHand(players []*Player, g *Game) (winners []*Player, bestHand *
 }
 
 // findBestLowHand iterates through a list of players and determines who has the
-// best qualifying low hand. It returns the winning player(s) and the best low hand.
-// If no player has a qualifying low hand, it returns nil.
-func findBestLowHand(players []*Player, g *Game) (winners []*Player, bestHand *poker.HandResult) {
+// best qualifying low hand on the board. It returns the winning player(s) and
+// the best low hand. If no player has a qualifying low hand, it returns nil.
+func findBestLowHand(players []*Player, board []poker.Card, g *Game) (winners []*Player, bestHand *poker.HandResult) {
 	for _, p := range players {
-		_, lowHand := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
+		_, lowHand := poker.EvaluateHand(p.Hand, board, g.Rules)
 		if lowHand == nil {
 			continue
 		}
@@ -302,11 +360,27 @@ func compareHandResults(h1, h2 *poker.HandResult) int {
 	return 0 // Hands are identical.
 }
 
-// getPlayerNames is a helper function for logging, returning a slice of player names.
-func getPlayerNames(players []*Player) []string {
-	names := make([]string, len(players))
-	for i, p := range players {
-		names[i] = p.Name
+// splitShares divides an amount evenly among the winners and returns each
+// winner's share, in the order of winners. Chips that cannot be divided evenly
+// go one at a time to the winners seated closest to the left of the button.
+func (g *Game) splitShares(amount int, winners []*Player) []int {
+	shares := make([]int, len(winners))
+	for i := range shares {
+		shares[i] = amount / len(winners)
+	}
+
+	order := make([]int, len(winners))
+	for i := range order {
+		order[i] = i
+	}
+	seatsAfterButton := func(p *Player) int {
+		return (p.Position - g.DealerPos - 1 + 2*len(g.Players)) % len(g.Players)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return seatsAfterButton(winners[order[a]]) < seatsAfterButton(winners[order[b]])
+	})
+	for i := 0; i < amount%len(winners); i++ {
+		shares[order[i]]++
 	}
-	return names
+	return shares
 }
@@ -315,3 +315,29 @@ func TestDistributePot_PLO8_HiLoSplit(t *testing.T) {
 		t.Errorf("Expected pot to be 0 after distribution, but got %d", g.Pot)
 	}
 }
+
+// TestDistributePot_OddChipGoesLeftOfButton tests that a pot that cannot be split
+// evenly between tied players loses no chips: the odd chip goes to the winner
+// seated closest to the left of the button.
+func TestDistributePot_OddChipGoesLeftOfButton(t *testing.T) {
+	playerNames := []string{"YOU", "CPU1", "CPU2"}
+	rules := loadRule(t, "nlh.yml")
+	g := NewGame(playerNames, 0, 50, 100, DifficultyMedium, rules, true, false, 0)
+	g.DealerPos = 0
+	g.CommunityCards = poker.CardsFromStrings("As Ks Qs Js Ts") // Everyone plays the board.
+
+	g.Players[0].Hand = poker.CardsFromStrings("2c 3d")
+	g.Players[0].TotalBetInHand = 1000
+	g.Players[1].Hand = poker.CardsFromStrings("2d 3c")
+	g.Players[1].TotalBetInHand = 1000
+	g.Players[2].Status = PlayerStatusFolded
+	g.Players[2].TotalBetInHand = 101
+	g.Pot = 2101
+
+	g.DistributePot()
+
+	if g.Players[0].Chips != 1050 || g.Players[1].Chips != 1051 {
+		t.Errorf("Expected YOU to win 1050 and CPU1 (left of the button) 1051, got %d and %d",
+			g.Players[0].Chips, g.Players[1].Chips)
+	}
+}
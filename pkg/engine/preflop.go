@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"pls7-cli/pkg/poker"
+	"sort"
+)
+
+// byRank is a helper type that implements the sort.Interface for a slice of
+// poker.Rank, allowing them to be sorted. It sorts in descending order (Ace high).
+type byRank []poker.Rank
+
+func (a byRank) Len() int           { return len(a) }
+func (a byRank) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a byRank) Less(i, j int) bool { return a[i] > a[j] } // Sort descending
+
+// highCardPoints are the pre-flop points for hole cards of rank Ten or higher.
+var highCardPoints = map[poker.Rank]float64{
+	poker.Ace: 10, poker.King: 8, poker.Queen: 7, poker.Jack: 6, poker.Ten: 5,
+}
+
+// preflopScore rates the potential of hole cards before the flop with the
+// heuristic for the game variant. Games where exactly some of the hole cards
+// must be used, like Omaha, or with more than 3 hole cards are scored by how
+// well the cards work together; other games by the strength of the few cards
+// that make the hand. Hi-lo games add points for the chance of a good low.
+//
+// The scores are scaled so that every variant spreads its starting hands over
+// the same range as 3-card PLS hands, letting the AI profiles' thresholds pick
+// about the same share of hands in each game.
+func preflopScore(hand []poker.Card, rules *poker.GameRules) float64 {
+	var score float64
+	switch {
+	case rules.HoleCards.UseConstraint == "exact" || len(hand) > 3:
+		score = omahaScore(hand) * 0.85 // More cards give more points to scale down.
+	case len(hand) == 2:
+		score = holdemScore(hand) * 5 / 3 // Fewer cards give fewer points to scale up.
+	default:
+		score = holdemScore(hand)
+	}
+	if rules.LowHand.Enabled {
+		score += lowPotentialScore(hand, rules.LowHand.MaxRank)
+	}
+	return score
+}
+
+// holdemScore scores 2 or 3 hole cards for games where any of them may be used,
+// such as Hold'em and PLS, considering:
+// - High card values (points for cards Ten and above).
+// - A significant bonus for pairs.
+// - A small bonus for suited cards.
+// - A bonus for connected cards (cards in sequence).
+func holdemScore(hand []poker.Card) float64 {
+	var score float64
+
+	// 1. High card points for cards Ten or higher.
+	for _, c := range hand {
+		score += highCardPoints[c.Rank]
+	}
+
+	// 2. Add a large bonus for having a pair in the hole cards.
+	if len(hand) >= 3 {
+		if hand[0].Rank == hand[1].Rank || hand[0].Rank == hand[2].Rank || hand[1].Rank == hand[2].Rank {
+			pairRank := hand[0].Rank
+			if hand[1].Rank == hand[2].Rank {
+				pairRank = hand[1].Rank
+			}
+			score += 15 + float64(pairRank) // Major bonus for pairs
+		}
+	} else if len(hand) == 2 {
+		if hand[0].Rank == hand[1].Rank {
+			score += 15 + float64(hand[0].Rank)
+		}
+	}
+
+	// 3. Add a small bonus if any cards are suited.
+	if len(hand) >= 3 {
+		if hand[0].Suit == hand[1].Suit || hand[0].Suit == hand[2].Suit || hand[1].Suit == hand[2].Suit {
+			score += 2
+		}
+	} else if len(hand) == 2 {
+		if hand[0].Suit == hand[1].Suit {
+			score += 2
+		}
+	}
+
+	// 4. Add a bonus for card connectivity (potential to make a straight).
+	if len(hand) >= 3 {
+		ranks := []poker.Rank{hand[0].Rank, hand[1].Rank, hand[2].Rank}
+		// Sort ranks in descending order for consistent gap calculation.
+		sort.Sort(byRank(ranks))
+
+		// Check for connectors.
+		if ranks[0] == ranks[1]+1 && ranks[1] == ranks[2]+1 { // 3-card straight
+			score += 5
+		} else if (ranks[0] == ranks[1]+1) || (ranks[1] == ranks[2]+1) { // 2-card connector
+			score += 2
+		}
+
+		// Bonus for cards being high and close together.
+		if ranks[0] >= poker.Ten && (ranks[0]-ranks[2] < 5) {
+			score += 1
+		}
+	} else if len(hand) == 2 {
+		ranks := []poker.Rank{hand[0].Rank, hand[1].Rank}
+		sort.Sort(byRank(ranks))
+		if ranks[0] == ranks[1]+1 { // connectors
+			score += 2
+		}
+		if ranks[0] >= poker.Ten && (int(ranks[0])-int(ranks[1]) < 5) {
+			score += 1
+		}
+	}
+
+	return score
+}
+
+// omahaScore scores hole cards for games where exactly two of them must be used,
+// such as Omaha. Since every hand is made from a pair of hole cards, it rewards
+// cards that work together, considering:
+// - High card values, weighted down since more cards hold more of them.
+// - A bonus for each pair, and a penalty for three or four of a kind.
+// - A bonus for each suit held twice (more with the Ace), less for three or more.
+// - A bonus for each pair of cards close enough to make a straight together.
+func omahaScore(hand []poker.Card) float64 {
+	var score float64
+	rankCounts := make(map[poker.Rank]int)
+	suitCounts := make(map[poker.Suit]int)
+	for _, c := range hand {
+		score += highCardPoints[c.Rank] * 0.75
+		rankCounts[c.Rank]++
+		suitCounts[c.Suit]++
+	}
+
+	var ranks []poker.Rank
+	for rank, count := range rankCounts {
+		ranks = append(ranks, rank)
+		if count >= 2 {
+			score += 8 + float64(rank)
+		}
+		if count >= 3 {
+			score -= 10
+		}
+	}
+
+	for _, count := range suitCounts {
+		if count >= 2 {
+			score += 2
+		}
+		if count >= 3 {
+			score -= 1
+		}
+	}
+	for _, c := range hand {
+		if c.Rank == poker.Ace && suitCounts[c.Suit] >= 2 {
+			score += 2 // A draw to the nut flush.
+		}
+	}
+
+	sort.Sort(byRank(ranks))
+	for i := 1; i < len(ranks); i++ {
+		switch ranks[i-1] - ranks[i] {
+		case 1:
+			score += 2
+		case 2:
+			score += 1
+		}
+	}
+	return score
+}
+
+// lowPotentialScore rates the chance of the hole cards making a good low hand in
+// hi-lo games. An Ace with a Two or Three draws to the best possible low, and
+// more low cards keep the low alive when the board pairs one of them.
+func lowPotentialScore(hand []poker.Card, maxRank int) float64 {
+	lows := make(map[poker.Rank]bool)
+	for _, c := range hand {
+		if c.Rank == poker.Ace || int(c.Rank) <= maxRank {
+			lows[c.Rank] = true
+		}
+	}
+	if len(lows) < 2 {
+		return 0
+	}
+
+	score := 2 * float64(len(lows)-2)
+	switch {
+	case lows[poker.Ace] && lows[poker.Two]:
+		score += 8
+	case lows[poker.Ace] && lows[poker.Three]:
+		score += 5
+	case lows[poker.Two] && lows[poker.Three]:
+		score += 3
+	}
+	return score
+}
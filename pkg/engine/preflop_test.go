@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"pls7-cli/internal/config"
+	"pls7-cli/pkg/poker"
+	"testing"
+)
+
+func loadRulesForTest(t *testing.T, name string) *poker.GameRules {
+	t.Helper()
+	rules, err := config.LoadGameRulesFromFile("../../rules/" + name + ".yml")
+	if err != nil {
+		t.Fatalf("Failed to load game rules %s: %v", name, err)
+	}
+	return rules
+}
+
+func TestPreflopScore_RanksHandsPerVariant(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rule   string
+		better string
+		worse  string
+	}{
+		{name: "NLH - Big cards beat junk", rule: "nlh", better: "As Ks", worse: "7c 2d"},
+		{name: "NLH - Pairs beat unpaired cards", rule: "nlh", better: "9s 9d", worse: "Ks Jd"},
+		{name: "PLO - Aces with Kings double-suited", rule: "plo", better: "As Ad Ks Kd", worse: "Ah Ac Kh 7d"},
+		{name: "PLO - Rundowns beat disconnected cards", rule: "plo", better: "Jh Tc 9h 8c", worse: "Kh 9c 6d 2s"},
+		{name: "PLO - Trips waste a card", rule: "plo", better: "As Ad Kh Qd", worse: "As Ad Ah Kd"},
+		{name: "PLO8 - Low potential counts", rule: "plo8", better: "As 2s 3d Kd", worse: "Ks Qd 9h 9c"},
+		{name: "PLO - No low potential without a low", rule: "plo", better: "Ks Qd 9h 9c", worse: "As 2s 3d Kd"},
+		{name: "PLS7 - A-2 beats A-8", rule: "pls7", better: "Ah 2c 9d", worse: "Ah 8c 9d"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := loadRulesForTest(t, tc.rule)
+			better := preflopScore(poker.CardsFromStrings(tc.better), rules)
+			worse := preflopScore(poker.CardsFromStrings(tc.worse), rules)
+			if better <= worse {
+				t.Errorf("Expected %s (%.2f) to score above %s (%.2f)", tc.better, better, tc.worse, worse)
+			}
+		})
+	}
+}
+
+func TestPreflopScore_PlaysSimilarShareOfHands(t *testing.T) {
+	// The Tight-Aggressive profile's play threshold should pick about the top
+	// 20% of starting hands in every variant.
+	threshold := defaultAIConfig.Profiles["Tight-Aggressive"].PlayHandThreshold
+	const hands = 5000
+	for _, name := range []string{"nlh", "pls", "pls7", "plo", "plo8"} {
+		t.Run(name, func(t *testing.T) {
+			rules := loadRulesForTest(t, name)
+			r := poker.NewRand(1)
+			playable := 0
+			for i := 0; i < hands; i++ {
+				deck := poker.NewDeck()
+				deck.Shuffle(r)
+				if preflopScore(deck.Cards[:rules.HoleCards.Count], rules) >= threshold {
+					playable++
+				}
+			}
+			if share := float64(playable) / hands; share < 0.15 || share > 0.3 {
+				t.Errorf("Expected 15-30%% of hands to score %.0f or more, got %.1f%%", threshold, share*100)
+			}
+		})
+	}
+}
+
+func TestLowPotentialScore(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hand     string
+		maxRank  int
+		expected float64
+	}{
+		{name: "A-2 draws to the nut low", hand: "Ah 2c Kd", maxRank: 7, expected: 8},
+		{name: "A-3 draws to the second nut low", hand: "Ah 3c Kd", maxRank: 7, expected: 5},
+		{name: "A-2-3 keeps the low when the board pairs", hand: "Ah 2c 3d", maxRank: 7, expected: 10},
+		{name: "Cards above the max rank do not count", hand: "Ah 8c 2d 9s", maxRank: 7, expected: 8},
+		{name: "A single low card has no low potential", hand: "Ah Kc Qd", maxRank: 8, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score := lowPotentialScore(poker.CardsFromStrings(tc.hand), tc.maxRank)
+			if score != tc.expected {
+				t.Errorf("Expected a low potential of %.0f, got %.0f", tc.expected, score)
+			}
+		})
+	}
+}
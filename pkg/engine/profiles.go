@@ -0,0 +1,228 @@
+package engine
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultAIConfigYAML holds the built-in AI profiles and difficulty line-ups.
+//
+//go:embed ai_profiles.yml
+var defaultAIConfigYAML []byte
+
+// defaultAIConfig is the parsed built-in AI configuration. New games assign
+// their CPU players' profiles from it.
+var defaultAIConfig = mustParseAIConfig(defaultAIConfigYAML)
+
+// AIConfig defines the AI profiles CPU players can use and which of them each
+// difficulty seats. It is loaded from YAML; see ai_profiles.yml for the format
+// and the built-in defaults.
+type AIConfig struct {
+	// Profiles maps profile names to their parameters.
+	Profiles map[string]AIProfile `yaml:"profiles"`
+	// LineUps maps each difficulty (easy, medium, hard) to the profiles of CPU
+	// seats 1 to 5, in seat order. Tables with more CPUs than listed start over
+	// from the first profile.
+	LineUps map[string][]string `yaml:"difficulties,omitempty"`
+}
+
+// mustParseAIConfig parses and validates the built-in AI configuration.
+func mustParseAIConfig(data []byte) *AIConfig {
+	c, err := parseAIConfig(data)
+	if err == nil {
+		err = c.Validate()
+	}
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in AI profiles: %v", err))
+	}
+	return c
+}
+
+// parseAIConfig unmarshals an AI configuration from YAML, naming each profile
+// after its key.
+func parseAIConfig(data []byte) (*AIConfig, error) {
+	var c AIConfig
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	for name, profile := range c.Profiles {
+		profile.Name = name
+		c.Profiles[name] = profile
+	}
+	return &c, nil
+}
+
+// DefaultAIConfig returns a copy of the built-in AI configuration.
+func DefaultAIConfig() *AIConfig {
+	c := &AIConfig{
+		Profiles: make(map[string]AIProfile),
+		LineUps:  make(map[string][]string),
+	}
+	c.merge(defaultAIConfig)
+	return c
+}
+
+// LoadAIConfig loads the built-in AI configuration and the given user files on
+// top of it. Profiles in the files are added to the built-in ones, replacing
+// profiles of the same name, and line-ups in the files replace those of the
+// same difficulty. The result is validated.
+func LoadAIConfig(paths ...string) (*AIConfig, error) {
+	c := DefaultAIConfig()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		user, err := parseAIConfig(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		c.merge(user)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// merge adds the profiles and line-ups of other to c, replacing any of the same name.
+func (c *AIConfig) merge(other *AIConfig) {
+	for name, profile := range other.Profiles {
+		c.Profiles[name] = profile
+	}
+	for difficulty, lineUp := range other.LineUps {
+		c.LineUps[difficulty] = append([]string(nil), lineUp...)
+	}
+}
+
+// Validate checks that every profile's parameters are in range and that every
+// difficulty has a line-up of known profiles.
+func (c *AIConfig) Validate() error {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := c.Profiles[name].Validate(); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+
+	for _, d := range []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard} {
+		key := lineUpKey(d)
+		lineUp := c.LineUps[key]
+		if len(lineUp) == 0 {
+			return fmt.Errorf("difficulty %q has no profiles", key)
+		}
+		for _, name := range lineUp {
+			if _, ok := c.Profiles[name]; !ok {
+				return fmt.Errorf("difficulty %q uses unknown profile %q", key, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that the profile's thresholds, frequencies, raise multipliers
+// and position bonus are within their allowed ranges.
+func (p AIProfile) Validate() error {
+	if p.PlayHandThreshold < 0 || p.PlayHandThreshold > 100 {
+		return fmt.Errorf("play_hand_threshold must be between 0 and 100, got %v", p.PlayHandThreshold)
+	}
+	if p.RaiseHandThreshold < p.PlayHandThreshold || p.RaiseHandThreshold > 100 {
+		return fmt.Errorf("raise_hand_threshold must be between play_hand_threshold (%v) and 100, got %v", p.PlayHandThreshold, p.RaiseHandThreshold)
+	}
+	if p.BluffingFrequency < 0 || p.BluffingFrequency > 1 {
+		return fmt.Errorf("bluffing_frequency must be between 0 and 1, got %v", p.BluffingFrequency)
+	}
+	if p.AggressionFactor < 0 || p.AggressionFactor > 1 {
+		return fmt.Errorf("aggression_factor must be between 0 and 1, got %v", p.AggressionFactor)
+	}
+	if p.MinRaiseMultiplier < 1 || p.MinRaiseMultiplier > 10 {
+		return fmt.Errorf("min_raise_multiplier must be between 1 and 10, got %v", p.MinRaiseMultiplier)
+	}
+	if p.MaxRaiseMultiplier < p.MinRaiseMultiplier || p.MaxRaiseMultiplier > 10 {
+		return fmt.Errorf("max_raise_multiplier must be between min_raise_multiplier (%v) and 10, got %v", p.MinRaiseMultiplier, p.MaxRaiseMultiplier)
+	}
+	if p.PositionBonus < 0 || p.PositionBonus > p.PlayHandThreshold {
+		return fmt.Errorf("position_bonus must be between 0 and play_hand_threshold (%v), got %v", p.PlayHandThreshold, p.PositionBonus)
+	}
+	if p.ContinuationBetFrequency < 0 || p.ContinuationBetFrequency > 1 {
+		return fmt.Errorf("continuation_bet_frequency must be between 0 and 1, got %v", p.ContinuationBetFrequency)
+	}
+	if p.CheckRaiseFrequency < 0 || p.CheckRaiseFrequency > 1 {
+		return fmt.Errorf("check_raise_frequency must be between 0 and 1, got %v", p.CheckRaiseFrequency)
+	}
+	return nil
+}
+
+// lineUpKey returns the name of the difficulty's line-up in the configuration.
+func lineUpKey(d Difficulty) string {
+	return strings.ToLower(d.String())
+}
+
+// Profile returns the profile with the given name.
+func (c *AIConfig) Profile(name string) (AIProfile, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return AIProfile{}, fmt.Errorf("unknown AI profile: %s", name)
+	}
+	return profile, nil
+}
+
+// LineUp returns the profile names for the given number of CPU players at the
+// given difficulty.
+func (c *AIConfig) LineUp(difficulty Difficulty, numCPUs int) ([]string, error) {
+	if numCPUs < 1 || numCPUs > 5 {
+		return []string{}, fmt.Errorf("numCPUs must be between 1 and 5, got %d", numCPUs)
+	}
+	lineUp, ok := c.LineUps[lineUpKey(difficulty)]
+	if !ok || len(lineUp) == 0 {
+		return []string{}, fmt.Errorf("unknown difficulty: %v", difficulty)
+	}
+	names := make([]string, numCPUs)
+	for i := range names {
+		names[i] = lineUp[i%len(lineUp)]
+	}
+	return names, nil
+}
+
+// AssignAIProfiles gives the CPU players the profiles of the game's difficulty
+// from the configuration, as NewGame does with the built-in configuration. The
+// CPU at seat i gets the i-th profile of the line-up.
+func (g *Game) AssignAIProfiles(c *AIConfig) error {
+	lineUp, err := c.LineUp(g.Difficulty, len(g.Players)-1)
+	if err != nil {
+		return err
+	}
+	for i, p := range g.Players[1:] {
+		if !p.IsCPU {
+			continue
+		}
+		profile, err := c.Profile(lineUp[i])
+		if err != nil {
+			return err
+		}
+		p.Profile = &profile
+	}
+	return nil
+}
+
+// SetAIProfile gives the CPU player at the given seat the given profile.
+func (g *Game) SetAIProfile(pos int, profile AIProfile) error {
+	if pos < 0 || pos >= len(g.Players) {
+		return fmt.Errorf("seat %d is out of range (0-%d)", pos, len(g.Players)-1)
+	}
+	p := g.Players[pos]
+	if !p.IsCPU {
+		return fmt.Errorf("seat %d is not a CPU seat", pos)
+	}
+	p.Profile = &profile
+	return nil
+}
@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeAIConfig writes an AI configuration file to a temporary directory.
+func writeAIConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "profiles.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write AI config: %v", err)
+	}
+	return path
+}
+
+func TestDefaultAIConfig(t *testing.T) {
+	c := DefaultAIConfig()
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Expected the built-in AI config to be valid, got %v", err)
+	}
+	if profile, err := c.Profile("Tight-Aggressive"); err != nil || profile.Name != "Tight-Aggressive" || profile.PlayHandThreshold != 20 {
+		t.Errorf("Expected the Tight-Aggressive profile, got %+v (%v)", profile, err)
+	}
+
+	lineUp, err := c.LineUp(DifficultyMedium, 5)
+	expected := []string{"Loose-Passive", "Loose-Passive", "Tight-Passive", "Tight-Passive", "Tight-Passive"}
+	if err != nil || !reflect.DeepEqual(lineUp, expected) {
+		t.Errorf("Expected the medium line-up %v, got %v (%v)", expected, lineUp, err)
+	}
+	if _, err := c.LineUp(DifficultyMedium, 6); err == nil {
+		t.Error("Expected an error for more than 5 CPUs")
+	}
+}
+
+func TestLoadAIConfig_MergesUserFiles(t *testing.T) {
+	path := writeAIConfig(t, `
+profiles:
+  Maniac:
+    play_hand_threshold: 0
+    raise_hand_threshold: 5
+    bluffing_frequency: 0.8
+    aggression_factor: 1
+    min_raise_multiplier: 4
+    max_raise_multiplier: 8
+difficulties:
+  hard: [Maniac, Tight-Aggressive]
+`)
+
+	c, err := LoadAIConfig(path)
+	if err != nil {
+		t.Fatalf("Expected the user file to load, got %v", err)
+	}
+	if profile, err := c.Profile("Maniac"); err != nil || profile.Name != "Maniac" || profile.BluffingFrequency != 0.8 {
+		t.Errorf("Expected the Maniac profile, got %+v (%v)", profile, err)
+	}
+	if _, err := c.Profile("Loose-Passive"); err != nil {
+		t.Errorf("Expected the built-in profiles to be kept, got %v", err)
+	}
+
+	// Short line-ups start over from the first profile.
+	lineUp, _ := c.LineUp(DifficultyHard, 5)
+	expected := []string{"Maniac", "Tight-Aggressive", "Maniac", "Tight-Aggressive", "Maniac"}
+	if !reflect.DeepEqual(lineUp, expected) {
+		t.Errorf("Expected the hard line-up %v, got %v", expected, lineUp)
+	}
+	if lineUp, _ := DefaultAIConfig().LineUp(DifficultyHard, 1); lineUp[0] != "Tight-Passive" {
+		t.Errorf("Expected the built-in line-up to be unchanged, got %v", lineUp)
+	}
+}
+
+func TestLoadAIConfig_ValidatesRanges(t *testing.T) {
+	testCases := []struct {
+		name    string
+		profile string
+		errPart string
+	}{
+		{name: "Play threshold too high", profile: "play_hand_threshold: 120\nraise_hand_threshold: 130", errPart: "play_hand_threshold"},
+		{name: "Raise threshold below play threshold", profile: "play_hand_threshold: 20\nraise_hand_threshold: 10", errPart: "raise_hand_threshold"},
+		{name: "Bluffing frequency above 1", profile: "raise_hand_threshold: 10\nbluffing_frequency: 1.5", errPart: "bluffing_frequency"},
+		{name: "Negative aggression", profile: "raise_hand_threshold: 10\naggression_factor: -0.1", errPart: "aggression_factor"},
+		{name: "Missing raise multipliers", profile: "raise_hand_threshold: 10", errPart: "min_raise_multiplier"},
+		{name: "Max multiplier below min", profile: "raise_hand_threshold: 10\nmin_raise_multiplier: 3\nmax_raise_multiplier: 2", errPart: "max_raise_multiplier"},
+		{name: "Position bonus above play threshold", profile: "play_hand_threshold: 5\nraise_hand_threshold: 10\nmin_raise_multiplier: 2\nmax_raise_multiplier: 3\nposition_bonus: 8", errPart: "position_bonus"},
+		{name: "Check-raise frequency above 1", profile: "raise_hand_threshold: 10\nmin_raise_multiplier: 2\nmax_raise_multiplier: 3\ncheck_raise_frequency: 2", errPart: "check_raise_frequency"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			indented := "    " + strings.ReplaceAll(tc.profile, "\n", "\n    ")
+			path := writeAIConfig(t, "profiles:\n  Broken:\n"+indented+"\n")
+			_, err := LoadAIConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tc.errPart) || !strings.Contains(err.Error(), "Broken") {
+				t.Errorf("Expected an error about %s of profile Broken, got %v", tc.errPart, err)
+			}
+		})
+	}
+
+	path := writeAIConfig(t, "difficulties:\n  easy: [Nobody]\n")
+	if _, err := LoadAIConfig(path); err == nil || !strings.Contains(err.Error(), "Nobody") {
+		t.Errorf("Expected an error about the unknown profile, got %v", err)
+	}
+}
+
+func TestGame_AssignAndSetAIProfiles(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	path := writeAIConfig(t, "difficulties:\n  medium: [Tight-Aggressive, Loose-Aggressive]\n")
+	c, err := LoadAIConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load AI config: %v", err)
+	}
+
+	if err := g.AssignAIProfiles(c); err != nil {
+		t.Fatalf("Expected the profiles to be assigned, got %v", err)
+	}
+	if g.Players[0].Profile != nil || g.Players[1].Profile.Name != "Tight-Aggressive" || g.Players[2].Profile.Name != "Loose-Aggressive" {
+		t.Errorf("Expected the medium line-up on the CPU seats, got %v and %v", g.Players[1].Profile, g.Players[2].Profile)
+	}
+
+	profile, _ := c.Profile("Tight-Passive")
+	if err := g.SetAIProfile(2, profile); err != nil || g.Players[2].Profile.Name != "Tight-Passive" {
+		t.Errorf("Expected seat 2 to get the Tight-Passive profile, got %v (%v)", g.Players[2].Profile, err)
+	}
+	if err := g.SetAIProfile(0, profile); err == nil {
+		t.Error("Expected an error for a human seat")
+	}
+	if err := g.SetAIProfile(3, profile); err == nil {
+		t.Error("Expected an error for a seat out of range")
+	}
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rake describes what the house takes from a game: a share of every pot, and
+// a time charge every player pays to be dealt in. The zero value takes
+// nothing. Everything the house takes is added to Game.HouseChips.
+type Rake struct {
+	// Percent is the share of each pot the house takes, from 0 to 100.
+	Percent float64 `json:"percent,omitempty"`
+	// Cap is the most the house takes from the pot of a hand. 0 means no cap.
+	Cap int `json:"cap,omitempty"`
+	// NoFlopNoDrop takes no rake from hands that end before the flop.
+	NoFlopNoDrop bool `json:"no_flop_no_drop,omitempty"`
+	// TimeCharge is the fee every player dealt in pays every TimeChargeHands
+	// hands, before the antes and blinds. A player whose stack would not
+	// cover it is not charged.
+	TimeCharge int `json:"time_charge,omitempty"`
+	// TimeChargeHands is how many hands a time charge pays for. 0 means
+	// every hand.
+	TimeChargeHands int `json:"time_charge_hands,omitempty"`
+}
+
+// Validate checks that the rake's parameters are in range.
+func (r Rake) Validate() error {
+	if r.Percent < 0 || r.Percent > 100 {
+		return fmt.Errorf("the rake must be between 0 and 100 percent, got %g", r.Percent)
+	}
+	if r.Cap < 0 {
+		return fmt.Errorf("the rake cap must not be negative, got %d", r.Cap)
+	}
+	if r.TimeCharge < 0 || r.TimeChargeHands < 0 {
+		return fmt.Errorf("the time charge and its hands must not be negative, got %d every %d hands", r.TimeCharge, r.TimeChargeHands)
+	}
+	return nil
+}
+
+// chargeTime collects the time charge from every player dealt in, if one is
+// due this hand.
+func (g *Game) chargeTime() {
+	if g.Rake.TimeCharge == 0 || (g.HandCount-1)%max(g.Rake.TimeChargeHands, 1) != 0 {
+		return
+	}
+	for _, p := range g.Players {
+		if p.IsActive() && p.Chips > g.Rake.TimeCharge {
+			p.Chips -= g.Rake.TimeCharge
+			g.HouseChips += g.Rake.TimeCharge
+		}
+	}
+}
+
+// potRake returns the rake due from the pot at the end of a hand. It is taken
+// from the chips the players put in against each other; a bet nobody called
+// goes back to the player who made it unraked.
+func (g *Game) potRake() int {
+	if g.Rake.Percent == 0 || (g.Rake.NoFlopNoDrop && len(g.CommunityCards) == 0) {
+		return 0
+	}
+	highest, second := 0, 0
+	for _, p := range g.Players {
+		if !p.IsActive() {
+			continue
+		}
+		if bet := p.TotalBetInHand - p.DeadMoney; bet > highest {
+			highest, second = bet, highest
+		} else if bet > second {
+			second = bet
+		}
+	}
+	// The small epsilon keeps percentages like 7% of 100 from rounding down
+	// because of floating-point error.
+	rake := int(math.Floor(float64(g.Pot-(highest-second))*g.Rake.Percent/100 + 1e-9))
+	if g.Rake.Cap > 0 {
+		rake = min(rake, g.Rake.Cap)
+	}
+	return rake
+}
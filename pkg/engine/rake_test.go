@@ -0,0 +1,186 @@
+package engine
+
+import (
+	"pls7-cli/pkg/poker"
+	"testing"
+)
+
+// newRakeGameForTests returns an NLH game on a dry board in which YOU has the
+// best hand and every player has put the given amount into the pot.
+func newRakeGameForTests(t *testing.T, rake Rake, bets ...int) *Game {
+	t.Helper()
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 50, 100, DifficultyMedium, loadRule(t, "nlh.yml"), true, false, 0)
+	g.Rake = rake
+	g.CommunityCards = poker.CardsFromStrings("2s 7d 9c Jh Kd")
+	hands := []string{"Ks Kc", "Js Jc", "2c 3c"}
+	for i, p := range g.Players {
+		p.Hand = poker.CardsFromStrings(hands[i])
+		p.TotalBetInHand = bets[i]
+		g.Pot += bets[i]
+	}
+	return g
+}
+
+func TestRake_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rake  Rake
+		valid bool
+	}{
+		{name: "No rake", rake: Rake{}, valid: true},
+		{name: "Capped percentage", rake: Rake{Percent: 5, Cap: 300, NoFlopNoDrop: true}, valid: true},
+		{name: "Above 100 percent", rake: Rake{Percent: 120}},
+		{name: "Negative cap", rake: Rake{Percent: 5, Cap: -1}},
+		{name: "Negative time charge", rake: Rake{TimeCharge: -10}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.rake.Validate(); (err == nil) != tc.valid {
+				t.Errorf("Validate() = %v, expected valid: %t", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestDistributePot_TakesCappedRake(t *testing.T) {
+	g := newRakeGameForTests(t, Rake{Percent: 10, Cap: 200}, 1000, 1000, 1000)
+
+	results := g.DistributePot()
+
+	if len(results) != 1 || results[0].AmountWon != 2800 || results[0].Rake != 200 {
+		t.Fatalf("Expected YOU to win 2800 after a rake of 200, got %+v", results)
+	}
+	if g.Players[0].Chips != 2800 || g.HouseChips != 200 || g.Pot != 0 {
+		t.Errorf("Expected YOU to have 2800 chips and the house 200, got %d and %d (pot %d)", g.Players[0].Chips, g.HouseChips, g.Pot)
+	}
+}
+
+func TestDistributePot_RakesMainPotFirst(t *testing.T) {
+	// YOU is all-in for 300 and wins the main pot of 900, from which the rake
+	// of 10% of the called 1,700 is taken. CPU1 wins the side pot of 800, and
+	// gets back its uncalled 100 unraked.
+	g := newRakeGameForTests(t, Rake{Percent: 10}, 300, 800, 700)
+	g.Players[0].Status = PlayerStatusAllIn
+
+	g.DistributePot()
+
+	if g.Players[0].Chips != 900-170 || g.Players[1].Chips != 900 || g.HouseChips != 170 {
+		t.Errorf("Expected YOU to win 730, CPU1 900 and the house 170, got %d, %d and %d",
+			g.Players[0].Chips, g.Players[1].Chips, g.HouseChips)
+	}
+}
+
+func TestDistributePot_SplitsRakeBetweenWinners(t *testing.T) {
+	g := newRakeGameForTests(t, Rake{Percent: 5}, 1000, 1000, 0)
+	g.CommunityCards = poker.CardsFromStrings("As Ks Qs Js Ts") // The board plays.
+
+	results := g.DistributePot()
+
+	if len(results) != 2 {
+		t.Fatalf("Expected a split pot, got %+v", results)
+	}
+	for _, result := range results {
+		if result.AmountWon != 950 || result.Rake != 50 {
+			t.Errorf("Expected %s to win 950 after a rake of 50, got %+v", result.PlayerName, result)
+		}
+	}
+}
+
+func TestAwardPotToLastPlayer_Rake(t *testing.T) {
+	testCases := []struct {
+		name  string
+		board string
+		rake  int
+	}{
+		{name: "No flop, no drop", board: "", rake: 0},
+		{name: "Raked after the flop", board: "2s 7d 9c", rake: 150},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// YOU's last 500 were not called, so only 1,500 of the pot is raked.
+			g := newRakeGameForTests(t, Rake{Percent: 10, NoFlopNoDrop: true}, 1000, 500, 500)
+			g.CommunityCards = poker.CardsFromStrings(tc.board)
+			g.Players[1].Status = PlayerStatusFolded
+			g.Players[2].Status = PlayerStatusFolded
+
+			results := g.AwardPotToLastPlayer()
+
+			if results[0].Rake != tc.rake || g.Players[0].Chips != 2000-tc.rake || g.HouseChips != tc.rake {
+				t.Errorf("Expected a rake of %d, got %+v with %d chips for the house", tc.rake, results[0], g.HouseChips)
+			}
+		})
+	}
+}
+
+func TestStartNewHand_TimeCharge(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Rake = Rake{TimeCharge: 20, TimeChargeHands: 2}
+	g.Players[0].Chips = 20 // Cannot cover the time charge and the blinds.
+
+	charged := []int{}
+	for hand := 0; hand < 4; hand++ {
+		houseChips := g.HouseChips
+		g.StartNewHand()
+		charged = append(charged, g.HouseChips-houseChips)
+		total := g.Pot + g.HouseChips
+		for _, p := range g.Players {
+			total += p.Chips
+		}
+		if total != g.TotalInitialChips-9980 {
+			t.Fatalf("Expected every chip to be accounted for in hand %d, got %d", hand+1, total)
+		}
+		// Take the blinds back, so that the hands do not depend on each other.
+		for _, p := range g.Players {
+			p.Chips += p.TotalBetInHand
+		}
+		g.Pot = 0
+	}
+	if charged[0] != 40 || charged[1] != 0 || charged[2] != 40 || charged[3] != 0 {
+		t.Errorf("Expected CPU1 and CPU2 to pay 20 every other hand, got %v", charged)
+	}
+}
+
+func TestPlayHand_RecordsRake(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Rake = Rake{Percent: 5, TimeCharge: 10}
+	var events []Event
+	end := g.PlayHand(&callingProvider{}, HandObserverFunc(func(_ *Game, e Event) { events = append(events, e) }))
+
+	if start := events[0].(*HandStartEvent); start.TimeCharges != 30 {
+		t.Errorf("Expected 3 time charges of 10, got %d", start.TimeCharges)
+	}
+	raked := 0
+	for _, result := range end.Results {
+		raked += result.Rake
+	}
+	if end.Rake != 15 || raked != end.Rake {
+		t.Errorf("Expected a rake of 5%% of the 300 pot, recorded with the winners, got %d (%d)", end.Rake, raked)
+	}
+	total := g.HouseChips
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	if g.HouseChips != 45 || total != g.TotalInitialChips {
+		t.Errorf("Expected the house to hold 45 and every chip to be accounted for, got %d and %d", g.HouseChips, total)
+	}
+}
+
+func TestRake_SurvivesSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.Rake = Rake{Percent: 5, Cap: 300, NoFlopNoDrop: true, TimeCharge: 10, TimeChargeHands: 30}
+	g.HouseChips = 1234
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if loaded.Rake != g.Rake || loaded.HouseChips != 1234 {
+		t.Errorf("Expected the rake and the house's chips to be restored, got %+v and %d", loaded.Rake, loaded.HouseChips)
+	}
+
+	saveData := g.ToSaveData()
+	saveData.GameMetadata.Rake.Percent = -1
+	if _, err := FromSaveData(saveData); err == nil {
+		t.Error("Expected an error for an invalid rake")
+	}
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"fmt"
 	"pls7-cli/pkg/poker"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -89,21 +90,33 @@ func (g *Game) ProcessAction(player *Player, action PlayerAction) (wasAggressive
 }
 
 // CleanupHand performs post-hand maintenance. It checks for and marks any players
-// who have been eliminated (run out of chips) and checks for a game-over condition.
+// who have been eliminated (run out of chips), records their finishing places,
+// and checks for a game-over condition. In a cash game, busted players are not
+// eliminated for good: CPU players are replaced, and humans may rebuy.
 func (g *Game) CleanupHand() []string {
 	var events []string
 	events = append(events, "\n--- End of Hand ---")
+	var eliminated []*Player
 	for _, p := range g.Players {
-		if p.Chips == 0 && p.Status != PlayerStatusEliminated {
+		if p.Chips == 0 && p.IsActive() {
 			p.Status = PlayerStatusEliminated
-			events = append(events, fmt.Sprintf("%s has been eliminated!", p.Name))
+			eliminated = append(eliminated, p)
+			if g.Cash != nil {
+				events = append(events, fmt.Sprintf("%s is out of chips!", p.Name))
+			} else {
+				events = append(events, fmt.Sprintf("%s has been eliminated!", p.Name))
+			}
 		}
 	}
+	if g.Cash != nil {
+		return append(events, g.refillSeats()...)
+	}
+	g.recordEliminations(eliminated)
 
 	// Check if only one player is left in the entire game.
 	if g.CountRemainingPlayers() <= 1 {
 		for _, p := range g.Players {
-			if p.Status != PlayerStatusEliminated {
+			if p.IsActive() {
 				events = append(events, fmt.Sprintf("%s wins the game!", p.Name))
 				break
 			}
@@ -112,12 +125,37 @@ func (g *Game) CleanupHand() []string {
 	return events
 }
 
-// CountRemainingPlayers counts players who have not been eliminated from the game.
-// This is used to check for the end-of-game condition.
+// CountRemainingPlayers counts players who are dealt into hands: those who have
+// neither been eliminated from the game nor are sitting out. This is used to
+// check for the end-of-game condition.
 func (g *Game) CountRemainingPlayers() int {
 	count := 0
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusEliminated {
+		if p.IsActive() {
+			count++
+		}
+	}
+	return count
+}
+
+// CountHumanPlayers counts the human-controlled seats at the table, including
+// those who have already been eliminated.
+func (g *Game) CountHumanPlayers() int {
+	count := 0
+	for _, p := range g.Players {
+		if !p.IsCPU {
+			count++
+		}
+	}
+	return count
+}
+
+// CountRemainingHumans counts human-controlled players who have not been eliminated.
+// The CLI uses this to end the session once no human is left at the table.
+func (g *Game) CountRemainingHumans() int {
+	count := 0
+	for _, p := range g.Players {
+		if !p.IsCPU && p.Status != PlayerStatusEliminated {
 			count++
 		}
 	}
@@ -154,11 +192,15 @@ func (g *Game) CountPlayersAbleToAct() int {
 func (g *Game) StartNewHand() (event *BlindEvent) {
 	g.HandCount++
 
-	// Increase blinds if the blind-up interval has been reached.
-	if g.BlindUpInterval > 0 && g.HandCount > 1 && (g.HandCount-1)%g.BlindUpInterval == 0 {
+	// Follow the tournament structure, or increase blinds if the blind-up
+	// interval has been reached.
+	if g.Structure != nil {
+		event = g.startLevelHand(time.Now())
+	} else if g.BlindUpInterval > 0 && g.HandCount > 1 && (g.HandCount-1)%g.BlindUpInterval == 0 {
 		g.SmallBlind *= 2
 		g.BigBlind *= 2
-		event = &BlindEvent{SmallBlind: g.SmallBlind, BigBlind: g.BigBlind}
+		g.Ante *= 2
+		event = g.blindEvent()
 	}
 
 	// Reset game state for the new hand.
@@ -166,30 +208,60 @@ func (g *Game) StartNewHand() (event *BlindEvent) {
 	g.Deck = poker.NewDeck()
 	g.Deck.Shuffle(g.Rand)
 	g.CommunityCards = []poker.Card{}
+	g.Boards = nil
+	if boards := g.Rules.BoardCount(); boards > 1 {
+		g.Boards = make([][]poker.Card, boards)
+	}
 	g.Pot = 0
 	g.LastRaiseAmount = 0
+	g.HandType, g.NextHandType = g.NextHandType, HandRegular
+	g.StraddlePos = -1
 
-	g.DealerPos = g.FindNextActivePlayer(g.DealerPos)
+	previousBigBlindPos := g.BigBlindPos
+	skipped := g.moveButton()
+	g.chargeMissedBlinds(previousBigBlindPos)
 
 	// Reset each player's state for the new hand.
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusEliminated {
+		if p.IsActive() {
 			p.Hand = []poker.Card{}
 			p.CurrentBet = 0
 			p.TotalBetInHand = 0
+			p.DeadMoney = 0
 			p.Status = PlayerStatusPlaying
 			p.LastActionDesc = ""
 		}
 	}
 
-	// Post blinds.
-	sbPos := g.FindNextActivePlayer(g.DealerPos)
-	bbPos := g.FindNextActivePlayer(sbPos)
-	g.postBet(g.Players[sbPos], g.SmallBlind)
-	g.postBet(g.Players[bbPos], g.BigBlind)
-
-	g.BetToCall = g.BigBlind
-	g.CurrentTurnPos = g.FindNextActivePlayer(bbPos)
+	g.chargeTime()
+	g.postMissedBlinds()
+	// The players the big blind skipped owe it from the next hand on.
+	for _, p := range skipped {
+		p.MissedBlinds += g.BigBlind
+	}
+	if g.HandType == HandBombPot {
+		g.postBombPotAntes()
+	} else {
+		// Post antes and blinds. Every player antes before the blinds are posted,
+		// while the big blind posts a big blind ante after the blind.
+		if g.Ante > 0 && !g.BigBlindAnte {
+			for _, p := range g.Players {
+				if p.IsActive() {
+					g.postAnte(p, g.Ante)
+				}
+			}
+		}
+		if sb := g.Players[g.SmallBlindPos]; sb.IsActive() {
+			g.postBet(sb, g.SmallBlind)
+		}
+		g.postBet(g.Players[g.BigBlindPos], g.BigBlind)
+		if g.Ante > 0 && g.BigBlindAnte {
+			g.Players[g.BigBlindPos].DeadMoney = g.postAnte(g.Players[g.BigBlindPos], g.Ante)
+		}
+		g.BetToCall = g.BigBlind
+		g.postStraddle()
+	}
+	g.CurrentTurnPos = g.FindNextActivePlayer(g.preflopCloser())
 
 	// Deal hole cards.
 	// In dev/debug mode, specific cards can be dealt to the human player.
@@ -242,12 +314,12 @@ func (g *Game) StartNewHand() (event *BlindEvent) {
 	return event
 }
 
-// FindNextActivePlayer finds the index of the next player at the table who has
-// not been eliminated from the game.
+// FindNextActivePlayer finds the index of the next player at the table who is
+// dealt into hands: one who has not been eliminated and is not sitting out.
 func (g *Game) FindNextActivePlayer(startPos int) int {
 	pos := (startPos + 1) % len(g.Players)
 	for {
-		if g.Players[pos].Status != PlayerStatusEliminated {
+		if g.Players[pos].IsActive() {
 			return pos
 		}
 		pos = (pos + 1) % len(g.Players)
@@ -269,6 +341,26 @@ func (g *Game) postBet(player *Player, amount int) {
 	}
 }
 
+// postAnte moves an ante from the player's stack to the pot. Antes count
+// towards the player's TotalBetInHand, and so towards the pots they can win,
+// but not towards the CurrentBet other players have to call. It returns the
+// amount posted.
+func (g *Game) postAnte(player *Player, amount int) int {
+	amount = min(amount, player.Chips) // Player is going all-in for less.
+	player.Chips -= amount
+	player.TotalBetInHand += amount
+	g.Pot += amount
+	if player.Chips == 0 {
+		player.Status = PlayerStatusAllIn
+	}
+	return amount
+}
+
+// blindEvent returns a BlindEvent announcing the current blinds and ante.
+func (g *Game) blindEvent() *BlindEvent {
+	return &BlindEvent{SmallBlind: g.SmallBlind, BigBlind: g.BigBlind, Ante: g.Ante, BigBlindAnte: g.BigBlindAnte}
+}
+
 // Advance moves the game state to the next phase (e.g., from Flop to Turn),
 // dealing community cards as required.
 func (g *Game) Advance() {
@@ -291,8 +383,20 @@ func (g *Game) Advance() {
 	}
 }
 
-// dealCommunityCards deals n cards from the deck to the community cards on the board.
+// dealCommunityCards deals n cards from the deck to the community cards on the
+// board. In a game with several boards, n cards are dealt to each board in
+// turn, and the community cards are those of the first board.
 func (g *Game) dealCommunityCards(n int) {
+	if g.Rules.BoardCount() > 1 {
+		for b := range g.Boards {
+			for i := 0; i < n; i++ {
+				card, _ := g.Deck.Deal()
+				g.Boards[b] = append(g.Boards[b], card)
+			}
+		}
+		g.CommunityCards = append([]poker.Card{}, g.Boards[0]...)
+		return
+	}
 	for i := 0; i < n; i++ {
 		card, _ := g.Deck.Deal()
 		g.CommunityCards = append(g.CommunityCards, card)
@@ -316,19 +420,21 @@ func (g *Game) isBettingActionRequired() bool {
 // (e.g., after the flop is dealt). It clears players' current bets and determines
 // who acts first.
 func (g *Game) PrepareNewBettingRound() {
+	g.PreviousAggressor = g.Aggressor
 	g.Aggressor = nil
 	g.ActionsTakenThisRound = 0
 
 	if g.Phase == PhasePreFlop {
-		// Pre-flop is special: blinds are already posted, and action starts after the big blind.
-		bbPos := g.FindNextActivePlayer(g.FindNextActivePlayer(g.DealerPos))
-		g.ActionCloserPos = bbPos
+		g.PreviousAggressor = nil
+		// Pre-flop is special: blinds are already posted, and action starts after
+		// the big blind, or after the straddler, who then closes the action.
+		g.ActionCloserPos = g.preflopCloser()
 		return
 	}
 
 	// For post-flop rounds, reset bets and start with the first active player after the dealer.
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusEliminated {
+		if p.IsActive() {
 			p.CurrentBet = 0
 			p.LastActionDesc = ""
 		}
@@ -340,11 +446,11 @@ func (g *Game) PrepareNewBettingRound() {
 }
 
 // FindPreviousActivePlayer finds the index of the previous player at the table
-// who has not been eliminated from the game.
+// who is dealt into hands.
 func (g *Game) FindPreviousActivePlayer(startPos int) int {
 	pos := (startPos - 1 + len(g.Players)) % len(g.Players)
 	for {
-		if g.Players[pos].Status != PlayerStatusEliminated {
+		if g.Players[pos].IsActive() {
 			return pos
 		}
 		pos = (pos - 1 + len(g.Players)) % len(g.Players)
@@ -354,6 +460,11 @@ func (g *Game) FindPreviousActivePlayer(startPos int) int {
 // IsBettingRoundOver determines if the current betting round has concluded.
 // A round ends when all active players have had a turn and all bets have been matched.
 func (g *Game) IsBettingRoundOver() bool {
+	// Bomb pots have no pre-flop betting.
+	if g.Phase == PhasePreFlop && g.HandType == HandBombPot {
+		return true
+	}
+
 	// Round is over if only one or zero players can act.
 	if g.CountNonFoldedPlayers() <= 1 {
 		return true
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"fmt"
+	"pls7-cli/pkg/poker"
+)
+
+// MaxRunouts is the most times the rest of the board may be dealt.
+const MaxRunouts = 4
+
+// RunoutProvider is an ActionProvider that can also ask players whether they
+// agree to run the board more than once. When every player still in the hand
+// is all-in, or all but one, before the river, PlayHand asks each of them in
+// turn. Players whose provider does not implement it decline, and the board is
+// dealt once.
+type RunoutProvider interface {
+	ActionProvider
+	// AgreeToRunouts reports whether the player agrees to deal the rest of
+	// the board the given number of times.
+	AgreeToRunouts(g *Game, p *Player, times int) bool
+}
+
+// RequestRunouts asks the provider whether the player agrees to run the board
+// the given number of times, or declines if the provider cannot ask. It lets
+// providers that wrap other providers pass the question on.
+func RequestRunouts(provider ActionProvider, g *Game, p *Player, times int) bool {
+	if rp, ok := provider.(RunoutProvider); ok {
+		return rp.AgreeToRunouts(g, p, times)
+	}
+	return false
+}
+
+// ValidateRunItTimes checks that a number of runouts is in range. 0 and 1
+// both deal the board once.
+func ValidateRunItTimes(times int) error {
+	if times < 0 || times > MaxRunouts {
+		return fmt.Errorf("the board can be run 1 to %d times, got %d", MaxRunouts, times)
+	}
+	return nil
+}
+
+// runoutsOffered reports whether the players may run the board more than
+// once: the game has a single board, the river has not been dealt, and no
+// more than one of the players still in the hand can bet.
+func (g *Game) runoutsOffered() bool {
+	return g.RunItTimes > 1 && g.Rules.BoardCount() == 1 && g.Phase < PhaseRiver && g.CountNonFoldedPlayers() > 1 && g.CountPlayersAbleToAct() <= 1
+}
+
+// agreeToRunouts asks the players still in the hand, starting left of the
+// button, whether they agree to run the board RunItTimes times, or as many
+// times as the deck allows. It returns the number of times to deal the rest of
+// the board: 1 unless everyone agreed.
+func (g *Game) agreeToRunouts(provider ActionProvider) int {
+	times := min(g.RunItTimes, len(g.Deck.Cards)/(5-len(g.CommunityCards)))
+	if times < 2 {
+		return 1
+	}
+	for i := 1; i <= len(g.Players); i++ {
+		p := g.Players[(g.DealerPos+i)%len(g.Players)]
+		if p.Status != PlayerStatusPlaying && p.Status != PlayerStatusAllIn {
+			continue
+		}
+		if !RequestRunouts(provider, g, p, times) {
+			return 1
+		}
+	}
+	return times
+}
+
+// dealRunouts deals the rest of the board times-1 more times, once the first
+// board is complete. Every board keeps the first shared cards, which were
+// dealt before the players agreed, and the pot is shown down on each of them.
+func (g *Game) dealRunouts(times, shared int) {
+	g.Boards = [][]poker.Card{append([]poker.Card{}, g.CommunityCards...)}
+	for run := 1; run < times; run++ {
+		board := append([]poker.Card{}, g.CommunityCards[:shared]...)
+		for len(board) < len(g.CommunityCards) {
+			card, _ := g.Deck.Deal()
+			board = append(board, card)
+		}
+		g.Boards = append(g.Boards, board)
+	}
+}
+
+// boards returns the boards the pot is shown down on: Boards if the game has
+// several boards or the hand was run more than once, or else the community
+// cards.
+func (g *Game) boards() [][]poker.Card {
+	if len(g.Boards) > 0 {
+		return g.Boards
+	}
+	return [][]poker.Card{g.CommunityCards}
+}
+
+// boardLabel names the boards of a hand in descriptions: "Board" in a game
+// with several boards, and "Run" for a hand run more than once.
+func (g *Game) boardLabel() string {
+	if g.Rules.BoardCount() > 1 {
+		return "Board"
+	}
+	return "Run"
+}
@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"math/rand"
+	"pls7-cli/pkg/poker"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// allInProvider moves every player all-in, and answers whether to run the
+// board more than once, recording who was asked.
+type allInProvider struct {
+	agree bool
+	asked []string
+}
+
+func (p *allInProvider) GetAction(g *Game, pl *Player, _ *rand.Rand) PlayerAction {
+	legal := g.LegalActions(pl)
+	switch {
+	case legal.CanRaise:
+		return PlayerAction{Type: ActionRaise, Amount: legal.MaxAmount}
+	case legal.CanBet:
+		return PlayerAction{Type: ActionBet, Amount: legal.MaxAmount}
+	case legal.CanCall:
+		return PlayerAction{Type: ActionCall}
+	}
+	return PlayerAction{Type: ActionCheck}
+}
+
+func (p *allInProvider) AgreeToRunouts(_ *Game, pl *Player, _ int) bool {
+	p.asked = append(p.asked, pl.Name)
+	return p.agree
+}
+
+func TestDistributePot_SplitsPotBetweenRunouts(t *testing.T) {
+	// CPU2 folded after putting in 1 chip, so the pot of 2,001 cannot be split
+	// evenly: the odd chip goes to the first runout, which YOU wins with kings.
+	// CPU1 makes a set of jacks on the second.
+	g := newRakeGameForTests(t, Rake{}, 1000, 1000, 1)
+	g.Players[2].Status = PlayerStatusFolded
+	g.CommunityCards = poker.CardsFromStrings("2s 7d 9c 4h 3d")
+	g.Boards = [][]poker.Card{g.CommunityCards, poker.CardsFromStrings("2s 7d 9c Jh 3d")}
+
+	results := g.DistributePot()
+
+	if g.Players[0].Chips != 1001 || g.Players[1].Chips != 1000 {
+		t.Errorf("Expected YOU to win 1001 and CPU1 1000, got %d and %d", g.Players[0].Chips, g.Players[1].Chips)
+	}
+	for _, result := range results {
+		expected := map[string]string{"YOU": "Run 1: ", "CPU1": "Run 2: "}[result.PlayerName]
+		if !strings.HasPrefix(result.HandDesc, expected) || strings.Contains(result.HandDesc, ";") {
+			t.Errorf("Expected %s to win only %q, got %q", result.PlayerName, expected, result.HandDesc)
+		}
+	}
+}
+
+func TestPlayHand_RunItTwice(t *testing.T) {
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 1000, 50, 100, DifficultyMedium, loadRule(t, "nlh.yml"), false, false, 0)
+	g.RunItTimes = 2
+	provider := &allInProvider{agree: true}
+
+	end := g.PlayHand(provider, nil)
+
+	if len(provider.asked) != 3 {
+		t.Errorf("Expected all three players to be asked, got %v", provider.asked)
+	}
+	if len(end.Boards) != 2 || len(end.Boards[0]) != 5 || len(end.Boards[1]) != 5 {
+		t.Fatalf("Expected two full boards, got %v", end.Boards)
+	}
+	// Both boards were dealt from the same deck, so no card appears twice.
+	seen := make(map[poker.Card]bool)
+	cards := append(append([]poker.Card{}, end.Boards[0]...), end.Boards[1]...)
+	for _, p := range g.Players {
+		cards = append(cards, p.Hand...)
+	}
+	for _, c := range cards {
+		if seen[c] {
+			t.Errorf("Expected every card to be dealt once, got %v twice", c)
+		}
+		seen[c] = true
+	}
+	total := 0
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	if total != g.TotalInitialChips || g.Pot != 0 {
+		t.Errorf("Expected every chip to be awarded, got %d of %d", total, g.TotalInitialChips)
+	}
+}
+
+func TestPlayHand_RunoutDeclined(t *testing.T) {
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 1000, 50, 100, DifficultyMedium, loadRule(t, "nlh.yml"), false, false, 0)
+	g.RunItTimes = 3
+	provider := &allInProvider{}
+
+	end := g.PlayHand(provider, nil)
+
+	if len(provider.asked) != 1 {
+		t.Errorf("Expected nobody to be asked after the first player declined, got %v", provider.asked)
+	}
+	if end.Boards != nil || len(g.CommunityCards) != 5 {
+		t.Errorf("Expected the board to be dealt once, got %v", end.Boards)
+	}
+}
+
+func TestRunItTimes_SurvivesSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.RunItTimes = 2
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if loaded.RunItTimes != 2 {
+		t.Errorf("Expected the board to be run twice after loading, got %d", loaded.RunItTimes)
+	}
+
+	saveData := g.ToSaveData()
+	saveData.GameMetadata.RunItTimes = MaxRunouts + 1
+	if _, err := FromSaveData(saveData); err == nil {
+		t.Error("Expected an error for too many runouts")
+	}
+}
+
+func TestDealRunouts_KeepsSharedCards(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.dealCommunityCards(5) // Flop, turn and river of the first board.
+
+	g.dealRunouts(3, 3) // The players agreed on the flop.
+
+	if len(g.Boards) != 3 {
+		t.Fatalf("Expected three boards, got %v", g.Boards)
+	}
+	for run, board := range g.Boards[1:] {
+		if len(board) != 5 || !reflect.DeepEqual(board[:3], g.CommunityCards[:3]) || reflect.DeepEqual(board[3:], g.CommunityCards[3:]) {
+			t.Errorf("Expected run %d to share the flop and have its own turn and river, got %v for %v", run+2, board, g.CommunityCards)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"pls7-cli/pkg/poker"
+	"runtime"
 	"time"
 )
 
@@ -30,14 +31,50 @@ type GameMetadata struct {
 	HandCount int `json:"hand_count"`
 	// DealerPos is the index in the Players slice corresponding to the player with the dealer button.
 	DealerPos int `json:"dealer_pos"`
+	// SmallBlindPos and BigBlindPos are the seats of the blinds in the last
+	// hand. Saves from before they were recorded have neither, and are loaded
+	// as if no hand had been played, so no one owes blinds for the first hand.
+	SmallBlindPos *int `json:"small_blind_pos,omitempty"`
+	BigBlindPos   *int `json:"big_blind_pos,omitempty"`
+	// ButtonPolicy decides how the button and the blinds move on when players
+	// are eliminated.
+	ButtonPolicy ButtonPolicy `json:"button_policy,omitempty"`
 	// SmallBlind is the size of the small blind for the current hand.
 	SmallBlind int `json:"small_blind"`
 	// BigBlind is the size of the big blind for the current hand.
 	BigBlind int `json:"big_blind"`
+	// Ante is the ante for the current hand.
+	Ante int `json:"ante,omitempty"`
+	// BigBlindAnte is true if the big blind posts the ante for the table.
+	BigBlindAnte bool `json:"big_blind_ante,omitempty"`
+	// BombPotAnte is the ante every player posts in a bomb pot.
+	BombPotAnte int `json:"bomb_pot_ante,omitempty"`
+	// RunItTimes is how many times the board may be run when players are
+	// all-in before the river.
+	RunItTimes int `json:"run_it_times,omitempty"`
 	// BlindUpInterval is the number of hands after which the blinds increase. 0 disables this.
 	BlindUpInterval int `json:"blind_up_interval"`
 	// TotalInitialChips stores the sum of all players' starting chips.
 	TotalInitialChips int `json:"total_initial_chips"`
+	// Rake is what the house takes from the game, if anything.
+	Rake *Rake `json:"rake,omitempty"`
+	// HouseChips is the total of the rake and time charges taken so far.
+	HouseChips int `json:"house_chips,omitempty"`
+	// Structure is the tournament structure the blinds follow, if any.
+	Structure *Structure `json:"structure,omitempty"`
+	// LevelIndex is the index of the current level in the structure.
+	LevelIndex int `json:"level_index,omitempty"`
+	// LevelHands is the number of hands dealt at the current level.
+	LevelHands int `json:"level_hands,omitempty"`
+	// LevelElapsedMs is the time in milliseconds the current level had been
+	// running when the game was saved. The level's clock resumes from there.
+	LevelElapsedMs int64 `json:"level_elapsed_ms,omitempty"`
+	// Eliminations are the finishing places of the players eliminated so far.
+	Eliminations []Standing `json:"eliminations,omitempty"`
+	// Cash holds the buy-in limits of a cash game.
+	Cash *CashRules `json:"cash,omitempty"`
+	// Departed are the session results of the CPU players who left a cash game.
+	Departed []SessionResult `json:"departed,omitempty"`
 }
 
 // PlayerSaveData contains the state of a single player that needs to be saved.
@@ -51,10 +88,16 @@ type PlayerSaveData struct {
 	IsCPU bool `json:"is_cpu"`
 	// Position is the player's seat at the table.
 	Position int `json:"position"`
-	// Status is the player's current status (Playing, Folded, AllIn, Eliminated).
+	// Status is the player's current status (Playing, Folded, AllIn, Eliminated, SittingOut).
 	Status PlayerStatus `json:"status"`
 	// Profile contains the AI behavior parameters if the player is a CPU.
 	Profile *AIProfileSaveData `json:"profile,omitempty"`
+	// Stats contains the statistics the CPU players keep about the player.
+	Stats PlayerStats `json:"stats"`
+	// MissedBlinds is the amount of blinds the player still owes.
+	MissedBlinds int `json:"missed_blinds,omitempty"`
+	// BoughtIn is the total number of chips the player has bought in a cash game.
+	BoughtIn int `json:"bought_in,omitempty"`
 }
 
 // AIProfileSaveData contains the AI behavior parameters in a JSON-serializable format.
@@ -73,6 +116,12 @@ type AIProfileSaveData struct {
 	MinRaiseMultiplier float64 `json:"min_raise_multiplier"`
 	// MaxRaiseMultiplier is the maximum multiplier for a raise amount.
 	MaxRaiseMultiplier float64 `json:"max_raise_multiplier"`
+	// PositionBonus is how many points the pre-flop thresholds drop in late position.
+	PositionBonus float64 `json:"position_bonus,omitempty"`
+	// ContinuationBetFrequency is the probability (0.0 to 1.0) that the AI bets again as the last aggressor.
+	ContinuationBetFrequency float64 `json:"continuation_bet_frequency,omitempty"`
+	// CheckRaiseFrequency is the probability (0.0 to 1.0) that the AI check-raises a strong hand out of position.
+	CheckRaiseFrequency float64 `json:"check_raise_frequency,omitempty"`
 }
 
 // GameSettings contains the game configuration settings that affect gameplay.
@@ -92,23 +141,48 @@ func (g *Game) ToSaveData() *GameSaveData {
 	players := make([]PlayerSaveData, len(g.Players))
 	for i, player := range g.Players {
 		players[i] = PlayerSaveData{
-			Name:     player.Name,
-			Chips:    player.Chips,
-			IsCPU:    player.IsCPU,
-			Position: player.Position,
-			Status:   player.Status,
-			Profile:  aiProfileToSaveData(player.Profile),
+			Name:         player.Name,
+			Chips:        player.Chips,
+			IsCPU:        player.IsCPU,
+			Position:     player.Position,
+			Status:       player.Status,
+			Profile:      aiProfileToSaveData(player.Profile),
+			Stats:        player.Stats,
+			MissedBlinds: player.MissedBlinds,
+			BoughtIn:     player.BoughtIn,
 		}
 	}
 
 	// Create simplified game metadata
+	smallBlindPos, bigBlindPos := g.SmallBlindPos, g.BigBlindPos
 	gameMetadata := GameMetadata{
 		HandCount:         g.HandCount,
 		DealerPos:         g.DealerPos,
+		SmallBlindPos:     &smallBlindPos,
+		BigBlindPos:       &bigBlindPos,
+		ButtonPolicy:      g.ButtonPolicy,
 		SmallBlind:        g.SmallBlind,
 		BigBlind:          g.BigBlind,
+		Ante:              g.Ante,
+		BigBlindAnte:      g.BigBlindAnte,
+		BombPotAnte:       g.BombPotAnte,
+		RunItTimes:        g.RunItTimes,
 		BlindUpInterval:   g.BlindUpInterval,
 		TotalInitialChips: g.TotalInitialChips,
+		HouseChips:        g.HouseChips,
+		Structure:         g.Structure,
+		LevelIndex:        g.LevelIndex,
+		LevelHands:        g.LevelHands,
+		Eliminations:      g.Eliminations,
+		Cash:              g.Cash,
+		Departed:          g.Departed,
+	}
+	if g.Rake != (Rake{}) {
+		rake := g.Rake
+		gameMetadata.Rake = &rake
+	}
+	if g.Structure != nil {
+		gameMetadata.LevelElapsedMs = time.Since(g.LevelStart).Milliseconds()
 	}
 
 	// Create settings
@@ -137,12 +211,15 @@ func FromSaveData(saveData *GameSaveData) (*Game, error) {
 	players := make([]*Player, len(saveData.Players))
 	for i, playerData := range saveData.Players {
 		players[i] = &Player{
-			Name:     playerData.Name,
-			Chips:    playerData.Chips,
-			IsCPU:    playerData.IsCPU,
-			Position: playerData.Position,
-			Status:   playerData.Status,
-			Profile:  aiProfileFromSaveData(playerData.Profile),
+			Name:         playerData.Name,
+			Chips:        playerData.Chips,
+			IsCPU:        playerData.IsCPU,
+			Position:     playerData.Position,
+			Status:       playerData.Status,
+			Profile:      aiProfileFromSaveData(playerData.Profile),
+			Stats:        playerData.Stats,
+			MissedBlinds: playerData.MissedBlinds,
+			BoughtIn:     playerData.BoughtIn,
 		}
 	}
 
@@ -157,12 +234,63 @@ func FromSaveData(saveData *GameSaveData) (*Game, error) {
 		return nil, fmt.Errorf("unknown betting limit type: %s", saveData.GameRules.BettingLimit)
 	}
 
+	smallBlindPos, bigBlindPos := -1, -1
+	if pos := saveData.GameMetadata.SmallBlindPos; pos != nil {
+		smallBlindPos = *pos
+	}
+	if pos := saveData.GameMetadata.BigBlindPos; pos != nil {
+		bigBlindPos = *pos
+	}
+	for _, pos := range []int{smallBlindPos, bigBlindPos} {
+		if pos < -1 || pos >= len(players) {
+			return nil, fmt.Errorf("blind position %d is out of range (-1-%d)", pos, len(players)-1)
+		}
+	}
+
+	if s := saveData.GameMetadata.Structure; s != nil {
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid tournament structure: %w", err)
+		}
+		if i := saveData.GameMetadata.LevelIndex; i < 0 || i >= len(s.Levels) {
+			return nil, fmt.Errorf("level index %d is out of range (0-%d)", i, len(s.Levels)-1)
+		}
+	}
+
+	if err := saveData.GameRules.ValidateBoards(); err != nil {
+		return nil, fmt.Errorf("invalid game rules: %w", err)
+	}
+
+	if err := ValidateRunItTimes(saveData.GameMetadata.RunItTimes); err != nil {
+		return nil, err
+	}
+
+	var rake Rake
+	if r := saveData.GameMetadata.Rake; r != nil {
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid rake: %w", err)
+		}
+		rake = *r
+	}
+
+	if c := saveData.GameMetadata.Cash; c != nil {
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid cash game rules: %w", err)
+		}
+	}
+
 	// Create game instance - ready to start a new hand
 	game := &Game{
 		Players:           players,
 		DealerPos:         saveData.GameMetadata.DealerPos,
+		SmallBlindPos:     smallBlindPos,
+		BigBlindPos:       bigBlindPos,
+		ButtonPolicy:      saveData.GameMetadata.ButtonPolicy,
 		SmallBlind:        saveData.GameMetadata.SmallBlind,
 		BigBlind:          saveData.GameMetadata.BigBlind,
+		Ante:              saveData.GameMetadata.Ante,
+		BigBlindAnte:      saveData.GameMetadata.BigBlindAnte,
+		BombPotAnte:       saveData.GameMetadata.BombPotAnte,
+		RunItTimes:        saveData.GameMetadata.RunItTimes,
 		Difficulty:        saveData.Settings.Difficulty,
 		DevMode:           saveData.Settings.DevMode,
 		ShowsOuts:         saveData.Settings.ShowsOuts,
@@ -171,7 +299,18 @@ func FromSaveData(saveData *GameSaveData) (*Game, error) {
 		BlindUpInterval:   saveData.GameMetadata.BlindUpInterval,
 		BettingCalculator: calculator,
 		TotalInitialChips: saveData.GameMetadata.TotalInitialChips,
+		Rake:              rake,
+		HouseChips:        saveData.GameMetadata.HouseChips,
 		HandCount:         saveData.GameMetadata.HandCount,
+		EquityWorkers:     runtime.NumCPU(),
+		Structure:         saveData.GameMetadata.Structure,
+		LevelIndex:        saveData.GameMetadata.LevelIndex,
+		LevelHands:        saveData.GameMetadata.LevelHands,
+		LevelStart:        time.Now().Add(-time.Duration(saveData.GameMetadata.LevelElapsedMs) * time.Millisecond),
+		announcedLevel:    saveData.GameMetadata.LevelIndex,
+		Eliminations:      saveData.GameMetadata.Eliminations,
+		Cash:              saveData.GameMetadata.Cash,
+		Departed:          saveData.GameMetadata.Departed,
 		// Initialize new hand state
 		Phase:                 PhaseHandOver, // Ready to start new hand
 		CurrentTurnPos:        -1,            // Will be set when starting new hand
@@ -180,10 +319,12 @@ func FromSaveData(saveData *GameSaveData) (*Game, error) {
 		LastRaiseAmount:       0,             // Fresh betting state
 		ActionsTakenThisRound: 0,             // Fresh betting state
 		ActionCloserPos:       -1,            // Will be set when starting new hand
+		StraddlePos:           -1,            // Will be set when starting new hand
 	}
 
-	// Set default hand evaluator
+	// Set default hand evaluator and equity estimator
 	game.handEvaluator = evaluateHandStrength
+	game.equityEstimator = estimateEquity
 
 	return game, nil
 }
@@ -194,13 +335,16 @@ func aiProfileToSaveData(profile *AIProfile) *AIProfileSaveData {
 		return nil
 	}
 	return &AIProfileSaveData{
-		Name:               profile.Name,
-		PlayHandThreshold:  profile.PlayHandThreshold,
-		RaiseHandThreshold: profile.RaiseHandThreshold,
-		BluffingFrequency:  profile.BluffingFrequency,
-		AggressionFactor:   profile.AggressionFactor,
-		MinRaiseMultiplier: profile.MinRaiseMultiplier,
-		MaxRaiseMultiplier: profile.MaxRaiseMultiplier,
+		Name:                     profile.Name,
+		PlayHandThreshold:        profile.PlayHandThreshold,
+		RaiseHandThreshold:       profile.RaiseHandThreshold,
+		BluffingFrequency:        profile.BluffingFrequency,
+		AggressionFactor:         profile.AggressionFactor,
+		MinRaiseMultiplier:       profile.MinRaiseMultiplier,
+		MaxRaiseMultiplier:       profile.MaxRaiseMultiplier,
+		PositionBonus:            profile.PositionBonus,
+		ContinuationBetFrequency: profile.ContinuationBetFrequency,
+		CheckRaiseFrequency:      profile.CheckRaiseFrequency,
 	}
 }
 
@@ -210,13 +354,16 @@ func aiProfileFromSaveData(saveData *AIProfileSaveData) *AIProfile {
 		return nil
 	}
 	return &AIProfile{
-		Name:               saveData.Name,
-		PlayHandThreshold:  saveData.PlayHandThreshold,
-		RaiseHandThreshold: saveData.RaiseHandThreshold,
-		BluffingFrequency:  saveData.BluffingFrequency,
-		AggressionFactor:   saveData.AggressionFactor,
-		MinRaiseMultiplier: saveData.MinRaiseMultiplier,
-		MaxRaiseMultiplier: saveData.MaxRaiseMultiplier,
+		Name:                     saveData.Name,
+		PlayHandThreshold:        saveData.PlayHandThreshold,
+		RaiseHandThreshold:       saveData.RaiseHandThreshold,
+		BluffingFrequency:        saveData.BluffingFrequency,
+		AggressionFactor:         saveData.AggressionFactor,
+		MinRaiseMultiplier:       saveData.MinRaiseMultiplier,
+		MaxRaiseMultiplier:       saveData.MaxRaiseMultiplier,
+		PositionBonus:            saveData.PositionBonus,
+		ContinuationBetFrequency: saveData.ContinuationBetFrequency,
+		CheckRaiseFrequency:      saveData.CheckRaiseFrequency,
 	}
 }
 
@@ -223,6 +223,39 @@ func TestAIProfileConversion(t *testing.T) {
 	}
 }
 
+func TestAIProfileSurvivesSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	custom := AIProfile{
+		Name:                     "Custom",
+		PlayHandThreshold:        15,
+		RaiseHandThreshold:       26,
+		BluffingFrequency:        0.2,
+		AggressionFactor:         0.6,
+		MinRaiseMultiplier:       2,
+		MaxRaiseMultiplier:       3.5,
+		PositionBonus:            7,
+		ContinuationBetFrequency: 0.8,
+		CheckRaiseFrequency:      0.25,
+	}
+	g.Players[1].Profile = &custom
+
+	data, err := g.ToSaveData().SaveToJSON()
+	if err != nil {
+		t.Fatalf("SaveToJSON returned unexpected error: %v", err)
+	}
+	saveData, err := LoadFromJSON(data)
+	if err != nil {
+		t.Fatalf("LoadFromJSON returned unexpected error: %v", err)
+	}
+	loaded, err := FromSaveData(saveData)
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if profile := loaded.Players[1].Profile; profile == nil || *profile != custom {
+		t.Errorf("Expected the profile %+v to be restored, got %+v", custom, profile)
+	}
+}
+
 func TestJSONSerialization(t *testing.T) {
 	// Create test save data with simplified structure
 	saveData := &GameSaveData{
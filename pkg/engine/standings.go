@@ -0,0 +1,68 @@
+package engine
+
+import "sort"
+
+// Standing is a player's place in a game.
+type Standing struct {
+	// Place is the player's finishing place, or their place by chip count if
+	// they are still in the game. Players who finish level share a place.
+	Place int `json:"place"`
+	// Name is the player's name.
+	Name string `json:"name"`
+	// Chips is the player's stack, or 0 once they have been eliminated.
+	Chips int `json:"chips"`
+	// EliminatedHand is the hand the player was eliminated in, or 0 if they
+	// are still in the game.
+	EliminatedHand int `json:"eliminated_hand,omitempty"`
+}
+
+// recordEliminations gives the players eliminated in the last hand their
+// finishing places.
+func (g *Game) recordEliminations(eliminated []*Player) {
+	// Keep the eliminations in finishing order, winners first.
+	g.Eliminations = append(placeEliminations(eliminated, g.CountRemainingPlayers(), g.HandCount), g.Eliminations...)
+}
+
+// placeEliminations returns the finishing places of players eliminated in the
+// same hand, behind the given number of players still in. Of those players,
+// the ones who started the hand with more chips finish higher.
+func placeEliminations(eliminated []*Player, remaining, hand int) []Standing {
+	// An eliminated player's bets in the hand are the chips they started it with.
+	sort.SliceStable(eliminated, func(i, j int) bool {
+		return eliminated[i].TotalBetInHand > eliminated[j].TotalBetInHand
+	})
+	var standings []Standing
+	for i, p := range eliminated {
+		place := remaining + i + 1
+		if i > 0 && p.TotalBetInHand == eliminated[i-1].TotalBetInHand {
+			place = standings[i-1].Place
+		}
+		standings = append(standings, Standing{Place: place, Name: p.Name, EliminatedHand: hand})
+	}
+	return standings
+}
+
+// Standings returns the players' places: the players still in the game ranked
+// by their stacks, followed by the eliminated players in finishing order.
+func (g *Game) Standings() []Standing {
+	return rankStandings(g.Players, g.Eliminations)
+}
+
+// rankStandings ranks the players who have not been eliminated by their
+// stacks, ahead of the eliminations.
+func rankStandings(players []*Player, eliminations []Standing) []Standing {
+	var standings []Standing
+	for _, p := range players {
+		if p.Status != PlayerStatusEliminated {
+			standings = append(standings, Standing{Name: p.Name, Chips: p.Chips})
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool { return standings[i].Chips > standings[j].Chips })
+	for i := range standings {
+		standings[i].Place = i + 1
+		if i > 0 && standings[i].Chips == standings[i-1].Chips {
+			standings[i].Place = standings[i-1].Place
+		}
+	}
+	return append(standings, eliminations...)
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandings_FinishingPlaces(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"}, 10000, 50, 100)
+
+	// In hand 3, CPU4 busts.
+	g.HandCount = 3
+	g.Players[4].Chips = 0
+	g.Players[4].TotalBetInHand = 2000
+	g.CleanupHand()
+
+	// In hand 7, YOU and CPU2 bust; CPU2 started the hand with more chips.
+	g.HandCount = 7
+	g.Players[0].Chips, g.Players[0].TotalBetInHand = 0, 1500
+	g.Players[2].Chips, g.Players[2].TotalBetInHand = 0, 4000
+	g.Players[1].Chips = 30000
+	g.Players[3].Chips = 20000
+	g.CleanupHand()
+
+	expected := []Standing{
+		{Place: 1, Name: "CPU1", Chips: 30000},
+		{Place: 2, Name: "CPU3", Chips: 20000},
+		{Place: 3, Name: "CPU2", EliminatedHand: 7},
+		{Place: 4, Name: "YOU", EliminatedHand: 7},
+		{Place: 5, Name: "CPU4", EliminatedHand: 3},
+	}
+	if standings := g.Standings(); !reflect.DeepEqual(standings, expected) {
+		t.Errorf("Expected standings %+v, got %+v", expected, standings)
+	}
+}
+
+func TestStandings_SameStartingStacksShareAPlace(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	g.HandCount = 5
+	for _, p := range g.Players[1:] {
+		p.Chips = 0
+		p.TotalBetInHand = 10000
+	}
+	g.Players[0].Chips = 30000
+	g.CleanupHand()
+
+	standings := g.Standings()
+	if standings[1].Place != 2 || standings[2].Place != 2 {
+		t.Errorf("Expected both eliminated players to finish second, got %+v", standings)
+	}
+}
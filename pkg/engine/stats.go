@@ -0,0 +1,165 @@
+package engine
+
+import "math"
+
+// Typical values of the player statistics, assumed for players the AI has not
+// seen enough of yet. Each statistic starts out as if statsPriorWeight
+// observations at its typical value had been made, so a few unusual hands do
+// not swing the AI's reads.
+const (
+	statsPriorWeight     = 10
+	typicalVPIP          = 0.3
+	typicalPFR           = 0.15
+	typicalAggression    = 1.0
+	typicalFoldToBet     = 0.5
+	typicalWeakShowdowns = 0.2
+)
+
+// PlayerStats are the statistics the CPU players keep about a player's play,
+// gathered from the events of every hand the player is dealt into. They are
+// public information: every seat at the table could have made the same notes.
+type PlayerStats struct {
+	// Hands is the number of hands the player was dealt into.
+	Hands int `json:"hands"`
+	// VPIPHands is the number of hands in which the player voluntarily put
+	// chips into the pot before the flop by calling or raising.
+	VPIPHands int `json:"vpip_hands"`
+	// PFRHands is the number of hands in which the player raised before the flop.
+	PFRHands int `json:"pfr_hands"`
+	// Bets is the number of bets and raises the player made after the flop.
+	Bets int `json:"bets"`
+	// Calls is the number of calls the player made after the flop.
+	Calls int `json:"calls"`
+	// FacedBets is the number of times the player had to act facing a bet after the flop.
+	FacedBets int `json:"faced_bets"`
+	// FoldsToBet is the number of those times the player folded.
+	FoldsToBet int `json:"folds_to_bet"`
+	// Showdowns is the number of hands the player showed down.
+	Showdowns int `json:"showdowns"`
+	// WeakShowdowns is the number of showdown hands whose hole cards most
+	// players would have folded before the flop.
+	WeakShowdowns int `json:"weak_showdowns"`
+
+	// vpip and pfr record whether VPIPHands and PFRHands already count the
+	// current hand.
+	vpip, pfr bool
+}
+
+// smoothed returns count/total, pulled towards the typical value while there
+// are few observations.
+func smoothed(count, total int, typical float64) float64 {
+	return (float64(count) + typical*statsPriorWeight) / (float64(total) + statsPriorWeight)
+}
+
+// VPIP returns the share of hands in which the player voluntarily put chips
+// into the pot before the flop. Loose players have a high VPIP.
+func (s *PlayerStats) VPIP() float64 {
+	return smoothed(s.VPIPHands, s.Hands, typicalVPIP)
+}
+
+// PFR returns the share of hands in which the player raised before the flop.
+func (s *PlayerStats) PFR() float64 {
+	return smoothed(s.PFRHands, s.Hands, typicalPFR)
+}
+
+// Aggression returns the player's post-flop aggression factor: their bets and
+// raises per call. Passive players stay below 1.
+func (s *PlayerStats) Aggression() float64 {
+	return (float64(s.Bets) + typicalAggression*statsPriorWeight) / (float64(s.Calls) + statsPriorWeight)
+}
+
+// FoldToBet returns the share of post-flop bets the player folded to.
+func (s *PlayerStats) FoldToBet() float64 {
+	return smoothed(s.FoldsToBet, s.FacedBets, typicalFoldToBet)
+}
+
+// WeakShowdownRate returns the share of the player's showdowns in which they
+// showed hole cards most players would have folded before the flop.
+func (s *PlayerStats) WeakShowdownRate() float64 {
+	return smoothed(s.WeakShowdowns, s.Showdowns, typicalWeakShowdowns)
+}
+
+// recordStats updates the players' statistics from an event of the hand being
+// played. It is called by PlayHand before the observer sees the event, while
+// the acting player is still the current player.
+func (g *Game) recordStats(event Event) {
+	switch e := event.(type) {
+	case *HandStartEvent:
+		for _, p := range g.Players {
+			p.Stats.vpip, p.Stats.pfr = false, false
+			// Bomb pots have no pre-flop decisions to count.
+			if p.IsActive() && e.HandType != HandBombPot {
+				p.Stats.Hands++
+			}
+		}
+	case *ActionEvent:
+		if e.TimedOut {
+			return // The player did not choose this action.
+		}
+		s := &g.CurrentPlayer().Stats
+		if g.Phase == PhasePreFlop {
+			if (e.Action == ActionCall || e.Action == ActionRaise || e.Action == ActionBet) && !s.vpip {
+				s.vpip = true
+				s.VPIPHands++
+			}
+			if e.Action == ActionRaise && !s.pfr {
+				s.pfr = true
+				s.PFRHands++
+			}
+			return
+		}
+		switch e.Action {
+		case ActionBet:
+			s.Bets++
+		case ActionRaise:
+			s.Bets++
+			s.FacedBets++
+		case ActionCall:
+			s.Calls++
+			s.FacedBets++
+		case ActionFold:
+			s.FacedBets++
+			s.FoldsToBet++
+		}
+	case *HandEndEvent:
+		// Everyone plays a bomb pot, so its showdowns say little about a player.
+		if !e.Showdown || g.HandType == HandBombPot {
+			return
+		}
+		for _, p := range g.getShowdownPlayers() {
+			p.Stats.Showdowns++
+			if preflopScore(p.Hand, g.Rules) < plausibleHandScore {
+				p.Stats.WeakShowdowns++
+			}
+		}
+	}
+}
+
+// bluffAdjustment returns how much more (above 1) or less (below 1) often the
+// player should bluff the given opponents than their profile says, based on
+// how often the opponents fold to bets.
+func bluffAdjustment(opponents []*Player) float64 {
+	if len(opponents) == 0 {
+		return 1
+	}
+	var foldToBet float64
+	for _, p := range opponents {
+		foldToBet += p.Stats.FoldToBet()
+	}
+	foldToBet /= float64(len(opponents))
+	return math.Max(0.25, math.Min(foldToBet/typicalFoldToBet, 2))
+}
+
+// callAdjustment returns the factor to apply to the equity needed to call a bet
+// from the given bettor. Bettors who bet a lot or show down weak hands bluff
+// more, so they are called with less equity; passive players with strong
+// showdown hands are called with more.
+func callAdjustment(bettor *Player) float64 {
+	if bettor == nil {
+		return 1
+	}
+	s := &bettor.Stats
+	aggression := math.Max(-1, math.Min((s.Aggression()-typicalAggression)/typicalAggression, 1))
+	weakShowdowns := s.WeakShowdownRate() - typicalWeakShowdowns
+	return math.Max(0.6, math.Min(1-0.2*aggression-weakShowdowns, 1.4))
+}
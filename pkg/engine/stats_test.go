@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRecordStats_FromPlayedHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	provider := &TestActionProvider{Actions: []PlayerAction{
+		{Type: ActionRaise, Amount: 3000}, // YOU raise pre-flop.
+		{Type: ActionCall},                // CPU1 calls from the small blind.
+		{Type: ActionFold},                // CPU2 folds the big blind.
+		{Type: ActionCheck},               // Flop: CPU1 checks,
+		{Type: ActionBet, Amount: 2000},   // YOU bet,
+		{Type: ActionCall},                // CPU1 calls.
+		{Type: ActionBet, Amount: 4000},   // Turn: CPU1 bets,
+		{Type: ActionFold},                // YOU fold.
+	}}
+
+	g.PlayHand(provider, nil)
+
+	expected := map[string]PlayerStats{
+		"YOU":  {Hands: 1, VPIPHands: 1, PFRHands: 1, Bets: 1, FacedBets: 1, FoldsToBet: 1},
+		"CPU1": {Hands: 1, VPIPHands: 1, Bets: 1, Calls: 1, FacedBets: 1},
+		"CPU2": {Hands: 1},
+	}
+	for _, p := range g.Players {
+		got := p.Stats
+		got.vpip, got.pfr = false, false
+		if got != expected[p.Name] {
+			t.Errorf("Expected %s's stats to be %+v, got %+v", p.Name, expected[p.Name], got)
+		}
+	}
+}
+
+func TestRecordStats_CountsShowdownsOncePerHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	for hand := 0; hand < 2; hand++ {
+		// Both players limp and check down to the showdown.
+		actions := []PlayerAction{{Type: ActionCall}, {Type: ActionCheck}}
+		for i := 0; i < 6; i++ {
+			actions = append(actions, PlayerAction{Type: ActionCheck})
+		}
+		if end := g.PlayHand(&TestActionProvider{Actions: actions}, nil); !end.Showdown {
+			t.Fatalf("Expected hand %d to reach a showdown", hand+1)
+		}
+		g.CleanupHand()
+	}
+
+	for _, p := range g.Players {
+		if p.Stats.Hands != 2 || p.Stats.Showdowns != 2 || p.Stats.VPIPHands > 2 {
+			t.Errorf("Expected %s to have 2 hands and 2 showdowns, got %+v", p.Name, p.Stats)
+		}
+	}
+}
+
+func TestPlayerStats_SurviveSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	g.Players[0].Stats = PlayerStats{Hands: 12, VPIPHands: 9, PFRHands: 4, Bets: 7, Calls: 3, FacedBets: 5, FoldsToBet: 1, Showdowns: 4, WeakShowdowns: 2}
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("Failed to load the saved game: %v", err)
+	}
+	if loaded.Players[0].Stats != g.Players[0].Stats {
+		t.Errorf("Expected the stats %+v to be restored, got %+v", g.Players[0].Stats, loaded.Players[0].Stats)
+	}
+}
+
+func TestPlayerStats_StartAtTypicalValues(t *testing.T) {
+	var s PlayerStats
+	if s.VPIP() != typicalVPIP || s.PFR() != typicalPFR || s.Aggression() != typicalAggression ||
+		s.FoldToBet() != typicalFoldToBet || s.WeakShowdownRate() != typicalWeakShowdowns {
+		t.Errorf("Expected unknown players to have typical stats, got VPIP %.2f, PFR %.2f, AF %.2f, fold to bet %.2f, weak showdowns %.2f",
+			s.VPIP(), s.PFR(), s.Aggression(), s.FoldToBet(), s.WeakShowdownRate())
+	}
+
+	s = PlayerStats{Hands: 100, VPIPHands: 80}
+	if vpip := s.VPIP(); vpip < 0.7 || vpip > 0.8 {
+		t.Errorf("Expected a VPIP close to 80%% after 100 hands, got %.2f", vpip)
+	}
+}
+
+func TestStatsAdjustments(t *testing.T) {
+	folder := &Player{Stats: PlayerStats{FacedBets: 40, FoldsToBet: 36}}
+	station := &Player{Stats: PlayerStats{FacedBets: 40, FoldsToBet: 4}}
+	maniac := &Player{Stats: PlayerStats{Bets: 60, Calls: 10, Showdowns: 20, WeakShowdowns: 12}}
+	rock := &Player{Stats: PlayerStats{Bets: 5, Calls: 40, Showdowns: 20}}
+
+	if adj := bluffAdjustment([]*Player{folder}); adj <= 1 {
+		t.Errorf("Expected to bluff more against a player who folds a lot, got %.2f", adj)
+	}
+	if adj := bluffAdjustment([]*Player{station}); adj >= 1 {
+		t.Errorf("Expected to bluff less against a calling station, got %.2f", adj)
+	}
+	if adj := callAdjustment(maniac); adj >= 1 {
+		t.Errorf("Expected to call a maniac with less equity, got %.2f", adj)
+	}
+	if adj := callAdjustment(rock); adj <= 1 {
+		t.Errorf("Expected to call a passive player with more equity, got %.2f", adj)
+	}
+	if adj := callAdjustment(nil); adj != 1 {
+		t.Errorf("Expected no adjustment without a bettor, got %.2f", adj)
+	}
+}
+
+func TestCPUPostFlopAction_CallsBluffersLighter(t *testing.T) {
+	// Facing a 500 bet into 1000, the pot odds ask for 25% equity.
+	g, player := newPostFlopGame(500, 0.22)
+	player.Profile = &AIProfile{MinRaiseMultiplier: 2, MaxRaiseMultiplier: 3}
+	g.Aggressor = g.Players[0]
+
+	if action := g.GetCPUAction(player, rand.New(rand.NewSource(1))); action.Type != ActionFold {
+		t.Errorf("Expected a fold against an unknown bettor, got %v", action.Type)
+	}
+
+	g.Players[0].Stats = PlayerStats{Bets: 60, Calls: 10, Showdowns: 20, WeakShowdowns: 12}
+	if action := g.GetCPUAction(player, rand.New(rand.NewSource(1))); action.Type != ActionCall {
+		t.Errorf("Expected a call against a known bluffer, got %v", action.Type)
+	}
+}
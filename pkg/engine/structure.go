@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Structure is a tournament structure: the blind levels of a game and the
+// breaks between them. It is loaded from YAML; see the structures directory
+// for examples.
+type Structure struct {
+	// Name describes the structure.
+	Name string `yaml:"name" json:"name"`
+	// Levels are the blind levels and breaks, in order. The last level lasts
+	// until the game ends.
+	Levels []Level `yaml:"levels" json:"levels"`
+}
+
+// Level is a blind level or a break of a tournament structure. A level lasts
+// either a number of hands or a number of minutes.
+type Level struct {
+	// SmallBlind and BigBlind are the blinds of the level.
+	SmallBlind int `yaml:"small_blind,omitempty" json:"small_blind,omitempty"`
+	BigBlind   int `yaml:"big_blind,omitempty" json:"big_blind,omitempty"`
+	// Ante is the ante every player posts at the level, or with BigBlindAnte
+	// the ante the big blind posts for the whole table.
+	Ante int `yaml:"ante,omitempty" json:"ante,omitempty"`
+	// BigBlindAnte makes the big blind post the level's ante for the table.
+	BigBlindAnte bool `yaml:"big_blind_ante,omitempty" json:"big_blind_ante,omitempty"`
+	// Hands is the number of hands the level lasts.
+	Hands int `yaml:"hands,omitempty" json:"hands,omitempty"`
+	// Minutes is the number of minutes the level lasts.
+	Minutes float64 `yaml:"minutes,omitempty" json:"minutes,omitempty"`
+	// Break makes the level a break of Minutes minutes, during which no hands
+	// are dealt.
+	Break bool `yaml:"break,omitempty" json:"break,omitempty"`
+}
+
+// Duration returns how long a level timed in minutes lasts.
+func (l Level) Duration() time.Duration {
+	return time.Duration(l.Minutes * float64(time.Minute))
+}
+
+// LoadStructure reads a tournament structure from a YAML file and validates it.
+func LoadStructure(path string) (*Structure, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Structure
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Validate checks that the structure starts and ends with a blind level, that
+// every blind level has valid blinds, and that every level but the last has a
+// duration.
+func (s *Structure) Validate() error {
+	if len(s.Levels) == 0 {
+		return fmt.Errorf("the structure has no levels")
+	}
+	if s.Levels[0].Break || s.Levels[len(s.Levels)-1].Break {
+		return fmt.Errorf("the structure must start and end with a blind level")
+	}
+	for i, l := range s.Levels {
+		last := i == len(s.Levels)-1
+		switch {
+		case l.Hands < 0 || l.Minutes < 0:
+			return fmt.Errorf("level %d: hands and minutes cannot be negative", i+1)
+		case l.Hands > 0 && l.Minutes > 0:
+			return fmt.Errorf("level %d: a level lasts either a number of hands or a number of minutes, not both", i+1)
+		case l.Break && (l.Minutes == 0 || l.SmallBlind != 0 || l.BigBlind != 0 || l.Ante != 0 || l.BigBlindAnte):
+			return fmt.Errorf("level %d: a break needs minutes and has no blinds or ante", i+1)
+		case l.Break:
+			continue
+		case l.SmallBlind <= 0 || l.SmallBlind >= l.BigBlind:
+			return fmt.Errorf("level %d: the small blind must be positive and smaller than the big blind, got %d/%d", i+1, l.SmallBlind, l.BigBlind)
+		case l.Ante < 0:
+			return fmt.Errorf("level %d: the ante cannot be negative, got %d", i+1, l.Ante)
+		case l.BigBlindAnte && l.Ante == 0:
+			return fmt.Errorf("level %d: a big blind ante needs an ante", i+1)
+		case !last && l.Hands == 0 && l.Minutes == 0:
+			return fmt.Errorf("level %d: every level but the last needs hands or minutes", i+1)
+		}
+	}
+	return nil
+}
+
+// SetStructure makes the blinds follow the tournament structure, starting
+// with its first level at the given time. The structure replaces the
+// BlindUpInterval.
+func (g *Game) SetStructure(s *Structure, now time.Time) {
+	g.Structure = s
+	g.BlindUpInterval = 0
+	g.LevelIndex = 0
+	g.LevelHands = 0
+	g.LevelStart = now
+	g.announcedLevel = 0
+	g.applyLevel()
+}
+
+// CurrentLevel returns the current level of the structure. It returns the
+// zero Level if the game has no structure.
+func (g *Game) CurrentLevel() Level {
+	if g.Structure == nil {
+		return Level{}
+	}
+	return g.Structure.Levels[g.LevelIndex]
+}
+
+// LevelNumber returns the number of the current blind level, counting from 1
+// and skipping breaks. During a break, it is the number of the level before
+// the break. It returns 0 if the game has no structure.
+func (g *Game) LevelNumber() int {
+	if g.Structure == nil {
+		return 0
+	}
+	number := 0
+	for _, l := range g.Structure.Levels[:g.LevelIndex+1] {
+		if !l.Break {
+			number++
+		}
+	}
+	return number
+}
+
+// LevelLeft returns how much of the current level is left: the hands left for
+// levels that last a number of hands, the time left for timed levels and
+// breaks. Both are 0 for the last level and for games without a structure.
+func (g *Game) LevelLeft(now time.Time) (hands int, left time.Duration) {
+	if g.Structure == nil || g.LevelIndex == len(g.Structure.Levels)-1 {
+		return 0, 0
+	}
+	l := g.CurrentLevel()
+	if l.Hands > 0 {
+		return max(l.Hands-g.LevelHands, 0), 0
+	}
+	return 0, max(g.LevelStart.Add(l.Duration()).Sub(now), 0)
+}
+
+// UpdateLevel moves on to the next levels of the structure once the current
+// level is over: after its number of hands, or when its time is up. Timed
+// levels follow each other without gaps, so the clock keeps running during
+// the hands that are played when a level ends.
+func (g *Game) UpdateLevel(now time.Time) {
+	if g.Structure == nil {
+		return
+	}
+	changed := false
+	for g.LevelIndex < len(g.Structure.Levels)-1 {
+		l := g.CurrentLevel()
+		end := now
+		if l.Hands > 0 {
+			if g.LevelHands < l.Hands {
+				break
+			}
+		} else {
+			end = g.LevelStart.Add(l.Duration())
+			if now.Before(end) {
+				break
+			}
+		}
+		g.LevelIndex++
+		g.LevelHands = 0
+		g.LevelStart = end
+		changed = true
+	}
+	if changed {
+		g.applyLevel()
+	}
+}
+
+// BreakLeft moves on to the next levels of the structure if the current one is
+// over and returns the time left of the break the game is on, or 0 if it is
+// not on a break.
+func (g *Game) BreakLeft(now time.Time) time.Duration {
+	g.UpdateLevel(now)
+	if !g.CurrentLevel().Break {
+		return 0
+	}
+	_, left := g.LevelLeft(now)
+	return max(left, time.Nanosecond) // The break is on until UpdateLevel ends it.
+}
+
+// EndBreak ends the break the game is on, if any, and starts the next level.
+func (g *Game) EndBreak(now time.Time) {
+	if !g.CurrentLevel().Break {
+		return
+	}
+	g.LevelIndex++
+	g.LevelHands = 0
+	g.LevelStart = now
+	g.applyLevel()
+}
+
+// applyLevel sets the blinds and ante of the current level. Breaks keep those
+// of the level before them.
+func (g *Game) applyLevel() {
+	l := g.CurrentLevel()
+	if l.Break {
+		return
+	}
+	g.SmallBlind = l.SmallBlind
+	g.BigBlind = l.BigBlind
+	g.Ante = l.Ante
+	g.BigBlindAnte = l.BigBlindAnte
+}
+
+// startLevelHand updates the level before a hand is dealt and counts the hand
+// at its level. Breaks end right away, so tables that do not pause for them
+// play through them. It returns a BlindEvent when the hand is the first of a
+// new level.
+func (g *Game) startLevelHand(now time.Time) *BlindEvent {
+	g.UpdateLevel(now)
+	g.EndBreak(now)
+	g.LevelHands++
+	if g.LevelIndex == g.announcedLevel {
+		return nil
+	}
+	g.announcedLevel = g.LevelIndex
+	event := g.blindEvent()
+	event.Level = g.LevelNumber()
+	return event
+}
@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestStructure returns a structure with a level of 2 hands, a 10-minute
+// level, a 5-minute break and a last level.
+func newTestStructure() *Structure {
+	return &Structure{Name: "Test", Levels: []Level{
+		{SmallBlind: 50, BigBlind: 100, Hands: 2},
+		{SmallBlind: 100, BigBlind: 200, Minutes: 10},
+		{Break: true, Minutes: 5},
+		{SmallBlind: 200, BigBlind: 400, Ante: 50},
+	}}
+}
+
+func TestLoadStructure(t *testing.T) {
+	for _, name := range []string{"turbo", "standard"} {
+		if _, err := LoadStructure(filepath.Join("../../structures", name+".yml")); err != nil {
+			t.Errorf("Failed to load the %s structure: %v", name, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.yml")
+	data := "name: Bad\nlevels:\n  - small_blind: 100\n    big_blind: 50\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write structure: %v", err)
+	}
+	if _, err := LoadStructure(path); err == nil || !strings.Contains(err.Error(), "level 1") {
+		t.Errorf("Expected an error about level 1, got %v", err)
+	}
+}
+
+func TestStructure_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		levels []Level
+	}{
+		{name: "No levels"},
+		{name: "Starts with a break", levels: []Level{{Break: true, Minutes: 5}, {SmallBlind: 50, BigBlind: 100}}},
+		{name: "Ends with a break", levels: []Level{{SmallBlind: 50, BigBlind: 100, Hands: 5}, {Break: true, Minutes: 5}}},
+		{name: "Hands and minutes", levels: []Level{{SmallBlind: 50, BigBlind: 100, Hands: 5, Minutes: 5}, {SmallBlind: 100, BigBlind: 200}}},
+		{name: "No duration", levels: []Level{{SmallBlind: 50, BigBlind: 100}, {SmallBlind: 100, BigBlind: 200}}},
+		{name: "Break without minutes", levels: []Level{{SmallBlind: 50, BigBlind: 100, Hands: 5}, {Break: true, Hands: 5}, {SmallBlind: 100, BigBlind: 200}}},
+		{name: "Negative ante", levels: []Level{{SmallBlind: 50, BigBlind: 100, Ante: -1}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Structure{Levels: tc.levels}
+			if err := s.Validate(); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+	if err := newTestStructure().Validate(); err != nil {
+		t.Errorf("Expected the test structure to be valid, got %v", err)
+	}
+}
+
+func TestGame_LevelsFollowStructure(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	start := time.Now()
+	g.SetStructure(newTestStructure(), start)
+	if g.SmallBlind != 50 || g.BigBlind != 100 || g.LevelNumber() != 1 {
+		t.Fatalf("Expected level 1 at 50/100, got level %d at %d/%d", g.LevelNumber(), g.SmallBlind, g.BigBlind)
+	}
+
+	// The first level lasts 2 hands.
+	for hand := 1; hand <= 2; hand++ {
+		if event := g.startLevelHand(start); event != nil {
+			t.Errorf("Expected no blind event in hand %d, got %+v", hand, event)
+		}
+	}
+	if hands, _ := g.LevelLeft(start); hands != 0 {
+		t.Errorf("Expected no hands left at level 1, got %d", hands)
+	}
+	event := g.startLevelHand(start)
+	if event == nil || event.Level != 2 || event.SmallBlind != 100 || event.BigBlind != 200 {
+		t.Fatalf("Expected level 2 at 100/200 to be announced, got %+v", event)
+	}
+
+	// The second level lasts 10 minutes, then the break starts.
+	if _, left := g.LevelLeft(start.Add(4 * time.Minute)); left != 6*time.Minute {
+		t.Errorf("Expected 6 minutes left at level 2, got %v", left)
+	}
+	if left := g.BreakLeft(start.Add(9 * time.Minute)); left != 0 {
+		t.Errorf("Expected no break before level 2 is over, got %v", left)
+	}
+	if left := g.BreakLeft(start.Add(12 * time.Minute)); left != 3*time.Minute {
+		t.Errorf("Expected 3 minutes of the break left, got %v", left)
+	}
+	if g.LevelNumber() != 2 || g.BigBlind != 200 {
+		t.Errorf("Expected the break to keep level 2's number and blinds, got level %d at %d/%d", g.LevelNumber(), g.SmallBlind, g.BigBlind)
+	}
+
+	// The break is over after 5 minutes; the last level lasts until the end.
+	event = g.startLevelHand(start.Add(15 * time.Minute))
+	if event == nil || event.Level != 3 || event.BigBlind != 400 {
+		t.Fatalf("Expected level 3 at 200/400 to be announced, got %+v", event)
+	}
+	if hands, left := g.LevelLeft(start.Add(time.Hour)); hands != 0 || left != 0 {
+		t.Errorf("Expected the last level to last until the end, got %d hands and %v left", hands, left)
+	}
+	if g.BreakLeft(start.Add(time.Hour)) != 0 || g.LevelIndex != 3 {
+		t.Errorf("Expected to stay at the last level, got level index %d", g.LevelIndex)
+	}
+}
+
+func TestGame_TablesWithoutBreaksPlayThroughThem(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	start := time.Now()
+	g.SetStructure(newTestStructure(), start)
+	g.LevelIndex = 1
+	g.applyLevel()
+
+	event := g.startLevelHand(start.Add(11 * time.Minute))
+	if event == nil || event.Level != 3 {
+		t.Fatalf("Expected the hand to be dealt at level 3, got %+v", event)
+	}
+	if !g.LevelStart.Equal(start.Add(11 * time.Minute)) {
+		t.Errorf("Expected level 3 to start when the break was cut short, got %v", g.LevelStart.Sub(start))
+	}
+}
+
+func TestStartNewHand_AnnouncesNewLevels(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.BlindUpInterval = 1
+	g.SetStructure(newTestStructure(), time.Now())
+	if g.BlindUpInterval != 0 {
+		t.Errorf("Expected the structure to replace the blind-up interval, got %d", g.BlindUpInterval)
+	}
+
+	var events []*BlindEvent
+	for hand := 0; hand < 3; hand++ {
+		events = append(events, g.StartNewHand())
+	}
+	if events[0] != nil || events[1] != nil {
+		t.Errorf("Expected no blind events at level 1, got %+v and %+v", events[0], events[1])
+	}
+	if events[2] == nil || events[2].Level != 2 {
+		t.Errorf("Expected the third hand to start level 2, got %+v", events[2])
+	}
+	if g.BetToCall != 200 {
+		t.Errorf("Expected the big blind of level 2 to be posted, got a bet to call of %d", g.BetToCall)
+	}
+}
+
+func TestStructure_SurvivesSaveAndLoad(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.SetStructure(newTestStructure(), time.Now().Add(-3*time.Minute))
+	g.LevelIndex = 1
+	g.LevelHands = 4
+	g.applyLevel()
+	g.announcedLevel = 1
+
+	loaded, err := FromSaveData(g.ToSaveData())
+	if err != nil {
+		t.Fatalf("FromSaveData returned unexpected error: %v", err)
+	}
+	if loaded.Structure == nil || len(loaded.Structure.Levels) != 4 || loaded.LevelIndex != 1 || loaded.LevelHands != 4 {
+		t.Fatalf("Expected the structure and level to be restored, got %+v at level index %d", loaded.Structure, loaded.LevelIndex)
+	}
+	if _, left := loaded.LevelLeft(time.Now()); left < 6*time.Minute || left > 7*time.Minute {
+		t.Errorf("Expected the level clock to resume with about 7 minutes left, got %v", left)
+	}
+	if event := loaded.startLevelHand(time.Now()); event != nil {
+		t.Errorf("Expected the restored level not to be announced again, got %+v", event)
+	}
+
+	saveData := g.ToSaveData()
+	saveData.GameMetadata.LevelIndex = 4
+	if _, err := FromSaveData(saveData); err == nil {
+		t.Error("Expected an error for a level index out of range")
+	}
+}
@@ -0,0 +1,294 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"pls7-cli/pkg/poker"
+	"time"
+)
+
+// MaxTableSize is the most seats a table of a tournament can have.
+const MaxTableSize = 6
+
+// Tournament is a tournament played at several tables. Every table is a Game
+// of its own; each round, every table plays a hand. Between rounds, players
+// are moved from the tables with the most players to those with the fewest,
+// so that the tables never differ by more than one player. Tables are broken
+// as soon as the players still in fit at fewer tables, until the last players
+// meet at the final table.
+type Tournament struct {
+	// Tables are the tables still in play, in the order of their numbers.
+	Tables []*TournamentTable
+	// TableSize is the number of seats at each table.
+	TableSize int
+	// Entrants is the number of players who started the tournament.
+	Entrants int
+	// Round is the number of rounds played so far.
+	Round int
+	// Eliminations are the finishing places of the players eliminated so far,
+	// best first. EliminatedHand is the round they were eliminated in.
+	Eliminations []Standing
+	// Workers is the number of goroutines the tables' hands are played on.
+	// Values below 2 play every hand on the calling goroutine. Every table
+	// draws from its own random stream, so the results are the same for any
+	// number of workers.
+	Workers int
+	// Rand draws the seats and decides where moved players sit.
+	Rand *rand.Rand
+}
+
+// TournamentTable is a table of a tournament.
+type TournamentTable struct {
+	// Number identifies the table. Tables keep their numbers when others are
+	// broken.
+	Number int
+	// Game is the game played at the table. Empty seats hold eliminated
+	// players, or nameless ones where players have moved away.
+	Game *Game
+}
+
+// NewTournament seats CPU players with the given AI profiles, one player for
+// each profile, at as few tables of tableSize seats as they fit at, with as
+// many players at each table as possible. The seats are drawn at random.
+// Players are named "CPU 1", "CPU 2" and so on in the order of the profiles.
+// Tournaments with the same seed and profiles play out the same way.
+func NewTournament(
+	profiles []AIProfile,
+	tableSize int,
+	initialChips int,
+	smallBlind int,
+	bigBlind int,
+	rules *poker.GameRules,
+	blindUpInterval int,
+	seed int64,
+) (*Tournament, error) {
+	if len(profiles) < 2 {
+		return nil, fmt.Errorf("a tournament needs at least 2 players, got %d", len(profiles))
+	}
+	if tableSize < 2 || tableSize > MaxTableSize {
+		return nil, fmt.Errorf("a table has 2 to %d seats, got %d", MaxTableSize, tableSize)
+	}
+	if smallBlind <= 0 || smallBlind >= bigBlind {
+		return nil, fmt.Errorf("the small blind must be positive and smaller than the big blind, got %d/%d", smallBlind, bigBlind)
+	}
+	if initialChips < bigBlind {
+		return nil, fmt.Errorf("the initial chips must be at least the big blind (%d), got %d", bigBlind, initialChips)
+	}
+	for i, profile := range profiles {
+		if err := profile.Validate(); err != nil {
+			return nil, fmt.Errorf("player %d (%s): %w", i+1, profile.Name, err)
+		}
+	}
+
+	t := &Tournament{
+		TableSize: tableSize,
+		Entrants:  len(profiles),
+		Rand:      poker.NewStreamRand(seed, 0),
+	}
+	tables := (len(profiles) + tableSize - 1) / tableSize
+	for n := 1; n <= tables; n++ {
+		// NewGame seats a human named YOU in the first seat; every seat is
+		// emptied and then given to an entrant or left empty.
+		names := make([]string, tableSize)
+		names[0] = "YOU"
+		g := NewGame(names, initialChips, smallBlind, bigBlind, DifficultyMedium, rules, false, false, blindUpInterval)
+		for seat := range g.Players {
+			g.Players[seat] = emptySeat(seat)
+		}
+		g.TotalInitialChips = 0
+		g.Rand = poker.NewStreamRand(seed, n)
+		// The tables are already spread over the tournament's workers.
+		g.EquityWorkers = 1
+		t.Tables = append(t.Tables, &TournamentTable{Number: n, Game: g})
+	}
+
+	// Deal the entrants to the tables in turn, in a random order, so that the
+	// table sizes differ by one player at most.
+	for i, entrant := range t.Rand.Perm(len(profiles)) {
+		g := t.Tables[i%tables].Game
+		seat := i / tables
+		profile := profiles[entrant]
+		g.Players[seat] = &Player{Name: fmt.Sprintf("CPU %d", entrant+1), Chips: initialChips, IsCPU: true, Profile: &profile, Position: seat}
+		g.TotalInitialChips += initialChips
+	}
+	return t, nil
+}
+
+// emptySeat returns the player that holds an empty seat: a nameless CPU
+// player who has been eliminated, so no human is waited for at the table.
+func emptySeat(seat int) *Player {
+	return &Player{Position: seat, Status: PlayerStatusEliminated, IsCPU: true}
+}
+
+// SetStructure makes the blinds at every table follow the tournament
+// structure. Every table plays a hand each round, so levels that last a
+// number of hands end at the same time at every table.
+func (t *Tournament) SetStructure(s *Structure, now time.Time) {
+	for _, table := range t.Tables {
+		table.Game.SetStructure(s, now)
+	}
+}
+
+// RemainingPlayers counts the players still in the tournament.
+func (t *Tournament) RemainingPlayers() int {
+	count := 0
+	for _, table := range t.Tables {
+		count += table.Game.CountRemainingPlayers()
+	}
+	return count
+}
+
+// Finished reports whether the tournament is over: only one player is left.
+func (t *Tournament) Finished() bool {
+	return t.RemainingPlayers() <= 1
+}
+
+// Standings returns the players' places: the players still in the tournament
+// ranked by their stacks, followed by the eliminated players in finishing
+// order.
+func (t *Tournament) Standings() []Standing {
+	var players []*Player
+	for _, table := range t.Tables {
+		players = append(players, table.Game.Players...)
+	}
+	return rankStandings(players, t.Eliminations)
+}
+
+// PlayRound plays a hand at every table with two or more players, records the
+// finishing places of the players eliminated, and balances the tables. The
+// hands are played on the tournament's workers, so the provider must be safe
+// for concurrent use. It returns messages announcing the eliminations and the
+// players moved and tables broken.
+func (t *Tournament) PlayRound(provider ActionProvider) []string {
+	t.Round++
+	eliminated := make([][]*Player, len(t.Tables))
+	runParallel(len(t.Tables), t.Workers, func(i int) {
+		g := t.Tables[i].Game
+		if g.CountRemainingPlayers() < 2 {
+			return
+		}
+		g.PlayHand(provider, nil)
+		for _, p := range g.Players {
+			if p.Chips == 0 && p.IsActive() {
+				eliminated[i] = append(eliminated[i], p)
+			}
+		}
+		g.CleanupHand()
+	})
+
+	var events []string
+	var out []*Player
+	tableOf := make(map[string]int)
+	for i, players := range eliminated {
+		for _, p := range players {
+			out = append(out, p)
+			tableOf[p.Name] = t.Tables[i].Number
+		}
+	}
+	standings := placeEliminations(out, t.RemainingPlayers(), t.Round)
+	for _, s := range standings {
+		events = append(events, fmt.Sprintf("%s is eliminated at table %d and finishes in place %d.", s.Name, tableOf[s.Name], s.Place))
+	}
+	t.Eliminations = append(standings, t.Eliminations...)
+
+	if t.Finished() {
+		if standings := t.Standings(); len(standings) > 0 {
+			events = append(events, fmt.Sprintf("%s wins the tournament!", standings[0].Name))
+		}
+		return events
+	}
+	return append(events, t.balance()...)
+}
+
+// balance breaks tables while the players still in fit at fewer tables, and
+// then moves players from the table with the most players to the one with the
+// fewest until they differ by one player at most. The player moved from a
+// table is the one due to post the next big blind there. It returns messages
+// announcing the moves.
+func (t *Tournament) balance() []string {
+	var events []string
+	for len(t.Tables) > 1 && t.RemainingPlayers() <= (len(t.Tables)-1)*t.TableSize {
+		events = append(events, t.breakTable(t.smallestTable())...)
+		if len(t.Tables) == 1 {
+			events = append(events, fmt.Sprintf("Table %d is the final table.", t.Tables[0].Number))
+		}
+	}
+	for {
+		biggest, smallest := t.biggestTable(), t.smallestTable()
+		if biggest.Game.CountRemainingPlayers()-smallest.Game.CountRemainingPlayers() <= 1 {
+			break
+		}
+		events = append(events, t.movePlayer(biggest, biggest.nextBigBlindPos(), smallest))
+	}
+	return events
+}
+
+// breakTable moves the players at the table to the tables with the fewest
+// players, in seat order, and removes the table.
+func (t *Tournament) breakTable(table *TournamentTable) []string {
+	for i, other := range t.Tables {
+		if other == table {
+			t.Tables = append(t.Tables[:i], t.Tables[i+1:]...)
+			break
+		}
+	}
+	events := []string{fmt.Sprintf("Table %d is broken.", table.Number)}
+	for seat, p := range table.Game.Players {
+		if p.IsActive() {
+			events = append(events, t.movePlayer(table, seat, t.smallestTable()))
+		}
+	}
+	return events
+}
+
+// movePlayer moves the player at the given seat of one table to a random
+// empty seat at another, along with their chips. Blinds the player owed at
+// their old table are forgiven. It returns a message announcing the move.
+func (t *Tournament) movePlayer(from *TournamentTable, seat int, to *TournamentTable) string {
+	p := from.Game.Players[seat]
+	from.Game.Players[seat] = emptySeat(seat)
+	from.Game.TotalInitialChips -= p.Chips
+
+	var empty []int
+	for pos, other := range to.Game.Players {
+		if other.Status == PlayerStatusEliminated {
+			empty = append(empty, pos)
+		}
+	}
+	pos := empty[t.Rand.Intn(len(empty))]
+	p.Position = pos
+	p.MissedBlinds = 0
+	to.Game.Players[pos] = p
+	to.Game.TotalInitialChips += p.Chips
+	return fmt.Sprintf("%s moves from table %d to table %d, seat %d.", p.Name, from.Number, to.Number, pos+1)
+}
+
+// smallestTable returns the table with the fewest players, the first of them
+// if several tie.
+func (t *Tournament) smallestTable() *TournamentTable {
+	smallest := t.Tables[0]
+	for _, table := range t.Tables[1:] {
+		if table.Game.CountRemainingPlayers() < smallest.Game.CountRemainingPlayers() {
+			smallest = table
+		}
+	}
+	return smallest
+}
+
+// biggestTable returns the table with the most players, the last of them if
+// several tie.
+func (t *Tournament) biggestTable() *TournamentTable {
+	biggest := t.Tables[0]
+	for _, table := range t.Tables[1:] {
+		if table.Game.CountRemainingPlayers() >= biggest.Game.CountRemainingPlayers() {
+			biggest = table
+		}
+	}
+	return biggest
+}
+
+// nextBigBlindPos returns the seat of the player due to post the big blind in
+// the table's next hand.
+func (table *TournamentTable) nextBigBlindPos() int {
+	return table.Game.FindNextActivePlayer(table.Game.BigBlindPos)
+}
@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// cpuTournamentProvider lets the AI decide for every player.
+type cpuTournamentProvider struct{}
+
+func (cpuTournamentProvider) GetAction(g *Game, p *Player, r *rand.Rand) PlayerAction {
+	return g.GetCPUAction(p, r)
+}
+
+func newTournamentForTests(t *testing.T, entrants, tableSize int, seed int64) *Tournament {
+	t.Helper()
+	profiles := make([]AIProfile, entrants)
+	for i := range profiles {
+		profiles[i] = defaultAIConfig.Profiles["Loose-Aggressive"]
+	}
+	tournament, err := NewTournament(profiles, tableSize, 2000, 50, 100, loadRule(t, "nlh.yml"), 5, seed)
+	if err != nil {
+		t.Fatalf("NewTournament returned unexpected error: %v", err)
+	}
+	return tournament
+}
+
+// tableSizes returns the number of players at each table.
+func tableSizes(tournament *Tournament) []int {
+	var sizes []int
+	for _, table := range tournament.Tables {
+		sizes = append(sizes, table.Game.CountRemainingPlayers())
+	}
+	return sizes
+}
+
+// eliminate busts the player at the given seat of the table between hands.
+func eliminate(table *TournamentTable, seat int) {
+	p := table.Game.Players[seat]
+	table.Game.TotalInitialChips -= p.Chips
+	p.Chips = 0
+	p.Status = PlayerStatusEliminated
+}
+
+// tournamentChips returns the chips at every table and the chips every table
+// should have.
+func tournamentChips(tournament *Tournament) (chips, expected int) {
+	for _, table := range tournament.Tables {
+		g := table.Game
+		chips += g.Pot + g.HouseChips
+		for _, p := range g.Players {
+			chips += p.Chips
+		}
+		expected += g.TotalInitialChips
+	}
+	return chips, expected
+}
+
+func TestNewTournament_SeatsPlayersEvenly(t *testing.T) {
+	tournament := newTournamentForTests(t, 14, 6, 1)
+
+	if sizes := tableSizes(tournament); !reflect.DeepEqual(sizes, []int{5, 5, 4}) {
+		t.Errorf("Expected tables of 5, 5 and 4 players, got %v", sizes)
+	}
+	seen := make(map[string]bool)
+	for _, table := range tournament.Tables {
+		for _, p := range table.Game.Players {
+			if p.IsActive() && seen[p.Name] {
+				t.Errorf("Expected %s to be seated once", p.Name)
+			}
+			seen[p.Name] = p.IsActive()
+		}
+	}
+	if chips, expected := tournamentChips(tournament); chips != 14*2000 || expected != chips {
+		t.Errorf("Expected 28000 chips, got %d of %d", chips, expected)
+	}
+}
+
+func TestNewTournament_Validates(t *testing.T) {
+	profile := defaultAIConfig.Profiles["Tight-Aggressive"]
+	rules := loadRule(t, "nlh.yml")
+	if _, err := NewTournament([]AIProfile{profile}, 6, 2000, 50, 100, rules, 5, 1); err == nil {
+		t.Error("Expected an error for a single player")
+	}
+	if _, err := NewTournament([]AIProfile{profile, profile}, MaxTableSize+1, 2000, 50, 100, rules, 5, 1); err == nil {
+		t.Error("Expected an error for too many seats")
+	}
+	if _, err := NewTournament([]AIProfile{profile, profile}, 6, 50, 50, 100, rules, 5, 1); err == nil {
+		t.Error("Expected an error for stacks below the big blind")
+	}
+}
+
+func TestTournament_BalanceMovesPlayers(t *testing.T) {
+	tournament := newTournamentForTests(t, 12, 6, 1)
+	first := tournament.Tables[0]
+	for seat := 0; seat < 3; seat++ {
+		eliminate(first, seat)
+	}
+
+	events := tournament.balance()
+
+	if sizes := tableSizes(tournament); !reflect.DeepEqual(sizes, []int{4, 5}) {
+		t.Errorf("Expected tables of 4 and 5 players, got %v", sizes)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected one player to move, got %v", events)
+	}
+	if chips, expected := tournamentChips(tournament); chips != expected {
+		t.Errorf("Expected the chips to move with the player, got %d of %d", chips, expected)
+	}
+}
+
+func TestTournament_BalanceBreaksTables(t *testing.T) {
+	tournament := newTournamentForTests(t, 13, 6, 1)
+	eliminate(tournament.Tables[2], 0) // 5, 4 and 3 players fit at two tables.
+
+	events := tournament.balance()
+
+	if len(tournament.Tables) != 2 || tournament.Tables[0].Number != 1 || tournament.Tables[1].Number != 2 {
+		t.Fatalf("Expected table 3 to be broken, got %d tables", len(tournament.Tables))
+	}
+	if sizes := tableSizes(tournament); !reflect.DeepEqual(sizes, []int{6, 6}) {
+		t.Errorf("Expected tables of 6 players, got %v", sizes)
+	}
+	if events[0] != "Table 3 is broken." {
+		t.Errorf("Expected the break to be announced first, got %v", events)
+	}
+
+	for seat := 0; seat < 6; seat++ {
+		eliminate(tournament.Tables[1], seat)
+	}
+	eliminate(tournament.Tables[0], 0)
+	tournament.balance()
+
+	if len(tournament.Tables) != 1 || tournament.RemainingPlayers() != 5 {
+		t.Errorf("Expected a final table of 5 players, got %v", tableSizes(tournament))
+	}
+}
+
+func TestTournament_PlaysToTheEnd(t *testing.T) {
+	play := func(workers int) *Tournament {
+		tournament := newTournamentForTests(t, 15, 6, 7)
+		tournament.Workers = workers
+		for !tournament.Finished() {
+			if tournament.Round == 2000 {
+				t.Fatalf("Expected the tournament to end within 2000 rounds, %d players are left", tournament.RemainingPlayers())
+			}
+			tournament.PlayRound(cpuTournamentProvider{})
+			if chips, expected := tournamentChips(tournament); chips != expected {
+				t.Fatalf("Expected every chip to be accounted for after round %d, got %d of %d", tournament.Round, chips, expected)
+			}
+			sizes := tableSizes(tournament)
+			for _, size := range sizes {
+				if size > 6 || size < sizes[0]-1 || size > sizes[0]+1 {
+					t.Fatalf("Expected balanced tables after round %d, got %v", tournament.Round, sizes)
+				}
+			}
+		}
+		return tournament
+	}
+
+	tournament := play(1)
+	standings := tournament.Standings()
+	if len(standings) != 15 || len(tournament.Tables) != 1 {
+		t.Fatalf("Expected 15 players to finish at a final table, got %d at %d tables", len(standings), len(tournament.Tables))
+	}
+	if standings[0].Place != 1 || standings[0].Chips != 15*2000 {
+		t.Errorf("Expected the winner to have every chip, got %+v", standings[0])
+	}
+	for i, s := range standings[1:] {
+		if s.Place < standings[i].Place || s.EliminatedHand == 0 {
+			t.Errorf("Expected the players to be in finishing order, got %+v after %+v", s, standings[i])
+		}
+	}
+
+	if parallel := play(4); !reflect.DeepEqual(parallel.Standings(), standings) {
+		t.Error("Expected the same finishing order for any number of workers")
+	}
+}
@@ -0,0 +1,294 @@
+package engine
+
+import (
+	"fmt"
+	"pls7-cli/pkg/poker"
+	"time"
+)
+
+// SeatView is the information about a single seat that may be shown to a given
+// viewer. Hole cards are only filled in when the viewer is allowed to see them.
+type SeatView struct {
+	// Seat is the index of the player in the Game.Players slice.
+	Seat int `json:"seat"`
+	// Name is the player's name.
+	Name string `json:"name"`
+	// Chips is the player's current stack size.
+	Chips int `json:"chips"`
+	// CurrentBet is the amount the player has committed in the current betting round.
+	CurrentBet int `json:"current_bet"`
+	// TotalBetInHand is the amount the player has committed during the whole hand.
+	TotalBetInHand int `json:"total_bet_in_hand"`
+	// Status is the player's current status (Playing, Folded, AllIn, Eliminated).
+	Status PlayerStatus `json:"status"`
+	// IsCPU is true if the player is controlled by the AI.
+	IsCPU bool `json:"is_cpu"`
+	// ProfileName is the name of the CPU's AI profile. It is only revealed in dev mode.
+	ProfileName string `json:"profile_name,omitempty"`
+	// LastAction is a human-readable description of the player's last action.
+	LastAction string `json:"last_action,omitempty"`
+	// Hand holds the player's hole cards if the viewer may see them, otherwise it is empty.
+	Hand []poker.Card `json:"hand,omitempty"`
+	// TimeBankMs is the player's remaining time bank in milliseconds.
+	TimeBankMs int64 `json:"time_bank_ms,omitempty"`
+}
+
+// PublicView is a snapshot of the information about a game that every observer
+// may see: the board, the pot, the bets and the public state of each seat.
+type PublicView struct {
+	// Rules are the rules of the poker variant being played.
+	Rules poker.GameRules `json:"rules"`
+	// Difficulty is the skill level of the AI opponents.
+	Difficulty Difficulty `json:"difficulty"`
+	// DevMode reports whether the game runs in development mode.
+	DevMode bool `json:"dev_mode"`
+	// HandCount is the number of the current hand.
+	HandCount int `json:"hand_count"`
+	// Phase is the current stage of the hand.
+	Phase GamePhase `json:"phase"`
+	// Pot is the total amount of chips in the pot.
+	Pot int `json:"pot"`
+	// SmallBlind is the size of the small blind for the current hand.
+	SmallBlind int `json:"small_blind"`
+	// BigBlind is the size of the big blind for the current hand.
+	BigBlind int `json:"big_blind"`
+	// Ante is the ante for the current hand, or 0 if there is none.
+	Ante int `json:"ante,omitempty"`
+	// BigBlindAnte is true if the big blind posts the ante for the table.
+	BigBlindAnte bool `json:"big_blind_ante,omitempty"`
+	// HandType is the kind of the current hand.
+	HandType HandType `json:"hand_type,omitempty"`
+	// Level is the number of the current tournament level, or 0 if the game
+	// has no tournament structure.
+	Level int `json:"level,omitempty"`
+	// LevelHandsLeft is the number of hands left after the current one at a
+	// level that lasts a number of hands.
+	LevelHandsLeft int `json:"level_hands_left,omitempty"`
+	// LevelTimeLeftMs is the time in milliseconds left of a timed level.
+	LevelTimeLeftMs int64 `json:"level_time_left_ms,omitempty"`
+	// BetToCall is the current highest bet that players must match.
+	BetToCall int `json:"bet_to_call"`
+	// DealerPos is the seat holding the dealer button.
+	DealerPos int `json:"dealer_pos"`
+	// CurrentTurnPos is the seat whose turn it is to act.
+	CurrentTurnPos int `json:"current_turn_pos"`
+	// TimeLeftMs is the time in milliseconds the current player has left to act.
+	// It is 0 when the decision has no time limit.
+	TimeLeftMs int64 `json:"time_left_ms,omitempty"`
+	// CommunityCards are the cards dealt face-up on the board.
+	CommunityCards []poker.Card `json:"community_cards"`
+	// Boards are the boards of a game with several boards or a hand run more
+	// than once, the first being CommunityCards. It is empty if a single
+	// board was dealt once.
+	Boards [][]poker.Card `json:"boards,omitempty"`
+	// Seats holds the public state of every seat at the table.
+	Seats []SeatView `json:"seats"`
+}
+
+// LegalActions describes what the player whose turn it is may do. Folding is
+// always allowed. Bet and raise amounts are totals for the betting round, as in
+// PlayerAction.Amount.
+type LegalActions struct {
+	// CanCheck is true when the player has already matched the current bet.
+	CanCheck bool `json:"can_check"`
+	// CanCall is true when the player faces a bet.
+	CanCall bool `json:"can_call"`
+	// CallAmount is the number of chips needed to call.
+	CallAmount int `json:"call_amount"`
+	// CanBet is true when the player may open the betting round.
+	CanBet bool `json:"can_bet"`
+	// CanRaise is true when the player has enough chips to make a valid raise.
+	CanRaise bool `json:"can_raise"`
+	// MinAmount is the minimum total amount for a bet or raise.
+	MinAmount int `json:"min_amount"`
+	// MaxAmount is the maximum total amount for a bet or raise.
+	MaxAmount int `json:"max_amount"`
+}
+
+// PlayerView is a snapshot of the game from the perspective of one seat. It adds
+// the viewer's own hole cards and, on the viewer's turn, the legal actions to the
+// public information. It can be serialized to JSON for remote frontends.
+type PlayerView struct {
+	PublicView
+	// Seat is the viewer's seat, or -1 for a spectator.
+	Seat int `json:"seat"`
+	// Hand is the viewer's own hole cards.
+	Hand []poker.Card `json:"hand,omitempty"`
+	// CanShowOuts reports whether the outs helper should be displayed for the viewer.
+	CanShowOuts bool `json:"can_show_outs"`
+	// LegalActions is set only when it is the viewer's turn to act.
+	LegalActions *LegalActions `json:"legal_actions,omitempty"`
+}
+
+// PublicView builds the snapshot of the game that every observer may see. Hole
+// cards are only included once they have been shown down (or in dev mode).
+func (g *Game) PublicView() PublicView {
+	return g.publicView(-1, g.DevMode)
+}
+
+// ViewFor builds the snapshot of the game for the player at the given seat. The
+// viewer sees their own hole cards; the other hole cards stay hidden unless they
+// have been shown down. A seat outside the table yields a spectator view.
+func (g *Game) ViewFor(seat int) *PlayerView {
+	return g.viewFor(seat, g.DevMode)
+}
+
+// StrictViewFor builds the snapshot of the game for the player at the given
+// seat like ViewFor, but without what dev mode reveals: the other players'
+// hole cards and AI profiles stay hidden even in dev mode. It is meant for
+// programs playing a seat, which must not see more than the player would.
+func (g *Game) StrictViewFor(seat int) *PlayerView {
+	return g.viewFor(seat, false)
+}
+
+// viewFor builds the snapshot of the game for the player at the given seat,
+// revealing every hole card and AI profile if revealAll is set.
+func (g *Game) viewFor(seat int, revealAll bool) *PlayerView {
+	if seat < 0 || seat >= len(g.Players) {
+		return &PlayerView{PublicView: g.publicView(-1, revealAll), Seat: -1}
+	}
+
+	p := g.Players[seat]
+	view := &PlayerView{
+		PublicView:  g.publicView(seat, revealAll),
+		Seat:        seat,
+		Hand:        append([]poker.Card(nil), p.Hand...),
+		CanShowOuts: g.CanShowOuts(p),
+	}
+	if seat == g.CurrentTurnPos && p.Status == PlayerStatusPlaying && g.Phase < PhaseShowdown {
+		legal := g.LegalActions(p)
+		view.LegalActions = &legal
+	}
+	return view
+}
+
+// LegalActions calculates the actions available to the given player, who is
+// expected to be the player whose turn it is.
+func (g *Game) LegalActions(p *Player) LegalActions {
+	amountToCall := g.BetToCall - p.CurrentBet
+	legal := LegalActions{
+		CanCheck:   amountToCall == 0,
+		CanCall:    amountToCall != 0,
+		CallAmount: amountToCall,
+	}
+	legal.MinAmount, legal.MaxAmount = g.CalculateBettingLimits()
+	if legal.CanCheck {
+		legal.CanBet = p.Chips > 0
+	} else {
+		// Only allow a raise if the player has enough chips to make a valid raise.
+		legal.CanRaise = p.Chips > amountToCall && p.CurrentBet+p.Chips >= legal.MinAmount
+	}
+	return legal
+}
+
+// Validate checks that the action is one of the legal actions, including the
+// bet or raise amount, and returns a descriptive error if it is not.
+func (l LegalActions) Validate(action PlayerAction) error {
+	switch action.Type {
+	case ActionFold:
+		return nil
+	case ActionCheck:
+		if !l.CanCheck {
+			return fmt.Errorf("cannot check when facing a bet of %d", l.CallAmount)
+		}
+	case ActionCall:
+		if !l.CanCall {
+			return fmt.Errorf("there is no bet to call")
+		}
+	case ActionBet, ActionRaise:
+		if action.Type == ActionBet && !l.CanBet {
+			return fmt.Errorf("cannot bet now")
+		}
+		if action.Type == ActionRaise && !l.CanRaise {
+			return fmt.Errorf("cannot raise now")
+		}
+		if action.Amount < l.MinAmount || action.Amount > l.MaxAmount {
+			return fmt.Errorf("amount %d is outside the allowed range %d-%d", action.Amount, l.MinAmount, l.MaxAmount)
+		}
+	default:
+		return fmt.Errorf("unknown action type: %d", int(action.Type))
+	}
+	return nil
+}
+
+// ValidateAction checks that it is the given player's turn and that the action
+// is legal for them. Frontends receiving actions from untrusted sources (network
+// clients, bots) should validate them before passing them to ProcessAction.
+func (g *Game) ValidateAction(p *Player, action PlayerAction) error {
+	if g.Phase >= PhaseShowdown || g.CurrentTurnPos < 0 || g.CurrentTurnPos >= len(g.Players) {
+		return fmt.Errorf("no betting round is in progress")
+	}
+	if g.CurrentPlayer() != p {
+		return fmt.Errorf("it is not %s's turn", p.Name)
+	}
+	if p.Status != PlayerStatusPlaying {
+		return fmt.Errorf("%s cannot act with status %s", p.Name, p.Status)
+	}
+	return g.LegalActions(p).Validate(action)
+}
+
+// DefaultAction is the action taken on behalf of a player who cannot decide,
+// e.g. a disconnected client or an unresponsive bot: check if possible,
+// otherwise fold.
+func (g *Game) DefaultAction(p *Player) PlayerAction {
+	if p.CurrentBet == g.BetToCall {
+		return PlayerAction{Type: ActionCheck}
+	}
+	return PlayerAction{Type: ActionFold}
+}
+
+// publicView builds the public snapshot, additionally revealing the hole cards of
+// the given viewer seat, or every hole card and AI profile if revealAll is set.
+func (g *Game) publicView(viewer int, revealAll bool) PublicView {
+	view := PublicView{
+		Rules:          *g.Rules,
+		Difficulty:     g.Difficulty,
+		DevMode:        g.DevMode,
+		HandCount:      g.HandCount,
+		Phase:          g.Phase,
+		Pot:            g.Pot,
+		SmallBlind:     g.SmallBlind,
+		BigBlind:       g.BigBlind,
+		Ante:           g.Ante,
+		BigBlindAnte:   g.BigBlindAnte,
+		HandType:       g.HandType,
+		BetToCall:      g.BetToCall,
+		DealerPos:      g.DealerPos,
+		CurrentTurnPos: g.CurrentTurnPos,
+		TimeLeftMs:     g.TimeLeft().Milliseconds(),
+		CommunityCards: append([]poker.Card{}, g.CommunityCards...),
+		Seats:          make([]SeatView, len(g.Players)),
+		Level:          g.LevelNumber(),
+	}
+	for _, board := range g.Boards {
+		view.Boards = append(view.Boards, append([]poker.Card{}, board...))
+	}
+	handsLeft, timeLeft := g.LevelLeft(time.Now())
+	view.LevelHandsLeft = handsLeft
+	view.LevelTimeLeftMs = timeLeft.Milliseconds()
+
+	shownDown := g.Phase >= PhaseShowdown && g.CountNonFoldedPlayers() > 1
+	for i, p := range g.Players {
+		seat := SeatView{
+			Seat:           i,
+			Name:           p.Name,
+			Chips:          p.Chips,
+			CurrentBet:     p.CurrentBet,
+			TotalBetInHand: p.TotalBetInHand,
+			Status:         p.Status,
+			IsCPU:          p.IsCPU,
+			LastAction:     p.LastActionDesc,
+			TimeBankMs:     p.TimeBank.Milliseconds(),
+		}
+		if revealAll && p.Profile != nil {
+			seat.ProfileName = p.Profile.Name
+		}
+
+		inHand := p.Status == PlayerStatusPlaying || p.Status == PlayerStatusAllIn
+		if i == viewer || revealAll || (shownDown && inHand) {
+			seat.Hand = append([]poker.Card(nil), p.Hand...)
+		}
+		view.Seats[i] = seat
+	}
+	return view
+}
@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestViewFor_HidesOtherHoleCards(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.DevMode = false // Dev mode reveals every hand.
+
+	view := g.ViewFor(1)
+	if view.Seat != 1 {
+		t.Fatalf("Expected view for seat 1, got %d", view.Seat)
+	}
+	if len(view.Hand) != 3 || len(view.Seats[1].Hand) != 3 {
+		t.Errorf("Expected the viewer to see their own 3 hole cards, got %v / %v", view.Hand, view.Seats[1].Hand)
+	}
+	if len(view.Seats[0].Hand) != 0 || len(view.Seats[2].Hand) != 0 {
+		t.Errorf("Expected other players' hole cards to be hidden, got %v and %v", view.Seats[0].Hand, view.Seats[2].Hand)
+	}
+	if view.Seats[1].ProfileName != "" {
+		t.Errorf("Expected AI profile names to be hidden outside dev mode, got %q", view.Seats[1].ProfileName)
+	}
+
+	spectator := g.ViewFor(-1)
+	for _, seat := range spectator.Seats {
+		if len(seat.Hand) != 0 {
+			t.Errorf("Expected spectator not to see %s's hole cards", seat.Name)
+		}
+	}
+}
+
+func TestStrictViewFor_HidesHoleCardsInDevMode(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.DevMode = true
+
+	if view := g.ViewFor(1); len(view.Seats[2].Hand) != 3 {
+		t.Fatalf("Expected dev mode to reveal CPU2's hole cards, got %v", view.Seats[2].Hand)
+	}
+	view := g.StrictViewFor(1)
+	if len(view.Hand) != 3 || len(view.Seats[0].Hand) != 0 || len(view.Seats[2].Hand) != 0 {
+		t.Errorf("Expected only the viewer's own hole cards, got %v, %v and %v", view.Hand, view.Seats[0].Hand, view.Seats[2].Hand)
+	}
+	if view.Seats[2].ProfileName != "" {
+		t.Errorf("Expected AI profile names to be hidden, got %q", view.Seats[2].ProfileName)
+	}
+}
+
+func TestViewFor_RevealsHandsAtShowdown(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.DevMode = false
+	g.Players[2].Status = PlayerStatusFolded
+	g.Phase = PhaseShowdown
+
+	view := g.PublicView()
+	if len(view.Seats[0].Hand) != 3 || len(view.Seats[1].Hand) != 3 {
+		t.Errorf("Expected hands still in the pot to be shown down, got %v and %v", view.Seats[0].Hand, view.Seats[1].Hand)
+	}
+	if len(view.Seats[2].Hand) != 0 {
+		t.Errorf("Expected folded hand to stay hidden, got %v", view.Seats[2].Hand)
+	}
+}
+
+func TestViewFor_LegalActionsOnlyOnTurn(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	turn := g.CurrentTurnPos
+	view := g.ViewFor(turn)
+	if view.LegalActions == nil {
+		t.Fatal("Expected legal actions for the player whose turn it is")
+	}
+	legal := view.LegalActions
+	if legal.CanCheck || !legal.CanCall || legal.CallAmount != 1000 {
+		t.Errorf("Expected to face a call of 1000, got %+v", legal)
+	}
+	if !legal.CanRaise || legal.MinAmount != 2000 {
+		t.Errorf("Expected a legal raise to at least 2000, got %+v", legal)
+	}
+
+	other := g.ViewFor((turn + 1) % len(g.Players))
+	if other.LegalActions != nil {
+		t.Errorf("Expected no legal actions for a player who is not on turn, got %+v", other.LegalActions)
+	}
+}
+
+func TestPlayerView_JSONRoundTrip(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.DevMode = false
+
+	data, err := json.Marshal(g.ViewFor(0))
+	if err != nil {
+		t.Fatalf("Failed to marshal view: %v", err)
+	}
+	var decoded PlayerView
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal view: %v", err)
+	}
+	if decoded.Pot != g.Pot || decoded.Seats[1].Name != "CPU1" || len(decoded.Hand) != 3 {
+		t.Errorf("Decoded view does not match the game: %+v", decoded)
+	}
+	if len(decoded.Seats[1].Hand) != 0 {
+		t.Errorf("Expected opponent's hole cards to stay hidden after round trip, got %v", decoded.Seats[1].Hand)
+	}
+}
@@ -67,6 +67,20 @@ func NewRand(seed int64) *rand.Rand {
 	return rand.New(rand.NewSource(seed))
 }
 
+// NewStreamRand creates a random number generator for one of several
+// independent streams derived from the same seed. Work split into numbered
+// parts, each with its own stream, gives the same results however the parts
+// are spread over goroutines.
+func NewStreamRand(seed int64, stream int) *rand.Rand {
+	// Mix the seed and the stream number with SplitMix64, so that neighboring
+	// seeds and streams give unrelated sequences.
+	z := uint64(seed) + uint64(stream+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return rand.New(rand.NewSource(int64(z)))
+}
+
 // NewRandWithTime creates a new random number generator with a time-based seed.
 // This function is used for normal gameplay with unpredictable randomness.
 func NewRandWithTime() *rand.Rand {
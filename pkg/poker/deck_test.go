@@ -37,3 +37,16 @@ func TestDeck_DealForDebug(t *testing.T) {
 		}
 	}
 }
+
+// TestNewStreamRand checks that streams are reproducible and independent of each other.
+func TestNewStreamRand(t *testing.T) {
+	if a, b := NewStreamRand(1, 0).Int63(), NewStreamRand(1, 0).Int63(); a != b {
+		t.Errorf("Expected the same seed and stream to give the same numbers, got %d and %d", a, b)
+	}
+	first := NewStreamRand(1, 0).Int63()
+	for _, other := range []*rand.Rand{NewStreamRand(1, 1), NewStreamRand(2, 0)} {
+		if n := other.Int63(); n == first {
+			t.Errorf("Expected different streams to give different numbers, both gave %d", n)
+		}
+	}
+}
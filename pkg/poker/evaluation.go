@@ -547,8 +547,6 @@ func findStraightFlush(analysis *handAnalysis) ([]Card, bool) {
 // findSkipStraight checks for a Skip Straight. This is a special PLS7 hand
 // with a specific gapped sequence of 5 cards (e.g., K-J-9-7-5).
 func findSkipStraight(analysis *handAnalysis) ([]Card, bool) {
-	logrus.Tracef("findSkipStraight: Analyzing handAnalysis: %+v", analysis)
-
 	uniqueRanksAceHigh := make([]Rank, 0)
 	seenRanks := make(map[Rank]bool)
 	hasAce := false
@@ -567,21 +565,15 @@ func findSkipStraight(analysis *handAnalysis) ([]Card, bool) {
 
 	// If Ace is present, create a second list treating Ace as 1 (for low-end straights)
 	if hasAce {
-		logrus.Tracef("findSkipStraight: Ace found, creating alternative rank list treating Ace as 1.")
 		uniqueRanksAceLow := make([]Rank, 0)
 		uniqueRanksAceLow = append(uniqueRanksAceLow, uniqueRanksAceHigh[1:]...) // Copy all except Ace
 		uniqueRanksAceLow = append(uniqueRanksAceLow, uniqueRanksAceHigh[0])     // Add Ace at the end
 		listOfUniqueRanks = append(listOfUniqueRanks, uniqueRanksAceLow)
 	}
-	logrus.Tracef("findSkipStraight: listOfUniqueRanks: %+v", listOfUniqueRanks)
 
 	for _, uniqueRanks := range listOfUniqueRanks {
 		// In PLS7, a Skip Straight's highest card must be 9 or greater.
 		if len(uniqueRanks) > 0 && uniqueRanks[0] < 9 {
-			logrus.Tracef(
-				"findSkipStraight: Skipping analysis for uniqueRanks starting with %v, as it is less than 9.",
-				uniqueRanks[0],
-			)
 			continue // Skip analysis if the highest rank is less than 9
 		}
 		for i := 0; i <= len(uniqueRanks)-5; i++ {
@@ -590,7 +582,6 @@ func findSkipStraight(analysis *handAnalysis) ([]Card, bool) {
 			// Only biggest is an odd number, smallest less than Two can be treated as Ace
 			if smallest < Two && biggest%2 == 1 {
 				smallest = Ace
-				logrus.Tracef("findSkipStraight: Adjusting smallest rank to Ace as it is less than Two and biggest is an odd number.")
 			}
 			possibleSkipStraight := []Rank{
 				uniqueRanks[i],
@@ -599,26 +590,18 @@ func findSkipStraight(analysis *handAnalysis) ([]Card, bool) {
 				uniqueRanks[i] - 6,
 				smallest,
 			}
-			logrus.Tracef("findSkipStraight: Checking possible Skip Straight: %v", possibleSkipStraight)
 			isSkipStraight := true
 			for _, c := range possibleSkipStraight {
 				if !containsRank(uniqueRanks, c) {
 					isSkipStraight = false
-					logrus.Tracef(
-						"findSkipStraight: Rank %v not found in uniqueRanks: %v, setting isSkipStraight to false.",
-						c, uniqueRanks,
-					)
 					break
 				}
 			}
 			if isSkipStraight {
-				topRank := uniqueRanks[i]
-				logrus.Tracef("findSkipStraight: Found Skip Straight! topRank: %v, ranks: %v", topRank, possibleSkipStraight)
 				return findCardsForStraight(analysis.cards, possibleSkipStraight), true
 			}
 		}
 	}
-	logrus.Tracef("findSkipStraight: No Skip Straight found.")
 	return nil, false
 }
 
@@ -62,7 +62,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < SkipStraightFlush {
 		if hasDraw, outs := hasSkipStraightFlushDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[SkipStraightFlush] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -73,7 +72,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < StraightFlush {
 		if hasDraw, outs := hasStraightFlushDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[StraightFlush] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -84,7 +82,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < FourOfAKind {
 		if hasDraw, outs := hasFourOfAKindDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[FourOfAKind] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -95,7 +92,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < FullHouse {
 		if hasDraw, outs := hasFullHouseDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[FullHouse] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -106,7 +102,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < Flush {
 		if hasDraw, outs := hasFlushDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[Flush] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -117,7 +112,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < SkipStraight {
 		if hasDraw, outs := hasSkipStraightDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[SkipStraight] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -128,7 +122,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < Straight {
 		if hasDraw, outs := hasStraightDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[Straight] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -139,7 +132,6 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand.Rank < ThreeOfAKind {
 		if hasDraw, outs := hasThreeOfAKindDraw(holeCards, communityCards, seenCards); hasDraw {
 			outsInfo.OutsPerHandRank[ThreeOfAKind] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -147,13 +139,10 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	}
 
 	// --- Low Hand ---
-	logrus.Tracef("CalculateOuts: Checking for low hands draws, lowGameEnabled: %v", gameRules.LowHand.Enabled)
 	if gameRules.LowHand.Enabled {
-		logrus.Debugf("CalculateOuts: Low game enabled, checking for low hand draws")
 		if hasDraw, outs := hasLowHandDraw(holeCards, communityCards, seenCards, Rank(gameRules.LowHand.MaxRank)); hasDraw {
 			// Note: Low hand outs are stored under HighCard rank for simplicity.
 			outsInfo.OutsPerHandRank[HighCard] = outs
-			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
 				allOutsMap[out] = true
 			}
@@ -298,7 +287,6 @@ func hasFlushDraw(holeCards []Card, communityCards []Card, seenCards map[Card]bo
 	for _, c := range pool {
 		suitCounts[c.Suit]++
 	}
-	logrus.Debugf("hasFlushDraw: Suit counts: %+v", suitCounts)
 
 	for suit, count := range suitCounts {
 		if count == 4 {
@@ -308,10 +296,8 @@ func hasFlushDraw(holeCards []Card, communityCards []Card, seenCards map[Card]bo
 				outCard := Card{Suit: suit, Rank: r}
 				if !seenCards[outCard] {
 					outs = append(outs, outCard)
-					logrus.Debugf("hasFlushDraw: Found flush draw out: %v, current outs: %v", outCard, outs)
 				}
 			}
-			logrus.Debugf("hasFlushDraw: Final outs for flush draw: %v", outs)
 			return true, outs
 		}
 	}
@@ -327,7 +313,6 @@ func hasStraightDraw(holeCards []Card, communityCards []Card, seenCards map[Card
 	for _, c := range pool {
 		uniqueRanks[c.Rank] = true
 	}
-	logrus.Debugf("hasStraightDraw: Unique ranks in pool: %+v", uniqueRanks)
 
 	var outs []Card
 	// Iterate through all possible ranks to see if adding one completes a straight.
@@ -351,7 +336,6 @@ func hasStraightDraw(holeCards []Card, communityCards []Card, seenCards map[Card
 						outCard := Card{Rank: r, Suit: s}
 						if !seenCards[outCard] {
 							outs = append(outs, outCard)
-							logrus.Debugf("hasStraightDraw: Found straight draw out: %v, current outs: %v", outCard, outs)
 						}
 					}
 				}
@@ -359,7 +343,6 @@ func hasStraightDraw(holeCards []Card, communityCards []Card, seenCards map[Card
 		}
 	}
 
-	logrus.Debugf("hasStraightDraw: Final outs for straight draw: %v", outs)
 	return len(outs) > 0, outs
 }
 
@@ -370,7 +353,6 @@ func hasSkipStraightDraw(holeCards []Card, communityCards []Card, seenCards map[
 	for _, c := range pool {
 		uniqueRanks[c.Rank] = true
 	}
-	logrus.Debugf("hasSkipStraightDraw: Unique ranks in pool: %+v", uniqueRanks)
 
 	var outs []Card
 	// Iterate through all possible ranks to see if adding one completes a skip straight.
@@ -394,7 +376,6 @@ func hasSkipStraightDraw(holeCards []Card, communityCards []Card, seenCards map[
 						outCard := Card{Rank: r, Suit: s}
 						if !seenCards[outCard] {
 							outs = append(outs, outCard)
-							logrus.Debugf("hasSkipStraightDraw: Found skip straight draw out: %v, current outs: %v", outCard, outs)
 						}
 					}
 				}
@@ -402,7 +383,6 @@ func hasSkipStraightDraw(holeCards []Card, communityCards []Card, seenCards map[
 		}
 	}
 
-	logrus.Debugf("hasSkipStraightDraw: Final outs for skip straight draw: %v", outs)
 	return len(outs) > 0, outs
 }
 
@@ -411,14 +391,12 @@ func hasSkipStraightDraw(holeCards []Card, communityCards []Card, seenCards map[
 func hasThreeOfAKindDraw(holeCards []Card, communityCards []Card, seenCards map[Card]bool) (bool, []Card) {
 	ppFound, ppRank := findPocketPair(holeCards)
 	if !ppFound {
-		logrus.Debugf("hasThreeOfAKindDraw: No pocket pair found in hole cards: %v", holeCards)
 		return false, nil // Must have a pocket pair to have a "trips draw".
 	}
 
 	// Check if trips are already made.
 	for _, c := range communityCards {
 		if c.Rank == ppRank {
-			logrus.Debugf("hasThreeOfAKindDraw: Found a community card with rank %v, trips made already", c.Rank)
 			return false, nil // Trips already exist.
 		}
 	}
@@ -429,10 +407,6 @@ func hasThreeOfAKindDraw(holeCards []Card, communityCards []Card, seenCards map[
 		outCard := Card{Rank: ppRank, Suit: suit}
 		if !seenCards[outCard] {
 			outs = append(outs, outCard)
-			logrus.Debugf(
-				"hasThreeOfAKindDraw: Found trips draw out: %v, current outs: %v, pool: %v",
-				outCard, outs, append(holeCards, communityCards...),
-			)
 		}
 	}
 
@@ -453,7 +427,6 @@ func hasFullHouseDraw(holeCards []Card, communityCards []Card, seenCards map[Car
 	for _, c := range pool {
 		rankCounts[c.Rank]++
 	}
-	logrus.Debugf("hasFullHouseDraw: Unique rank counts in pool: %+v", rankCounts)
 
 	var outs []Card
 	switch currentHand.Rank {
@@ -471,10 +444,6 @@ func hasFullHouseDraw(holeCards []Card, communityCards []Card, seenCards map[Car
 				outCard := Card{Rank: rank, Suit: s}
 				if !seenCards[outCard] {
 					outs = append(outs, outCard)
-					logrus.Debugf(
-						"hasFullHouseDraw: Found full house draw out: %v, current outs: %v, pool: %v",
-						outCard, outs, pool,
-					)
 				}
 			}
 		}
@@ -493,11 +462,6 @@ func hasFullHouseDraw(holeCards []Card, communityCards []Card, seenCards map[Car
 			}
 		}
 	default:
-		logrus.Debugf(
-			"hasFullHouseDraw: current hand rank is not suitable for full house draw: %v, "+
-				"holeCards: %v, communityCards: %v, handRank: %v",
-			currentHand, holeCards, communityCards, currentHand.Rank,
-		)
 		return false, nil // Must have trips or two pair to have a full house draw.
 	}
 
@@ -509,7 +473,6 @@ func hasFullHouseDraw(holeCards []Card, communityCards []Card, seenCards map[Car
 func hasFourOfAKindDraw(holeCards []Card, communityCards []Card, seenCards map[Card]bool) (bool, []Card) {
 	currentHand, _ := EvaluateHand(holeCards, communityCards, &GameRules{HandRankings: HandRankingsRules{UseStandardRankings: true}})
 	if currentHand == nil || currentHand.Rank != ThreeOfAKind {
-		logrus.Debugf("hasFourOfAKindDraw: Current hand is not trips, cannot draw four of a kind: %v", currentHand)
 		return false, nil
 	}
 
@@ -520,7 +483,6 @@ func hasFourOfAKindDraw(holeCards []Card, communityCards []Card, seenCards map[C
 		outCard := Card{Rank: tripRank, Suit: s}
 		if !seenCards[outCard] {
 			outs = append(outs, outCard)
-			logrus.Debugf("hasFourOfAKindDraw: Found four of a kind draw out: %v", outCard)
 		}
 	}
 	return len(outs) > 0, outs
@@ -545,7 +507,6 @@ func hasLowHandDraw(holeCards []Card, communityCards []Card, seenCards map[Card]
 
 	// If we have exactly 4 unique low cards, we have a draw.
 	if len(uniqueLowCards) != 4 {
-		logrus.Debugf("hasLowHandDraw: cannot draw since lowCards count is not 4: %v", usedRanks)
 		return false, nil
 	}
 
@@ -557,7 +518,6 @@ func hasLowHandDraw(holeCards []Card, communityCards []Card, seenCards map[Card]
 				outCard := Card{Rank: r, Suit: s}
 				if !seenCards[outCard] {
 					outs = append(outs, outCard)
-					logrus.Debugf("hasLowHandDraw: Found low draw out: %v, current outs: %v", outCard, outs)
 				}
 			}
 		}
@@ -568,7 +528,6 @@ func hasLowHandDraw(holeCards []Card, communityCards []Card, seenCards map[Card]
 			outCard := Card{Rank: Ace, Suit: s}
 			if !seenCards[outCard] {
 				outs = append(outs, outCard)
-				logrus.Debugf("hasLowHandDraw: Found low draw out: %v, current outs: %v", outCard, outs)
 			}
 		}
 	}
@@ -595,7 +554,6 @@ func findPocketPair(holeCards []Card) (bool, Rank) {
 			return true, c.Rank
 		}
 	}
-	logrus.Debugf("findPocketPair: Hole cards: %v, Pocket pair rank: 0", holeCards)
 	return false, 0
 }
 
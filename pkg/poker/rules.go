@@ -1,5 +1,7 @@
 package poker
 
+import "fmt"
+
 // HoleCardRules defines the rules governing the use of a player's private cards
 // (hole cards) when forming a 5-card poker hand.
 type HoleCardRules struct {
@@ -82,4 +84,24 @@ type GameRules struct {
 	HandRankings HandRankingsRules `yaml:"hand_rankings"`
 	// LowHand defines the rules for the low hand in High-Low split games.
 	LowHand LowHandRules `yaml:"low_hand"`
+	// Boards is the number of boards dealt in each hand, from 1 to MaxBoards.
+	// The pot is split evenly between the boards, and each share goes to the
+	// best hands on its board. 0 means a single board.
+	Boards int `yaml:"boards"`
+}
+
+// MaxBoards is the most boards a game may deal.
+const MaxBoards = 3
+
+// BoardCount returns the number of boards dealt in each hand.
+func (r *GameRules) BoardCount() int {
+	return max(r.Boards, 1)
+}
+
+// ValidateBoards checks that the number of boards is in range.
+func (r *GameRules) ValidateBoards() error {
+	if r.Boards < 0 || r.Boards > MaxBoards {
+		return fmt.Errorf("a game deals 1 to %d boards, got %d", MaxBoards, r.Boards)
+	}
+	return nil
 }